@@ -7,6 +7,7 @@ import (
 	"rest-api/internal/database"
 	"rest-api/internal/middlewares"
 	"rest-api/internal/routes"
+	_ "time/tzdata" // Embed database timezone agar time.LoadLocation bekerja di semua environment
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
@@ -16,6 +17,9 @@ import (
 
 func main() {
 	cfg := config.LoadConfig()
+	if err := cfg.ValidateSecrets(); err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
 
 	app := fiber.New(fiber.Config{
 		ErrorHandler: middlewares.ErrorHandler,
@@ -30,10 +34,14 @@ func main() {
 	app.Use(cors.New(cors.Config{
 		AllowOrigins: cfg.CorsOrigin,
 		AllowCredentials: true,
-		AllowHeaders: "Origin, Content-Type, Accept, Authorization",
+		AllowHeaders: "Origin, Content-Type, Accept, Authorization, X-CSRF-Token",
 		AllowMethods: "GET, POST, PUT, DELETE, OPTIONS",
+		ExposeHeaders: "RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset, RateLimit-Policy, Retry-After",
 	}))
 
+	// Rate limit global dan per route (per IP); batas per user dan per token diterapkan oleh middleware Auth
+	app.Use(middlewares.RateLimitRequests(cfg))
+
 	if err := database.Connect(cfg); err != nil {
 		log.Fatalf("Unable to connect to database: %v", err)
 	}
@@ -18,11 +18,63 @@ type Config struct {
 		DBPassword string // Database password
 		DBName     string // Database name
 		DBSSLMode  string // Database SSL mode (disable/require/verify-ca/verify-full)
-		JWTSecret  string // Secret key untuk signing JWT tokens
-		JWTExpires string // JWT expiration duration (contoh: 168h = 7 hari)
+		JWTSecret  string // Secret dasar untuk menurunkan kunci enkripsi (private key JWT, secret TOTP) jika kunci khusus kosong, wajib diisi
+		JWTExpires string // Access token (JWT) expiration duration (contoh: 15m)
+		JWTRefreshExpires string // Refresh token expiration duration (contoh: 720h = 30 hari)
+		JWTAlgorithm string // Algoritma signing access token: RS256 atau EdDSA
+		JWTKeyRotationInterval string // Interval rotasi key signing JWT (contoh: 720h = 30 hari, 0 = tanpa rotasi terjadwal)
+		JWTKeyEncryptionKey string // Kunci enkripsi private key JWT di database, kosong = diturunkan dari JWT_SECRET
+		CSRFSecret string // Kunci HMAC token CSRF, wajib diisi dan terpisah dari JWT_SECRET
 		Port       string // Port untuk aplikasi web server
 		NodeEnv    string // Environment mode (development/production)
 		CorsOrigin string // Allowed CORS origin (URL frontend)
+		TaskStatusTransitions string // Workflow status task (contoh: todo:in_progress|done;in_progress:done), kosong = default
+		SearchBackend string // Backend pencarian task: mysql (FULLTEXT) atau memory (index in-memory)
+		TrashRetention string // Lama task/project disimpan di trash sebelum dihapus permanen (contoh: 720h = 30 hari, 0 = tidak pernah)
+		AppURL string // URL frontend, dipakai untuk link di email (contoh: reset password)
+		PasswordResetExpires string // Masa berlaku token reset password (contoh: 1h)
+		EmailVerificationExpires string // Masa berlaku link verifikasi dan perubahan email (contoh: 48h)
+		TwoFactorIssuer string // Nama aplikasi yang tampil di authenticator (otpauth issuer)
+		TwoFactorEncryptionKey string // Kunci enkripsi secret TOTP di database, kosong = diturunkan dari JWT_SECRET
+		AdminEmails string // Email yang otomatis mendapat role admin, dipisah koma
+		LoginMaxAttempts string // Jumlah login gagal per akun sebelum akun dikunci sementara (0 = tanpa lockout)
+		LoginMaxAttemptsPerIP string // Jumlah login gagal per IP sebelum IP dikunci sementara (0 = tanpa lockout)
+		LoginLockoutDuration string // Lama lockout login (contoh: 15m)
+		LoginBackoffBase string // Jeda awal setelah beberapa login gagal, berlipat ganda setiap gagal (contoh: 1s)
+		LoginAttemptWindow string // Login gagal dilupakan jika tidak ada percobaan gagal lagi selama durasi ini (contoh: 1h)
+		UnverifiedAllowedActions string // Aksi yang boleh dilakukan akun dengan email belum terverifikasi: login,read,write (none = tidak ada)
+		RateLimitStore string // Penyimpanan rate limit: memory (per instance, default) atau redis (dibagi antar instance)
+		RateLimitGlobal string // Batas request per IP untuk semua endpoint, format <jumlah>/<periode>[/<burst>] (contoh: 600/1m, off = tanpa batas)
+		RateLimitUser string // Batas request per user yang login untuk semua endpoint terproteksi (contoh: 300/1m)
+		RateLimitToken string // Batas request per personal access token (contoh: 120/1m)
+		RateLimitRoutes string // Batas per route per IP, dipisah titik koma (contoh: POST /api/auth/login=10/1m;/api/tasks*=120/1m)
+		RedisAddr string // Alamat server Redis (host:port) untuk RATE_LIMIT_STORE=redis
+		RedisPassword string // Password Redis, kosong = tanpa AUTH
+		RedisDB string // Nomor database Redis (default: 0)
+		PasswordHashAlgorithm string // Algoritma hash password baru: argon2id (default) atau bcrypt; hash lama di-upgrade saat login
+		PasswordArgon2Memory string // Memori argon2id dalam KiB (default: 19456 = 19 MiB)
+		PasswordArgon2Iterations string // Jumlah iterasi argon2id (default: 2)
+		PasswordArgon2Parallelism string // Jumlah thread argon2id (default: 1)
+		PasswordBcryptCost string // Cost bcrypt, 10-31 (default: 12)
+		PasswordMinLength string // Panjang minimal password (default: 8)
+		PasswordMaxLength string // Panjang maksimal password (default: 128)
+		PasswordRequiredCharacters string // Kelas karakter wajib, dipisah koma: lower,upper,digit,symbol (kosong = tidak ada)
+		PasswordDisallowPersonalInfo string // true = tolak password yang memuat username atau email
+		PasswordBreachedList string // Path file daftar password bocor (plain text atau SHA-1 hex per baris), kosong = tanpa pengecekan
+		PasswordBreachedFalsePositiveRate string // Peluang salah positif bloom filter daftar password bocor (default: 0.001)
+		OIDCIssuer string // URL issuer OpenID provider untuk login SSO, kosong = login OIDC nonaktif
+		OIDCClientID string // Client ID aplikasi di OpenID provider
+		OIDCClientSecret string // Client secret, kosong = public client (hanya PKCE)
+		OIDCRedirectURL string // Redirect URI terdaftar di provider; halaman frontend yang meneruskan code dan state ke /api/auth/oidc/callback
+		OIDCScopes string // Scope yang diminta, dipisah spasi (openid selalu ditambahkan)
+		OIDCAutoProvision string // true = buat akun otomatis saat login pertama jika email belum terdaftar
+		MailDriver string // Pengirim email: log (default), file, atau smtp
+		MailFrom string // Alamat pengirim email
+		MailFileDir string // Folder output untuk MAIL_DRIVER=file
+		SMTPHost string // Host SMTP untuk MAIL_DRIVER=smtp
+		SMTPPort string // Port SMTP (default: 587)
+		SMTPUsername string // Username SMTP, kosong = tanpa auth
+		SMTPPassword string // Password SMTP
 }
 
 // LoadConfig membaca konfigurasi dari file .env dan environment variables
@@ -45,11 +97,63 @@ func LoadConfig() *Config {
 		DBPassword: getEnv("DB_PASSWORD", ""),
 		DBName:     getEnv("DB_NAME", "blog_db"),
 		DBSSLMode:  getEnv("DB_SSLMODE", "disable"),
-		JWTSecret:  getEnv("JWT_SECRET", "your_super_secret_jwt_key_blog_app_2025"),
-		JWTExpires: getEnv("JWT_EXPIRES_IN", "168h"),
+		JWTSecret:  getEnv("JWT_SECRET", ""),
+		JWTExpires: getEnv("JWT_EXPIRES_IN", "15m"),
+		JWTRefreshExpires: getEnv("JWT_REFRESH_EXPIRES_IN", "720h"),
+		JWTAlgorithm: getEnv("JWT_ALGORITHM", "RS256"),
+		JWTKeyRotationInterval: getEnv("JWT_KEY_ROTATION_INTERVAL", "720h"),
+		JWTKeyEncryptionKey: getEnv("JWT_KEY_ENCRYPTION_KEY", ""),
+		CSRFSecret: getEnv("CSRF_SECRET", ""),
 		Port:       getEnv("PORT", "5000"),
 		NodeEnv:    getEnv("NODE_ENV", "development"),
 		CorsOrigin: getEnv("CORS_ORIGIN", "http://localhost:3000"),
+		TaskStatusTransitions: getEnv("TASK_STATUS_TRANSITIONS", ""),
+		SearchBackend: getEnv("SEARCH_BACKEND", "mysql"),
+		TrashRetention: getEnv("TRASH_RETENTION", "720h"),
+		AppURL: getEnv("APP_URL", "http://localhost:3000"),
+		PasswordResetExpires: getEnv("PASSWORD_RESET_EXPIRES_IN", "1h"),
+		EmailVerificationExpires: getEnv("EMAIL_VERIFICATION_EXPIRES_IN", "48h"),
+		TwoFactorIssuer: getEnv("TWO_FACTOR_ISSUER", "Go Todo"),
+		TwoFactorEncryptionKey: getEnv("TWO_FACTOR_ENCRYPTION_KEY", ""),
+		AdminEmails: getEnv("ADMIN_EMAILS", ""),
+		LoginMaxAttempts: getEnv("LOGIN_MAX_ATTEMPTS", "10"),
+		LoginMaxAttemptsPerIP: getEnv("LOGIN_MAX_ATTEMPTS_PER_IP", "100"),
+		LoginLockoutDuration: getEnv("LOGIN_LOCKOUT_DURATION", "15m"),
+		LoginBackoffBase: getEnv("LOGIN_BACKOFF_BASE", "1s"),
+		LoginAttemptWindow: getEnv("LOGIN_ATTEMPT_WINDOW", "1h"),
+		UnverifiedAllowedActions: getEnv("UNVERIFIED_ALLOWED_ACTIONS", "login,read"),
+		RateLimitStore: getEnv("RATE_LIMIT_STORE", "memory"),
+		RateLimitGlobal: getEnv("RATE_LIMIT_GLOBAL", "600/1m"),
+		RateLimitUser: getEnv("RATE_LIMIT_USER", "300/1m"),
+		RateLimitToken: getEnv("RATE_LIMIT_TOKEN", "120/1m"),
+		RateLimitRoutes: getEnv("RATE_LIMIT_ROUTES", "POST /api/auth/login=20/1m;POST /api/auth/login/2fa=20/1m;POST /api/auth/register=10/1m;POST /api/auth/password/forgot=5/1m;POST /api/auth/password/reset=10/1m;POST /api/auth/email/verify=10/1m;POST /api/auth/email/resend=5/1m"),
+		RedisAddr: getEnv("REDIS_ADDR", "localhost:6379"),
+		RedisPassword: getEnv("REDIS_PASSWORD", ""),
+		RedisDB: getEnv("REDIS_DB", "0"),
+		PasswordHashAlgorithm: getEnv("PASSWORD_HASH_ALGORITHM", "argon2id"),
+		PasswordArgon2Memory: getEnv("PASSWORD_ARGON2_MEMORY", "19456"),
+		PasswordArgon2Iterations: getEnv("PASSWORD_ARGON2_ITERATIONS", "2"),
+		PasswordArgon2Parallelism: getEnv("PASSWORD_ARGON2_PARALLELISM", "1"),
+		PasswordBcryptCost: getEnv("PASSWORD_BCRYPT_COST", "12"),
+		PasswordMinLength: getEnv("PASSWORD_MIN_LENGTH", "8"),
+		PasswordMaxLength: getEnv("PASSWORD_MAX_LENGTH", "128"),
+		PasswordRequiredCharacters: getEnv("PASSWORD_REQUIRED_CHARACTERS", ""),
+		PasswordDisallowPersonalInfo: getEnv("PASSWORD_DISALLOW_PERSONAL_INFO", "true"),
+		PasswordBreachedList: getEnv("PASSWORD_BREACHED_LIST", ""),
+		PasswordBreachedFalsePositiveRate: getEnv("PASSWORD_BREACHED_FALSE_POSITIVE_RATE", "0.001"),
+		OIDCIssuer: getEnv("OIDC_ISSUER", ""),
+		OIDCClientID: getEnv("OIDC_CLIENT_ID", ""),
+		OIDCClientSecret: getEnv("OIDC_CLIENT_SECRET", ""),
+		OIDCRedirectURL: getEnv("OIDC_REDIRECT_URL", ""),
+		OIDCScopes: getEnv("OIDC_SCOPES", "openid email profile"),
+		OIDCAutoProvision: getEnv("OIDC_AUTO_PROVISION", "true"),
+		MailDriver: getEnv("MAIL_DRIVER", "log"),
+		MailFrom: getEnv("MAIL_FROM", "no-reply@localhost"),
+		MailFileDir: getEnv("MAIL_FILE_DIR", "tmp/mail"),
+		SMTPHost: getEnv("SMTP_HOST", "localhost"),
+		SMTPPort: getEnv("SMTP_PORT", "587"),
+		SMTPUsername: getEnv("SMTP_USERNAME", ""),
+		SMTPPassword: getEnv("SMTP_PASSWORD", ""),
 	}
 }
 
@@ -0,0 +1,59 @@
+package config
+
+import "fmt"
+
+// publicSecrets adalah nilai contoh yang pernah ada di repo (default lama config.go, .env, README)
+// Kunci dengan nilai ini bisa diketahui siapa saja yang membaca repo, jadi ditolak saat startup
+var publicSecrets = []string{
+	"your_super_secret_jwt_key_blog_app_2025",
+	"your_jwt_secret",
+}
+
+// JWTEncryptionKey mengembalikan kunci enkripsi private key JWT: JWT_KEY_ENCRYPTION_KEY, atau JWT_SECRET jika kosong
+func (c *Config) JWTEncryptionKey() string {
+	if c.JWTKeyEncryptionKey != "" {
+		return c.JWTKeyEncryptionKey
+	}
+	return c.JWTSecret
+}
+
+// TwoFactorKey mengembalikan kunci enkripsi secret TOTP: TWO_FACTOR_ENCRYPTION_KEY, atau JWT_SECRET jika kosong
+func (c *Config) TwoFactorKey() string {
+	if c.TwoFactorEncryptionKey != "" {
+		return c.TwoFactorEncryptionKey
+	}
+	return c.JWTSecret
+}
+
+// ValidateSecrets memastikan kunci yang melindungi data di database dan token CSRF diisi dan bukan nilai contoh yang publik,
+// dan token dari email tidak ditulis ke log di production
+// Dipanggil saat startup; aplikasi tidak boleh jalan dengan kunci yang bisa ditebak
+func (c *Config) ValidateSecrets() error {
+	if err := checkSecret("JWT_KEY_ENCRYPTION_KEY (or JWT_SECRET)", c.JWTEncryptionKey()); err != nil {
+		return err
+	}
+	if err := checkSecret("TWO_FACTOR_ENCRYPTION_KEY (or JWT_SECRET)", c.TwoFactorKey()); err != nil {
+		return err
+	}
+	if err := checkSecret("CSRF_SECRET", c.CSRFSecret); err != nil {
+		return err
+	}
+	// Mailer log dan file menulis isi email, termasuk link reset password dan verifikasi yang masih aktif
+	// Di production email wajib dikirim lewat SMTP agar token tidak tersimpan di log atau disk
+	if c.NodeEnv == "production" && c.MailDriver != "smtp" {
+		return fmt.Errorf("MAIL_DRIVER must be smtp when NODE_ENV=production (got %q)", c.MailDriver)
+	}
+	return nil
+}
+
+func checkSecret(name, value string) error {
+	if value == "" {
+		return fmt.Errorf("%s must be set", name)
+	}
+	for _, public := range publicSecrets {
+		if value == public {
+			return fmt.Errorf("%s uses a publicly known example value, generate a new one", name)
+		}
+	}
+	return nil
+}
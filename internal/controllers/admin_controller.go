@@ -0,0 +1,219 @@
+package controllers
+
+import (
+	"fmt"
+	"rest-api/internal/dto/request"
+	"rest-api/internal/models"
+	"rest-api/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AdminController struct {
+	adminService services.AdminService
+}
+
+func NewAdminController(adminService services.AdminService) *AdminController {
+	return &AdminController{adminService: adminService}
+}
+
+func (ctrl *AdminController) ListUsers(c *fiber.Ctx) error {
+	var query request.AdminUserListQuery
+	if err := c.QueryParser(&query); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Invalid query parameters",
+		})
+	}
+
+	users, err := ctrl.adminService.ListUsers(query)
+	if err != nil {
+		return c.Status(adminErrorStatus(err)).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(users)
+}
+
+func (ctrl *AdminController) GetUser(c *fiber.Ctx) error {
+	userID, ok := parseAdminUserID(c)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Invalid user ID",
+		})
+	}
+
+	user, err := ctrl.adminService.GetUser(userID)
+	if err != nil {
+		return c.Status(adminErrorStatus(err)).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"user": user,
+	})
+}
+
+func (ctrl *AdminController) GetTaskCounts(c *fiber.Ctx) error {
+	userID, ok := parseAdminUserID(c)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Invalid user ID",
+		})
+	}
+
+	counts, err := ctrl.adminService.GetTaskCounts(userID)
+	if err != nil {
+		return c.Status(adminErrorStatus(err)).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"taskCounts": counts,
+	})
+}
+
+func (ctrl *AdminController) UpdateRole(c *fiber.Ctx) error {
+	admin := c.Locals("user").(*models.User)
+	userID, ok := parseAdminUserID(c)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Invalid user ID",
+		})
+	}
+
+	var req request.AdminRoleUpdateRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+
+	user, err := ctrl.adminService.UpdateRole(admin.ID, userID, req.Role)
+	if err != nil {
+		return c.Status(adminErrorStatus(err)).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"message": "Role updated",
+		"user":    user,
+	})
+}
+
+func (ctrl *AdminController) DisableUser(c *fiber.Ctx) error {
+	admin := c.Locals("user").(*models.User)
+	userID, ok := parseAdminUserID(c)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Invalid user ID",
+		})
+	}
+
+	user, err := ctrl.adminService.DisableUser(admin.ID, userID)
+	if err != nil {
+		return c.Status(adminErrorStatus(err)).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"message": "User disabled",
+		"user":    user,
+	})
+}
+
+func (ctrl *AdminController) EnableUser(c *fiber.Ctx) error {
+	admin := c.Locals("user").(*models.User)
+	userID, ok := parseAdminUserID(c)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Invalid user ID",
+		})
+	}
+
+	user, err := ctrl.adminService.EnableUser(admin.ID, userID)
+	if err != nil {
+		return c.Status(adminErrorStatus(err)).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"message": "User re-enabled",
+		"user":    user,
+	})
+}
+
+func (ctrl *AdminController) DeleteUser(c *fiber.Ctx) error {
+	admin := c.Locals("user").(*models.User)
+	userID, ok := parseAdminUserID(c)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Invalid user ID",
+		})
+	}
+
+	if err := ctrl.adminService.DeleteUser(admin.ID, userID); err != nil {
+		return c.Status(adminErrorStatus(err)).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"message": "User deleted",
+	})
+}
+
+func (ctrl *AdminController) ForcePasswordReset(c *fiber.Ctx) error {
+	admin := c.Locals("user").(*models.User)
+	userID, ok := parseAdminUserID(c)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Invalid user ID",
+		})
+	}
+
+	if err := ctrl.adminService.ForcePasswordReset(admin.ID, userID); err != nil {
+		return c.Status(adminErrorStatus(err)).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"message": "Password reset required, the user has been signed out and emailed a reset link",
+	})
+}
+
+func (ctrl *AdminController) ListSecurityEvents(c *fiber.Ctx) error {
+	var query request.AdminSecurityEventListQuery
+	if err := c.QueryParser(&query); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Invalid query parameters",
+		})
+	}
+
+	events, err := ctrl.adminService.ListSecurityEvents(query)
+	if err != nil {
+		return c.Status(adminErrorStatus(err)).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(events)
+}
+
+func parseAdminUserID(c *fiber.Ctx) (uint, bool) {
+	var userID uint
+	if _, err := fmt.Sscanf(c.Params("id"), "%d", &userID); err != nil {
+		return 0, false
+	}
+	return userID, true
+}
+
+// adminErrorStatus memetakan error dari AdminService ke HTTP status code
+func adminErrorStatus(err error) int {
+	switch err.Error() {
+	case "user not found":
+		return fiber.StatusNotFound
+	case "invalid role", "invalid status", "invalid offset", "invalid event type":
+		return fiber.StatusBadRequest
+	case "cannot modify your own account":
+		return fiber.StatusForbidden
+	case "user is already disabled", "user is not disabled":
+		return fiber.StatusConflict
+	}
+	return fiber.StatusInternalServerError
+}
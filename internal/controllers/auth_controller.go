@@ -1,9 +1,15 @@
 package controllers
 
 import (
+	"errors"
+	"math"
 	"rest-api/config"
 	"rest-api/internal/dto/request"
+	"rest-api/internal/dto/response"
+	"rest-api/internal/middlewares"
+	"rest-api/internal/models"
 	"rest-api/internal/services"
+	"strconv"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -24,68 +30,399 @@ func NewAuthController(authService services.AuthService, cfg *config.Config) *Au
 func (ctrl *AuthController) Login(c *fiber.Ctx) error {
 	var req request.LoginRequest
 
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "Invalid request body.",
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+
+	// Call service untuk login
+	// Login dicatat sebagai session dengan user agent dan IP client
+	client := services.ClientInfo{UserAgent: c.Get(fiber.HeaderUserAgent), IPAddress: c.IP()}
+	result, err := ctrl.authService.Login(req.Email, req.Password, client)
+	if err != nil {
+		if handled, err := loginThrottled(c, err); handled {
+			return err
+		}
+		statusCode := fiber.StatusBadRequest
+		switch err.Error() {
+		case "invalid email or password":
+			statusCode = fiber.StatusUnauthorized
+		case "email not verified", "account disabled", "password reset required":
+			statusCode = fiber.StatusForbidden
+		}
+		return c.Status(statusCode).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	// Akun dengan 2FA: token diterbitkan setelah POST /api/auth/login/2fa
+	if result.Challenge != nil {
+		return c.JSON(fiber.Map{
+			"message":            "Two-factor authentication required.",
+			"twoFactorRequired":  true,
+			"challengeToken":     result.Challenge.ChallengeToken,
+			"challengeExpiresAt": result.Challenge.ExpiresAt,
 		})
 	}
+	return ctrl.loginSuccess(c, result)
+}
 
-	// Validasi input
-	// if err := ctrl.validator.Struct(req); err != nil {
-	// 	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-	// 		"message": err.Error(),
-	// 	})
-	// }
+func (ctrl *AuthController) LoginTwoFactor(c *fiber.Ctx) error {
+	var req request.TwoFactorLoginRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
 
-	// Call service untuk login
-	token, userResponse, err := ctrl.authService.Login(req.Email, req.Password)
+	client := services.ClientInfo{UserAgent: c.Get(fiber.HeaderUserAgent), IPAddress: c.IP()}
+	result, err := ctrl.authService.LoginTwoFactor(req.ChallengeToken, req.Code, client)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		if handled, err := loginThrottled(c, err); handled {
+			return err
+		}
+		statusCode := fiber.StatusUnauthorized
+		switch err.Error() {
+		case "challenge token and code are required":
+			statusCode = fiber.StatusBadRequest
+		case "failed to verify two-factor code", "failed to create session", "failed to generate token":
+			statusCode = fiber.StatusInternalServerError
+		}
+		return c.Status(statusCode).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return ctrl.loginSuccess(c, result)
+}
+
+// loginThrottled mengirim 429 dengan Retry-After jika login sedang dikenai backoff atau lockout
+func loginThrottled(c *fiber.Ctx, err error) (bool, error) {
+	var throttled *services.LoginThrottledError
+	if !errors.As(err, &throttled) {
+		return false, nil
+	}
+	c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+	return true, c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+		"message": err.Error(),
+	})
+}
+
+// oidcStateCookie mengikat login OIDC ke browser yang memulainya
+const oidcStateCookie = "oidc_state"
+
+// StartOIDCLogin membuat URL login ke OpenID provider; frontend mengarahkan browser ke URL tersebut
+func (ctrl *AuthController) StartOIDCLogin(c *fiber.Ctx) error {
+	authorization, err := ctrl.authService.StartOIDCLogin()
+	if err != nil {
+		statusCode := fiber.StatusInternalServerError
+		switch err.Error() {
+		case "oidc login is not configured":
+			statusCode = fiber.StatusNotFound
+		case "oidc provider unavailable":
+			statusCode = fiber.StatusBadGateway
+		}
+		return c.Status(statusCode).JSON(fiber.Map{
 			"message": err.Error(),
 		})
 	}
 
-	// Set cookie dengan token
+	// SameSite Lax: cookie tetap terkirim saat provider mengarahkan browser kembali ke frontend
 	c.Cookie(&fiber.Cookie{
-		Name:     "token",
-		Value:    token,
-		Expires:  time.Now().Add(7 * 24 * time.Hour),
+		Name:     oidcStateCookie,
+		Value:    authorization.State,
+		Path:     "/api/auth/oidc",
+		Expires:  authorization.ExpiresAt,
 		HTTPOnly: true,
 		Secure:   ctrl.cfg.NodeEnv == "production",
 		SameSite: "Lax",
 	})
+	return c.JSON(fiber.Map{
+		"authorizationUrl": authorization.AuthorizationURL,
+	})
+}
+
+// CompleteOIDCLogin menyelesaikan login OIDC memakai code dan state dari redirect provider
+func (ctrl *AuthController) CompleteOIDCLogin(c *fiber.Ctx) error {
+	var req request.OIDCCallbackRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+
+	client := services.ClientInfo{UserAgent: c.Get(fiber.HeaderUserAgent), IPAddress: c.IP()}
+	browserState := c.Cookies(oidcStateCookie)
+	// State sekali pakai, cookie dihapus apa pun hasilnya
+	c.Cookie(&fiber.Cookie{
+		Name:     oidcStateCookie,
+		Path:     "/api/auth/oidc",
+		Expires:  time.Now().Add(-time.Hour),
+		HTTPOnly: true,
+		Secure:   ctrl.cfg.NodeEnv == "production",
+		SameSite: "Lax",
+	})
+	result, err := ctrl.authService.CompleteOIDCLogin(req.Code, req.State, browserState, client)
+	if err != nil {
+		statusCode := fiber.StatusInternalServerError
+		switch err.Error() {
+		case "oidc login is not configured":
+			statusCode = fiber.StatusNotFound
+		case "code and state are required", "invalid or expired oidc state":
+			statusCode = fiber.StatusBadRequest
+		case "failed to verify identity":
+			statusCode = fiber.StatusUnauthorized
+		case "verified email is required", "account not provisioned", "email not verified", "account disabled", "password reset required":
+			statusCode = fiber.StatusForbidden
+		case "email already registered but not verified":
+			statusCode = fiber.StatusConflict
+		}
+		return c.Status(statusCode).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	if result.Challenge != nil {
+		return c.JSON(fiber.Map{
+			"message":            "Two-factor authentication required.",
+			"twoFactorRequired":  true,
+			"challengeToken":     result.Challenge.ChallengeToken,
+			"challengeExpiresAt": result.Challenge.ExpiresAt,
+		})
+	}
+	return ctrl.loginSuccess(c, result)
+}
+
+// loginSuccess menyimpan token di cookie dan mengembalikan response login
+func (ctrl *AuthController) loginSuccess(c *fiber.Ctx, result *services.LoginResult) error {
+	// Set cookie dengan access token dan refresh token
+	ctrl.setTokenCookies(c, result.Tokens)
 
 	return c.JSON(fiber.Map{
-		"message": "Login successfully.",
-		"token":   token,
-		"user":    userResponse,
+		"message":          "Login successfully.",
+		"token":            result.Tokens.Token,
+		"expiresAt":        result.Tokens.ExpiresAt,
+		"refreshToken":     result.Tokens.RefreshToken,
+		"refreshExpiresAt": result.Tokens.RefreshExpiresAt,
+		"csrfToken":        result.Tokens.CSRFToken,
+		"user":             result.User,
 	})
 }
 
+func (ctrl *AuthController) Refresh(c *fiber.Ctx) error {
+	var req request.RefreshRequest
+	if len(c.Body()) > 0 {
+		if ok, err := parseBody(c, &req); !ok {
+			return err
+		}
+	}
+	// Refresh token dari body, jika tidak ada ambil dari cookie
+	if req.RefreshToken == "" {
+		req.RefreshToken = c.Cookies("refresh_token")
+	}
 
+	tokens, err := ctrl.authService.Refresh(req.RefreshToken)
+	if err != nil {
+		statusCode := fiber.StatusUnauthorized
+		if err.Error() == "refresh token is required" {
+			statusCode = fiber.StatusBadRequest
+		} else if err.Error() == "failed to refresh token" {
+			statusCode = fiber.StatusInternalServerError
+		}
+		return c.Status(statusCode).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
 
-func (ctrl *AuthController)Register(c *fiber.Ctx) error {
-	type RegisterRequest struct {
-		Username string `json:"username"`
-		Email    string `json:"email"`
-		Password string `json:"password"`
+	ctrl.setTokenCookies(c, tokens)
+	return c.JSON(fiber.Map{
+		"message":          "Token refreshed successfully.",
+		"token":            tokens.Token,
+		"expiresAt":        tokens.ExpiresAt,
+		"refreshToken":     tokens.RefreshToken,
+		"refreshExpiresAt": tokens.RefreshExpiresAt,
+		"csrfToken":        tokens.CSRFToken,
+	})
+}
+
+func (ctrl *AuthController) Logout(c *fiber.Ctx) error {
+	claims := c.Locals("claims").(*middlewares.Claims)
+
+	if err := ctrl.authService.Logout(claims); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": err.Error(),
+		})
 	}
-	var req RegisterRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "Invalid request body",
+
+	ctrl.clearTokenCookies(c)
+	return c.JSON(fiber.Map{
+		"message": "Logout successfully.",
+	})
+}
+
+func (ctrl *AuthController) ForgotPassword(c *fiber.Ctx) error {
+	var req request.ForgotPasswordRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+
+	if err := ctrl.authService.RequestPasswordReset(req.Email); err != nil {
+		statusCode := fiber.StatusInternalServerError
+		if err.Error() == "email is required" {
+			statusCode = fiber.StatusBadRequest
+		}
+		return c.Status(statusCode).JSON(fiber.Map{
+			"message": err.Error(),
 		})
 	}
 
+	// Pesan sama untuk email terdaftar maupun tidak
+	return c.JSON(fiber.Map{
+		"message": "If the email is registered, a password reset link has been sent.",
+	})
+}
+
+func (ctrl *AuthController) ResetPassword(c *fiber.Ctx) error {
+	var req request.ResetPasswordRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+
+	if err := ctrl.authService.ResetPassword(req.Token, req.Password); err != nil {
+		if handled, err := passwordPolicyFailed(c, err); handled {
+			return err
+		}
+		statusCode := fiber.StatusBadRequest
+		if err.Error() == "failed to reset password" || err.Error() == "failed to hash password" {
+			statusCode = fiber.StatusInternalServerError
+		}
+		return c.Status(statusCode).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Password has been reset. Please login again.",
+	})
+}
+
+func (ctrl *AuthController) VerifyEmail(c *fiber.Ctx) error {
+	var req request.VerifyEmailRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+
+	userResponse, err := ctrl.authService.VerifyEmail(req.Token)
+	if err != nil {
+		statusCode := fiber.StatusBadRequest
+		if err.Error() == "email already in use" {
+			statusCode = fiber.StatusConflict
+		} else if err.Error() == "failed to verify email" {
+			statusCode = fiber.StatusInternalServerError
+		}
+		return c.Status(statusCode).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Email verified successfully.",
+		"user":    userResponse,
+	})
+}
+
+func (ctrl *AuthController) ResendVerification(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	if err := ctrl.authService.ResendVerification(user.ID); err != nil {
+		statusCode := fiber.StatusInternalServerError
+		if err.Error() == "email already verified" {
+			statusCode = fiber.StatusConflict
+		} else if err.Error() == "user not found" {
+			statusCode = fiber.StatusNotFound
+		}
+		return c.Status(statusCode).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Verification email sent.",
+	})
+}
+
+// setTokenCookies menyimpan access token dan refresh token di cookie HTTP-only
+// Cookie refresh token hanya dikirim ke endpoint /api/auth
+func (ctrl *AuthController) setTokenCookies(c *fiber.Ctx, tokens *response.TokenResponse) {
+	c.Cookie(&fiber.Cookie{
+		Name:     "token",
+		Value:    tokens.Token,
+		Expires:  tokens.ExpiresAt,
+		HTTPOnly: true,
+		Secure:   ctrl.cfg.NodeEnv == "production",
+		SameSite: "Lax",
+	})
+	c.Cookie(&fiber.Cookie{
+		Name:     "refresh_token",
+		Value:    tokens.RefreshToken,
+		Path:     "/api/auth",
+		Expires:  tokens.RefreshExpiresAt,
+		HTTPOnly: true,
+		Secure:   ctrl.cfg.NodeEnv == "production",
+		SameSite: "Strict",
+	})
+	// Token CSRF sengaja tidak HTTP-only agar frontend bisa membacanya dan mengirimnya di header X-CSRF-Token
+	c.Cookie(&fiber.Cookie{
+		Name:     middlewares.CSRFCookieName,
+		Value:    tokens.CSRFToken,
+		Path:     "/",
+		Expires:  tokens.RefreshExpiresAt,
+		Secure:   ctrl.cfg.NodeEnv == "production",
+		SameSite: "Lax",
+	})
+}
+
+// clearTokenCookies menghapus cookie token saat logout
+func (ctrl *AuthController) clearTokenCookies(c *fiber.Ctx) {
+	expired := time.Now().Add(-time.Hour)
+	c.Cookie(&fiber.Cookie{
+		Name:     "token",
+		Expires:  expired,
+		HTTPOnly: true,
+		Secure:   ctrl.cfg.NodeEnv == "production",
+		SameSite: "Lax",
+	})
+	c.Cookie(&fiber.Cookie{
+		Name:     "refresh_token",
+		Path:     "/api/auth",
+		Expires:  expired,
+		HTTPOnly: true,
+		Secure:   ctrl.cfg.NodeEnv == "production",
+		SameSite: "Strict",
+	})
+	c.Cookie(&fiber.Cookie{
+		Name:     middlewares.CSRFCookieName,
+		Path:     "/",
+		Expires:  expired,
+		Secure:   ctrl.cfg.NodeEnv == "production",
+		SameSite: "Lax",
+	})
+}
+
+
+
+func (ctrl *AuthController)Register(c *fiber.Ctx) error {
+	var req request.RegisterRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+
 	userResponse, err := ctrl.authService.Register(req.Username, req.Email, req.Password)
 	if err != nil {
+		if handled, err := passwordPolicyFailed(c, err); handled {
+			return err
+		}
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"message": err.Error(),
 		})
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
-		"message": "User registered successfully",
+		"message": "User registered successfully. Check your email to verify your account.",
 		"user":    userResponse,
 	})
 }
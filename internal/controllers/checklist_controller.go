@@ -0,0 +1,115 @@
+package controllers
+
+import (
+	"fmt"
+	"rest-api/internal/dto/request"
+	"rest-api/internal/models"
+	"rest-api/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ChecklistController struct {
+	checklistService services.ChecklistService
+}
+
+func NewChecklistController(checklistService services.ChecklistService) *ChecklistController {
+	return &ChecklistController{
+		checklistService: checklistService,
+	}
+}
+
+func (ctrl *ChecklistController) AddItem(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	var taskID uint
+	if _, err := fmt.Sscanf(c.Params("id"), "%d", &taskID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Invalid task ID",
+		})
+	}
+	var req request.ChecklistItemCreateRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+
+	item, err := ctrl.checklistService.AddItem(user.ID, taskID, req)
+	if err != nil {
+		return c.Status(checklistErrorStatus(err)).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Checklist item created successfully",
+		"item":    item,
+	})
+}
+
+func (ctrl *ChecklistController) UpdateItem(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	var taskID, itemID uint
+	if _, err := fmt.Sscanf(c.Params("id"), "%d", &taskID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Invalid task ID",
+		})
+	}
+	if _, err := fmt.Sscanf(c.Params("itemId"), "%d", &itemID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Invalid checklist item ID",
+		})
+	}
+	var req request.ChecklistItemUpdateRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+
+	item, err := ctrl.checklistService.UpdateItem(user.ID, taskID, itemID, req)
+	if err != nil {
+		return c.Status(checklistErrorStatus(err)).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"message": "Checklist item updated successfully",
+		"item":    item,
+	})
+}
+
+func (ctrl *ChecklistController) DeleteItem(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	var taskID, itemID uint
+	if _, err := fmt.Sscanf(c.Params("id"), "%d", &taskID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Invalid task ID",
+		})
+	}
+	if _, err := fmt.Sscanf(c.Params("itemId"), "%d", &itemID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Invalid checklist item ID",
+		})
+	}
+
+	if err := ctrl.checklistService.DeleteItem(user.ID, taskID, itemID); err != nil {
+		return c.Status(checklistErrorStatus(err)).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"message": "Checklist item deleted successfully",
+	})
+}
+
+// checklistErrorStatus memetakan error dari ChecklistService ke HTTP status code
+func checklistErrorStatus(err error) int {
+	switch err.Error() {
+	case "task not found", "checklist item not found":
+		return fiber.StatusNotFound
+	case "unauthorized to update this task":
+		return fiber.StatusForbidden
+	case "checklist item title is required", "invalid checklist item position":
+		return fiber.StatusBadRequest
+	}
+	return fiber.StatusInternalServerError
+}
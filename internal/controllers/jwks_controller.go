@@ -0,0 +1,30 @@
+package controllers
+
+import (
+	"fmt"
+	"log"
+	"rest-api/internal/jwtkeys"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type JWKSController struct {
+	keys *jwtkeys.KeyRing
+}
+
+func NewJWKSController(keys *jwtkeys.KeyRing) *JWKSController {
+	return &JWKSController{keys: keys}
+}
+
+// GetJWKS mengembalikan public key untuk memverifikasi access token (RFC 7517)
+func (ctrl *JWKSController) GetJWKS(c *fiber.Ctx) error {
+	set, err := ctrl.keys.JWKS()
+	if err != nil {
+		log.Printf("Warning: failed to load JWKS: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "failed to load signing keys",
+		})
+	}
+	c.Set(fiber.HeaderCacheControl, fmt.Sprintf("public, max-age=%d", int(jwtkeys.JWKSCacheMaxAge.Seconds())))
+	return c.JSON(set)
+}
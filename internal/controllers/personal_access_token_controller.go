@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"fmt"
+	"rest-api/internal/dto/request"
+	"rest-api/internal/models"
+	"rest-api/internal/services"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PersonalAccessTokenController struct {
+	tokenService services.PersonalAccessTokenService
+}
+
+func NewPersonalAccessTokenController(tokenService services.PersonalAccessTokenService) *PersonalAccessTokenController {
+	return &PersonalAccessTokenController{tokenService: tokenService}
+}
+
+func (ctrl *PersonalAccessTokenController) CreateToken(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	var req request.PersonalAccessTokenCreateRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+
+	token, err := ctrl.tokenService.CreateToken(user.ID, req)
+	if err != nil {
+		statusCode := fiber.StatusBadRequest
+		if strings.HasPrefix(err.Error(), "failed to") {
+			statusCode = fiber.StatusInternalServerError
+		} else if err.Error() == "too many access tokens" {
+			statusCode = fiber.StatusConflict
+		}
+		return c.Status(statusCode).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Token created. Copy it now, it will not be shown again.",
+		"token":   token,
+	})
+}
+
+func (ctrl *PersonalAccessTokenController) GetTokens(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	tokens, err := ctrl.tokenService.GetTokens(user.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"tokens": tokens,
+	})
+}
+
+func (ctrl *PersonalAccessTokenController) DeleteToken(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	var tokenID uint
+	if _, err := fmt.Sscanf(c.Params("id"), "%d", &tokenID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Invalid token ID",
+		})
+	}
+
+	if err := ctrl.tokenService.DeleteToken(user.ID, tokenID); err != nil {
+		statusCode := fiber.StatusInternalServerError
+		if err.Error() == "token not found" {
+			statusCode = fiber.StatusNotFound
+		}
+		return c.Status(statusCode).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"message": "Token deleted",
+	})
+}
@@ -0,0 +1,223 @@
+package controllers
+
+import (
+	"fmt"
+	"rest-api/internal/dto/request"
+	"rest-api/internal/models"
+	"rest-api/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ProjectController struct {
+	projectService services.ProjectService
+}
+
+func NewProjectController(projectService services.ProjectService) *ProjectController {
+	return &ProjectController{
+		projectService: projectService,
+	}
+}
+
+func (ctrl *ProjectController) CreateProject(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	var req request.ProjectCreateRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+
+	project, err := ctrl.projectService.CreateProject(user.ID, req)
+	if err != nil {
+		return c.Status(projectErrorStatus(err)).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Project created successfully",
+		"project": project,
+	})
+}
+
+func (ctrl *ProjectController) GetProjects(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	// Query opsional: ?archived=true untuk ikut menampilkan project yang diarsipkan
+	projects, err := ctrl.projectService.GetProjectsByUserID(user.ID, c.QueryBool("archived", false))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"projects": projects,
+	})
+}
+
+func (ctrl *ProjectController) GetProjectByID(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	var projectID uint
+	if _, err := fmt.Sscanf(c.Params("id"), "%d", &projectID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Invalid project ID",
+		})
+	}
+
+	project, err := ctrl.projectService.GetProjectByID(user.ID, projectID)
+	if err != nil {
+		return c.Status(projectErrorStatus(err)).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"project": project,
+	})
+}
+
+func (ctrl *ProjectController) UpdateProject(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	var projectID uint
+	if _, err := fmt.Sscanf(c.Params("id"), "%d", &projectID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Invalid project ID",
+		})
+	}
+	var req request.ProjectUpdateRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+
+	project, err := ctrl.projectService.UpdateProject(user.ID, projectID, req)
+	if err != nil {
+		return c.Status(projectErrorStatus(err)).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"message": "Project updated successfully",
+		"project": project,
+	})
+}
+
+func (ctrl *ProjectController) ArchiveProject(c *fiber.Ctx) error {
+	return ctrl.setArchived(c, true, "Project archived successfully")
+}
+
+func (ctrl *ProjectController) UnarchiveProject(c *fiber.Ctx) error {
+	return ctrl.setArchived(c, false, "Project restored successfully")
+}
+
+func (ctrl *ProjectController) DeleteProject(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	var projectID uint
+	if _, err := fmt.Sscanf(c.Params("id"), "%d", &projectID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Invalid project ID",
+		})
+	}
+
+	if err := ctrl.projectService.DeleteProject(user.ID, projectID); err != nil {
+		return c.Status(projectErrorStatus(err)).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"message": "Project moved to trash",
+	})
+}
+
+func (ctrl *ProjectController) GetTrash(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	projects, err := ctrl.projectService.GetTrash(user.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"projects": projects,
+	})
+}
+
+func (ctrl *ProjectController) RestoreProject(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	var projectID uint
+	if _, err := fmt.Sscanf(c.Params("id"), "%d", &projectID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Invalid project ID",
+		})
+	}
+
+	project, err := ctrl.projectService.RestoreProject(user.ID, projectID)
+	if err != nil {
+		return c.Status(projectErrorStatus(err)).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"message": "Project restored from trash successfully",
+		"project": project,
+	})
+}
+
+func (ctrl *ProjectController) PurgeProject(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	var projectID uint
+	if _, err := fmt.Sscanf(c.Params("id"), "%d", &projectID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Invalid project ID",
+		})
+	}
+
+	if err := ctrl.projectService.PurgeProject(user.ID, projectID); err != nil {
+		return c.Status(projectErrorStatus(err)).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"message": "Project permanently deleted",
+	})
+}
+
+func (ctrl *ProjectController) setArchived(c *fiber.Ctx, archived bool, message string) error {
+	user := c.Locals("user").(*models.User)
+
+	var projectID uint
+	if _, err := fmt.Sscanf(c.Params("id"), "%d", &projectID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Invalid project ID",
+		})
+	}
+
+	project, err := ctrl.projectService.ArchiveProject(user.ID, projectID, archived)
+	if err != nil {
+		return c.Status(projectErrorStatus(err)).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"message": message,
+		"project": project,
+	})
+}
+
+// projectErrorStatus memetakan error dari ProjectService ke HTTP status code
+func projectErrorStatus(err error) int {
+	switch err.Error() {
+	case "project not found", "project not found in trash":
+		return fiber.StatusNotFound
+	case "unauthorized to access this project":
+		return fiber.StatusForbidden
+	case "project name is required", "invalid project color":
+		return fiber.StatusBadRequest
+	case "project name already in use", "project is archived":
+		return fiber.StatusConflict
+	}
+	return fiber.StatusInternalServerError
+}
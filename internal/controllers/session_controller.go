@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"fmt"
+	"rest-api/internal/middlewares"
+	"rest-api/internal/models"
+	"rest-api/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type SessionController struct {
+	sessionService services.SessionService
+}
+
+func NewSessionController(sessionService services.SessionService) *SessionController {
+	return &SessionController{sessionService: sessionService}
+}
+
+func (ctrl *SessionController) GetSessions(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+	claims := c.Locals("claims").(*middlewares.Claims)
+
+	sessions, err := ctrl.sessionService.GetSessions(user.ID, claims.FamilyID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"sessions": sessions,
+	})
+}
+
+func (ctrl *SessionController) RevokeSession(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	var sessionID uint
+	if _, err := fmt.Sscanf(c.Params("id"), "%d", &sessionID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Invalid session ID",
+		})
+	}
+
+	if err := ctrl.sessionService.RevokeSession(user.ID, sessionID); err != nil {
+		statusCode := fiber.StatusInternalServerError
+		if err.Error() == "session not found" {
+			statusCode = fiber.StatusNotFound
+		}
+		return c.Status(statusCode).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"message": "Session revoked successfully",
+	})
+}
+
+// RevokeAllSessions adalah "log out everywhere", termasuk session yang sedang dipakai
+func (ctrl *SessionController) RevokeAllSessions(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	if err := ctrl.sessionService.RevokeAllSessions(user.ID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"message": "Logged out from all sessions",
+	})
+}
@@ -0,0 +1,162 @@
+package controllers
+
+import (
+	"fmt"
+	"rest-api/internal/dto/request"
+	"rest-api/internal/models"
+	"rest-api/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type TagController struct {
+	tagService services.TagService
+}
+
+func NewTagController(tagService services.TagService) *TagController {
+	return &TagController{
+		tagService: tagService,
+	}
+}
+
+func (ctrl *TagController) CreateTag(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	var req request.TagCreateRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+
+	tag, err := ctrl.tagService.CreateTag(user.ID, req)
+	if err != nil {
+		return c.Status(tagErrorStatus(err)).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Tag created successfully",
+		"tag":     tag,
+	})
+}
+
+func (ctrl *TagController) GetTags(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	tags, err := ctrl.tagService.GetTagsByUserID(user.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"tags": tags,
+	})
+}
+
+func (ctrl *TagController) GetTagByID(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	var tagID uint
+	if _, err := fmt.Sscanf(c.Params("id"), "%d", &tagID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Invalid tag ID",
+		})
+	}
+
+	tag, err := ctrl.tagService.GetTagByID(user.ID, tagID)
+	if err != nil {
+		return c.Status(tagErrorStatus(err)).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"tag": tag,
+	})
+}
+
+func (ctrl *TagController) UpdateTag(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	var tagID uint
+	if _, err := fmt.Sscanf(c.Params("id"), "%d", &tagID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Invalid tag ID",
+		})
+	}
+	var req request.TagUpdateRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+
+	tag, err := ctrl.tagService.UpdateTag(user.ID, tagID, req)
+	if err != nil {
+		return c.Status(tagErrorStatus(err)).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"message": "Tag updated successfully",
+		"tag":     tag,
+	})
+}
+
+func (ctrl *TagController) MergeTag(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	var tagID uint
+	if _, err := fmt.Sscanf(c.Params("id"), "%d", &tagID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Invalid tag ID",
+		})
+	}
+	var req request.TagMergeRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+
+	tag, err := ctrl.tagService.MergeTag(user.ID, tagID, req.TargetTagID)
+	if err != nil {
+		return c.Status(tagErrorStatus(err)).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"message": "Tags merged successfully",
+		"tag":     tag,
+	})
+}
+
+func (ctrl *TagController) DeleteTag(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	var tagID uint
+	if _, err := fmt.Sscanf(c.Params("id"), "%d", &tagID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Invalid tag ID",
+		})
+	}
+
+	if err := ctrl.tagService.DeleteTag(user.ID, tagID); err != nil {
+		return c.Status(tagErrorStatus(err)).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"message": "Tag deleted successfully",
+	})
+}
+
+// tagErrorStatus memetakan error dari TagService ke HTTP status code
+func tagErrorStatus(err error) int {
+	switch err.Error() {
+	case "tag not found":
+		return fiber.StatusNotFound
+	case "unauthorized to access this tag":
+		return fiber.StatusForbidden
+	case "tag name is required", "invalid tag color", "cannot merge a tag into itself":
+		return fiber.StatusBadRequest
+	case "tag name already in use":
+		return fiber.StatusConflict
+	}
+	return fiber.StatusInternalServerError
+}
@@ -23,15 +23,21 @@ func (ctrl *TaskController) CreateTask(c *fiber.Ctx) error {
 	user := c.Locals("user").(*models.User)
 
 	var req request.TaskCreateRequest
-	if err:= c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "Invalid request body",
-		})
+	if ok, err := parseBody(c, &req); !ok {
+		return err
 	}
 
-	blog, err := ctrl.taskService.CreateTask(user.ID, req.Title, req.Description)
+	blog, err := ctrl.taskService.CreateTask(user.ID, req)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		statusCode := fiber.StatusBadRequest
+		if err.Error() == "project not found" {
+			statusCode = fiber.StatusNotFound
+		} else if err.Error() == "unauthorized to access this project" {
+			statusCode = fiber.StatusForbidden
+		} else if err.Error() == "project is archived" {
+			statusCode = fiber.StatusConflict
+		}
+		return c.Status(statusCode).JSON(fiber.Map{
 			"message": err.Error(),
 		})
 	}
@@ -63,7 +69,7 @@ func (ctrl *TaskController) DeleteTask(c *fiber.Ctx) error {
 		})
 	}
 	return c.JSON(fiber.Map{
-		"message": "Task deleted successfully",
+		"message": "Task moved to trash",
 	})
 }
 
@@ -99,19 +105,48 @@ func (ctrl *TaskController) GetTaskByID(c *fiber.Ctx) error {
 
 func (ctrl *TaskController) GetTasksByUserID(c *fiber.Ctx) error {
 	user := c.Locals("user").(*models.User)
-	tasks, err := ctrl.taskService.GetTasksByUserID(user.ID)
+	// Query opsional: filter, sort dan cursor pagination (lihat request.TaskListQuery)
+	var query request.TaskListQuery
+	if err := c.QueryParser(&query); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Invalid query parameters",
+		})
+	}
+	result, err := ctrl.taskService.GetTasksByUserID(user.ID, query)
 	if err != nil {
 		statusCode := fiber.StatusInternalServerError
-		if err.Error() == "no tasks found for this user" {
-			statusCode = fiber.StatusNotFound
+		switch err.Error() {
+		case "invalid due filter", "invalid tag filter", "invalid project filter", "invalid status filter",
+			"invalid date filter", "invalid time zone", "invalid sort field", "invalid sort order", "invalid cursor":
+			statusCode = fiber.StatusBadRequest
 		}
 		return c.Status(statusCode).JSON(fiber.Map{
 			"message": err.Error(),
 		})
 	}
-	return c.JSON(fiber.Map{
-		"tasks": tasks,
-	})
+	return c.JSON(result)
+}
+
+func (ctrl *TaskController) SearchTasks(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+	// Query: q (kata, "frasa", prefix*), limit dan offset (lihat request.TaskSearchQuery)
+	var query request.TaskSearchQuery
+	if err := c.QueryParser(&query); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Invalid query parameters",
+		})
+	}
+	result, err := ctrl.taskService.SearchTasks(user.ID, query)
+	if err != nil {
+		statusCode := fiber.StatusInternalServerError
+		if err.Error() == "search query is required" || err.Error() == "invalid offset" {
+			statusCode = fiber.StatusBadRequest
+		}
+		return c.Status(statusCode).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(result)
 }
 
 func (ctrl *TaskController) UpdateTask(c *fiber.Ctx) error {
@@ -124,12 +159,10 @@ func (ctrl *TaskController) UpdateTask(c *fiber.Ctx) error {
 		})
 	}
 	var req request.TaskUpdateRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "Invalid request body",
-		})
+	if ok, err := parseBody(c, &req); !ok {
+		return err
 	}
-	updatedTask, err := ctrl.taskService.UpdateTask(user.ID, taskID, req.Title, req.Description, req.IsCompleted)
+	updatedTask, err := ctrl.taskService.UpdateTask(user.ID, taskID, req)
 	if err != nil {
 		statusCode := fiber.StatusInternalServerError
 		if err.Error() == "task not found" {
@@ -138,6 +171,20 @@ func (ctrl *TaskController) UpdateTask(c *fiber.Ctx) error {
 			statusCode = fiber.StatusForbidden
 		}	 else if err.Error() == "title or description must be provided" {
 			statusCode = fiber.StatusBadRequest
+		} else if err.Error() == "invalid time zone" || err.Error() == "start date must be before due date" {
+			statusCode = fiber.StatusBadRequest
+		} else if err.Error() == "invalid status" || err.Error() == "invalid priority" {
+			statusCode = fiber.StatusBadRequest
+		} else if err.Error() == "status transition not allowed" {
+			statusCode = fiber.StatusConflict
+		} else if err.Error() == "tag not found" || err.Error() == "parent task not found" {
+			statusCode = fiber.StatusBadRequest
+		} else if err.Error() == "task cannot be its own parent" || err.Error() == "cannot move a task under its own subtask" {
+			statusCode = fiber.StatusBadRequest
+		} else if err.Error() == "maximum subtask depth exceeded" || err.Error() == "invalid recurrence rule" {
+			statusCode = fiber.StatusBadRequest
+		} else if err.Error() == "task has incomplete subtasks" {
+			statusCode = fiber.StatusConflict
 		}
 		return c.Status(statusCode).JSON(fiber.Map{
 			"message": err.Error(),
@@ -147,4 +194,220 @@ func (ctrl *TaskController) UpdateTask(c *fiber.Ctx) error {
 		"message": "Task updated successfully",
 		"task":    updatedTask,
 	})
-}
\ No newline at end of file
+}
+
+func (ctrl *TaskController) GetTasksByProjectID(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	var projectID uint
+	if _, err := fmt.Sscanf(c.Params("id"), "%d", &projectID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Invalid project ID",
+		})
+	}
+
+	tasks, err := ctrl.taskService.GetTasksByProjectID(user.ID, projectID)
+	if err != nil {
+		return c.Status(projectErrorStatus(err)).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"tasks": tasks,
+	})
+}
+
+func (ctrl *TaskController) MoveTask(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	var taskID uint
+	if _, err := fmt.Sscanf(c.Params("id"), "%d", &taskID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Invalid task ID",
+		})
+	}
+	var req request.MoveTaskRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+
+	task, err := ctrl.taskService.MoveTask(user.ID, taskID, req.ProjectID)
+	if err != nil {
+		statusCode := projectErrorStatus(err)
+		if err.Error() == "task not found" {
+			statusCode = fiber.StatusNotFound
+		} else if err.Error() == "unauthorized to update this task" {
+			statusCode = fiber.StatusForbidden
+		}
+		return c.Status(statusCode).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"message": "Task moved successfully",
+		"task":    task,
+	})
+}
+
+func (ctrl *TaskController) GetSubtasks(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	var taskID uint
+	if _, err := fmt.Sscanf(c.Params("id"), "%d", &taskID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Invalid task ID",
+		})
+	}
+
+	tasks, err := ctrl.taskService.GetSubtasks(user.ID, taskID)
+	if err != nil {
+		statusCode := fiber.StatusInternalServerError
+		if err.Error() == "task not found" {
+			statusCode = fiber.StatusNotFound
+		} else if err.Error() == "unauthorized to access this task" {
+			statusCode = fiber.StatusForbidden
+		}
+		return c.Status(statusCode).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"tasks": tasks,
+	})
+}
+
+func (ctrl *TaskController) GetSeries(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	var taskID uint
+	if _, err := fmt.Sscanf(c.Params("id"), "%d", &taskID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Invalid task ID",
+		})
+	}
+
+	tasks, err := ctrl.taskService.GetSeries(user.ID, taskID)
+	if err != nil {
+		statusCode := fiber.StatusInternalServerError
+		if err.Error() == "task not found" {
+			statusCode = fiber.StatusNotFound
+		} else if err.Error() == "unauthorized to access this task" {
+			statusCode = fiber.StatusForbidden
+		}
+		return c.Status(statusCode).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"tasks": tasks,
+	})
+}
+
+func (ctrl *TaskController) BulkTasks(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	var req request.TaskBulkRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+
+	// Kegagalan per task dilaporkan di results, error di sini berarti seluruh request ditolak
+	result, err := ctrl.taskService.BulkTasks(user.ID, req)
+	if err != nil {
+		statusCode := fiber.StatusBadRequest
+		switch err.Error() {
+		case "project not found", "unauthorized to access this project", "project is archived":
+			statusCode = projectErrorStatus(err)
+		case "failed to apply bulk operation", "failed to retrieve tasks", "failed to retrieve project", "failed to retrieve tags":
+			statusCode = fiber.StatusInternalServerError
+		}
+		return c.Status(statusCode).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(result)
+}
+
+func (ctrl *TaskController) GetTrash(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	tasks, err := ctrl.taskService.GetTrash(user.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"tasks": tasks,
+	})
+}
+
+func (ctrl *TaskController) RestoreTask(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	var taskID uint
+	if _, err := fmt.Sscanf(c.Params("id"), "%d", &taskID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Invalid task ID",
+		})
+	}
+
+	task, err := ctrl.taskService.RestoreTask(user.ID, taskID)
+	if err != nil {
+		return c.Status(trashErrorStatus(err)).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"message": "Task restored successfully",
+		"task":    task,
+	})
+}
+
+func (ctrl *TaskController) PurgeTask(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	var taskID uint
+	if _, err := fmt.Sscanf(c.Params("id"), "%d", &taskID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Invalid task ID",
+		})
+	}
+
+	if err := ctrl.taskService.PurgeTask(user.ID, taskID); err != nil {
+		return c.Status(trashErrorStatus(err)).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"message": "Task permanently deleted",
+	})
+}
+
+func (ctrl *TaskController) EmptyTrash(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	purged, err := ctrl.taskService.EmptyTrash(user.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"message": "Trash emptied successfully",
+		"purged":  purged,
+	})
+}
+
+// trashErrorStatus memetakan error restore/purge task ke HTTP status code
+func trashErrorStatus(err error) int {
+	switch err.Error() {
+	case "task not found in trash":
+		return fiber.StatusNotFound
+	case "unauthorized to access this task":
+		return fiber.StatusForbidden
+	case "parent task is in trash":
+		return fiber.StatusConflict
+	}
+	return fiber.StatusInternalServerError
+}
@@ -0,0 +1,104 @@
+package controllers
+
+import (
+	"rest-api/internal/dto/request"
+	"rest-api/internal/models"
+	"rest-api/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type TwoFactorController struct {
+	twoFactorService services.TwoFactorService
+}
+
+func NewTwoFactorController(twoFactorService services.TwoFactorService) *TwoFactorController {
+	return &TwoFactorController{twoFactorService: twoFactorService}
+}
+
+func (ctrl *TwoFactorController) Enroll(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	enrollment, err := ctrl.twoFactorService.Enroll(user.ID)
+	if err != nil {
+		return c.Status(twoFactorErrorStatus(err)).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"message":    "Scan the QR code with your authenticator app, then confirm with a code.",
+		"secret":     enrollment.Secret,
+		"otpauthUri": enrollment.OTPAuthURI,
+	})
+}
+
+func (ctrl *TwoFactorController) Confirm(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	var req request.TwoFactorCodeRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+
+	codes, err := ctrl.twoFactorService.Confirm(user.ID, req.Code)
+	if err != nil {
+		return c.Status(twoFactorErrorStatus(err)).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"message":       "Two-factor authentication enabled. Store these recovery codes somewhere safe, they are shown only once.",
+		"recoveryCodes": codes,
+	})
+}
+
+func (ctrl *TwoFactorController) Disable(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	var req request.TwoFactorDisableRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+
+	if err := ctrl.twoFactorService.Disable(user.ID, req.Password, req.Code); err != nil {
+		return c.Status(twoFactorErrorStatus(err)).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"message": "Two-factor authentication disabled.",
+	})
+}
+
+func (ctrl *TwoFactorController) RegenerateRecoveryCodes(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	var req request.TwoFactorCodeRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+
+	codes, err := ctrl.twoFactorService.RegenerateRecoveryCodes(user.ID, req.Code)
+	if err != nil {
+		return c.Status(twoFactorErrorStatus(err)).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{
+		"message":       "New recovery codes generated, the old ones no longer work.",
+		"recoveryCodes": codes,
+	})
+}
+
+// twoFactorErrorStatus memetakan error dari TwoFactorService ke HTTP status code
+func twoFactorErrorStatus(err error) int {
+	switch err.Error() {
+	case "user not found":
+		return fiber.StatusNotFound
+	case "two-factor authentication is already enabled", "two-factor authentication is not enabled", "two-factor enrollment has not been started":
+		return fiber.StatusConflict
+	case "invalid two-factor code", "incorrect password":
+		return fiber.StatusBadRequest
+	}
+	return fiber.StatusInternalServerError
+}
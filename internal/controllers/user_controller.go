@@ -3,6 +3,7 @@ package controllers
 import (
 	"fmt"
 	"rest-api/internal/dto/request"
+	"rest-api/internal/middlewares"
 	"rest-api/internal/models"
 	"rest-api/internal/services"
 
@@ -57,10 +58,18 @@ func (ctrl *UserController) UpdateUser(c *fiber.Ctx) error {
 	
 
 	var req request.UpdateUserRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"message": "Invalid request body",
-		})
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+	// Password dan email hanya bisa diganti dari login biasa, bukan personal access token
+	if (req.Password != nil || req.Email != nil) && !middlewares.HasScope(c, middlewares.ScopeSession) {
+		return middlewares.ScopeMissing(c, middlewares.ScopeSession)
+	}
+
+	// Session yang dipakai request ini tetap login setelah password diganti
+	currentFamilyID := ""
+	if claims, ok := c.Locals("claims").(*middlewares.Claims); ok {
+		currentFamilyID = claims.FamilyID
 	}
 
 	// Call service untuk update user
@@ -70,22 +79,37 @@ func (ctrl *UserController) UpdateUser(c *fiber.Ctx) error {
 		req.Username,
 		req.Email,
 		req.Password,
+		req.CurrentPassword,
+		currentFamilyID,
+		services.ClientInfo{UserAgent: c.Get(fiber.HeaderUserAgent), IPAddress: c.IP()},
 	)
 	if err != nil {
+		if handled, err := loginThrottled(c, err); handled {
+			return err
+		}
+		if handled, err := passwordPolicyFailed(c, err); handled {
+			return err
+		}
 		statusCode := fiber.StatusInternalServerError
 		if err.Error() == "user tidak ditemukan" {
 			statusCode = fiber.StatusNotFound
 		} else if err.Error() == "unauthorized" {
 			statusCode = fiber.StatusForbidden
-		} else if err.Error() == "email sudah digunakan" || err.Error() == "username sudah digunakan" {
+		} else if err.Error() == "email sudah digunakan" || err.Error() == "username sudah digunakan" || err.Error() == "current password is required" {
 			statusCode = fiber.StatusBadRequest
+		} else if err.Error() == "current password is incorrect" {
+			statusCode = fiber.StatusForbidden
 		}
 		return c.Status(statusCode).JSON(fiber.Map{
 			"message": err.Error(),
 		})
 	}
+	message := "Profil berhasil diupdate."
+	if userResponse.PendingEmail != "" {
+		message = "Profil berhasil diupdate. Cek email baru untuk mengonfirmasi perubahan email."
+	}
 	return c.JSON(fiber.Map{
-		"message": "Profil berhasil diupdate.",
+		"message": message,
 		"user":    userResponse,
 	})
 }
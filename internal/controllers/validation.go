@@ -0,0 +1,55 @@
+package controllers
+
+import (
+	"errors"
+	"rest-api/internal/password"
+	"rest-api/internal/validation"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// parseBody membaca body request ke req lalu menjalankan tag validate
+// Jika gagal, response 400 (body tidak bisa dibaca) atau 422 (field tidak valid) sudah dikirim dan ok bernilai false
+func parseBody(c *fiber.Ctx, req interface{}) (bool, error) {
+	if err := c.BodyParser(req); err != nil {
+		return false, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Invalid request body.",
+		})
+	}
+	if err := validation.Struct(req); err != nil {
+		var fieldErrors validation.Errors
+		if !errors.As(err, &fieldErrors) {
+			return false, c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"message": "Failed to validate request body.",
+			})
+		}
+		return false, validationFailed(c, fieldErrors)
+	}
+	return true, nil
+}
+
+// validationFailed mengirim 422 dengan daftar field yang tidak valid
+func validationFailed(c *fiber.Ctx, fieldErrors validation.Errors) error {
+	return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+		"message": "Validation failed.",
+		"errors":  fieldErrors,
+	})
+}
+
+// passwordPolicyFailed mengirim 422 jika err adalah pelanggaran kebijakan password
+// Format sama dengan error validasi; setiap aturan yang dilanggar menjadi satu error pada field password
+func passwordPolicyFailed(c *fiber.Ctx, err error) (bool, error) {
+	var policyErr *password.PolicyError
+	if !errors.As(err, &policyErr) {
+		return false, nil
+	}
+	fieldErrors := make(validation.Errors, 0, len(policyErr.Violations))
+	for _, violation := range policyErr.Violations {
+		fieldErrors = append(fieldErrors, validation.FieldError{
+			Field:   "password",
+			Rule:    violation.Rule,
+			Message: violation.Message,
+		})
+	}
+	return true, validationFailed(c, fieldErrors)
+}
@@ -59,19 +59,78 @@ func Connect(cfg *config.Config) error {
 func Migrate() error {
 	tables := []interface{}{
 		&models.User{},
+		&models.Project{},
 		&models.Task{},
+		&models.Tag{},
+		&models.ChecklistItem{},
+		&models.RefreshToken{},
+		&models.RevokedToken{},
+		&models.Session{},
+		&models.UserToken{},
+		&models.RecoveryCode{},
+		&models.PersonalAccessToken{},
+		&models.LoginAttempt{},
+		&models.SecurityEvent{},
+		&models.SigningKey{},
+		&models.OIDCState{},
+		&models.UserIdentity{},
 		// tambahkan model lain di sini jika ada (Blog, Comment, dsb)
 	}
 
+	// Dicek sebelum AutoMigrate menambahkan kolom email_verified_at
+	backfillVerified := DB.Migrator().HasTable(&models.User{}) && !DB.Migrator().HasColumn(&models.User{}, "email_verified_at")
+
 	err := DB.AutoMigrate(tables...)
 	if err != nil {
 		return fmt.Errorf("❌ gagal melakukan migrasi database: %w", err)
 	}
 
+	if backfillVerified {
+		if err := migrateEmailVerification(); err != nil {
+			return fmt.Errorf("❌ gagal migrasi verifikasi email: %w", err)
+		}
+	}
+
+	if err := migrateTaskCompletion(); err != nil {
+		return fmt.Errorf("❌ gagal migrasi status task: %w", err)
+	}
+
 	log.Println("✅ Migrasi database berhasil.")
 	return nil
 }
 
+// migrateEmailVerification menandai user yang sudah ada sebelum fitur verifikasi email sebagai terverifikasi
+// agar akun lama tidak tiba-tiba dibatasi; hanya dijalankan saat kolom email_verified_at baru dibuat
+func migrateEmailVerification() error {
+	return DB.Exec("UPDATE users SET email_verified_at = created_at WHERE email_verified_at IS NULL").Error
+}
+
+// migrateTaskCompletion memindahkan kolom lama is_completed ke kolom status
+// Task dengan is_completed=true menjadi "done", lalu kolom is_completed dihapus
+// Aman dijalankan berulang kali karena hanya berjalan jika kolom lama masih ada
+func migrateTaskCompletion() error {
+	migrator := DB.Migrator()
+	if !migrator.HasColumn(&models.Task{}, "is_completed") {
+		return nil
+	}
+
+	return DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(
+			"UPDATE tasks SET status = ?, completed_at = updated_at, status_changed_at = updated_at WHERE is_completed = ?",
+			models.TaskStatusDone, true,
+		).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(
+			"UPDATE tasks SET status_changed_at = created_at WHERE status_changed_at IS NULL",
+		).Error; err != nil {
+			return err
+		}
+		log.Println("✅ Kolom is_completed dimigrasi ke status.")
+		return tx.Migrator().DropColumn(&models.Task{}, "is_completed")
+	})
+}
+
 // GetDB mengembalikan instance *gorm.DB
 func GetDB() *gorm.DB {
 	return DB
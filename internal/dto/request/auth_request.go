@@ -2,11 +2,55 @@ package request
 
 type LoginRequest struct {
 	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required,min=6"`
+	Password string `json:"password" validate:"required"`
 }
 
 type RegisterRequest struct {
-	Username string `json:"username" validate:"required,min=3"`
+	Username string `json:"username" validate:"required,min=3,max=30,username"`
 	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required,min=6"`
+	Password string `json:"password" validate:"required"`
+}
+
+// RefreshRequest menerima refresh token dari body; jika kosong diambil dari cookie refresh_token
+type RefreshRequest struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+// ForgotPasswordRequest meminta link reset password dikirim ke email
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// ResetPasswordRequest mengganti password memakai token dari email reset
+type ResetPasswordRequest struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required"`
+}
+
+// VerifyEmailRequest mengonfirmasi email memakai token dari email verifikasi atau perubahan email
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// TwoFactorLoginRequest menyelesaikan login 2FA; code berisi kode TOTP atau recovery code
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challengeToken" validate:"required"`
+	Code           string `json:"code" validate:"required"`
+}
+
+// TwoFactorCodeRequest berisi kode TOTP (atau recovery code) untuk konfirmasi aksi 2FA
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+// TwoFactorDisableRequest mematikan 2FA, butuh password dan kode TOTP atau recovery code
+type TwoFactorDisableRequest struct {
+	Password string `json:"password" validate:"required"`
+	Code     string `json:"code" validate:"required"`
+}
+
+// OIDCCallbackRequest berisi parameter code dan state dari redirect OpenID provider ke frontend
+type OIDCCallbackRequest struct {
+	Code  string `json:"code" validate:"required"`
+	State string `json:"state" validate:"required"`
 }
@@ -0,0 +1,10 @@
+package request
+
+import "time"
+
+// PersonalAccessTokenCreateRequest membuat token API baru
+type PersonalAccessTokenCreateRequest struct {
+	Name      string     `json:"name" validate:"required,max=100"`
+	Scopes    []string   `json:"scopes" validate:"required,min=1"` // Contoh: ["tasks:read", "tasks:write"]
+	ExpiresAt *time.Time `json:"expiresAt"`                        // RFC 3339, null = tidak pernah expired
+}
@@ -0,0 +1,18 @@
+package request
+
+type ProjectCreateRequest struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	Description string `json:"description"`
+	Color       string `json:"color" validate:"color"`
+}
+
+type ProjectUpdateRequest struct {
+	Name        *string `json:"name" validate:"omitempty,min=1,max=100"`
+	Description *string `json:"description"`
+	Color       *string `json:"color" validate:"omitempty,color"`
+}
+
+// MoveTaskRequest memindahkan task ke project lain, projectId null = pindah ke inbox
+type MoveTaskRequest struct {
+	ProjectID *uint `json:"projectId"`
+}
@@ -0,0 +1,16 @@
+package request
+
+type TagCreateRequest struct {
+	Name  string `json:"name" validate:"required,max=50"`
+	Color string `json:"color" validate:"color"`
+}
+
+type TagUpdateRequest struct {
+	Name  *string `json:"name" validate:"omitempty,min=1,max=50"`
+	Color *string `json:"color" validate:"omitempty,color"`
+}
+
+// TagMergeRequest menggabungkan tag sumber (dari URL) ke tag tujuan
+type TagMergeRequest struct {
+	TargetTagID uint `json:"targetTagId" validate:"required"`
+}
@@ -1,12 +1,97 @@
 package request
 
+import "time"
+
 type TaskCreateRequest struct {
-	Title       string `json:"title"`
-	Description string `json:"description"`
+	Title       string     `json:"title" validate:"max=255"`
+	Description string     `json:"description"`
+	Status      string     `json:"status" validate:"omitempty,oneof=todo in_progress blocked done cancelled"` // todo/in_progress/blocked/done/cancelled, default: todo
+	Priority    string     `json:"priority" validate:"omitempty,oneof=low medium high urgent"`                // low/medium/high/urgent, default: medium
+	StartAt     *time.Time `json:"startAt"`                                                                   // RFC 3339, contoh: 2025-01-31T09:00:00+07:00
+	DueAt       *time.Time `json:"dueAt"`                                                                     // RFC 3339, contoh: 2025-01-31T17:00:00+07:00
+	TimeZone    string     `json:"timeZone" validate:"omitempty,timezone"`                                    // IANA timezone, default: UTC
+	ProjectID   *uint      `json:"projectId"`                                                                 // Kosong = inbox
+	TagIDs      []uint     `json:"tagIds"`
+	ParentID    *uint      `json:"parentId"`   // Isi untuk membuat subtask
+	Recurrence  string     `json:"recurrence"` // RRULE, contoh: FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH;COUNT=10
 }
 
 type TaskUpdateRequest struct {
-	Title       *string `json:"title"`
-	Description *string `json:"description"`
-	IsCompleted *bool   `json:"isCompleted"`
-}
\ No newline at end of file
+	Title        *string    `json:"title" validate:"omitempty,max=255"`
+	Description  *string    `json:"description"`
+	Status       *string    `json:"status" validate:"omitempty,oneof=todo in_progress blocked done cancelled"`
+	Priority     *string    `json:"priority" validate:"omitempty,oneof=low medium high urgent"`
+	StartAt      *time.Time `json:"startAt"`
+	DueAt        *time.Time `json:"dueAt"`
+	TimeZone     *string    `json:"timeZone" validate:"omitempty,timezone"`
+	ClearStartAt bool       `json:"clearStartAt"` // Hapus start date
+	ClearDueAt   bool       `json:"clearDueAt"`   // Hapus due date
+	TagIDs       *[]uint    `json:"tagIds"`       // Mengganti seluruh tag task, [] = hapus semua tag
+	ParentID     *uint      `json:"parentId"`     // Pindahkan task menjadi subtask dari task lain
+	ClearParent  bool       `json:"clearParent"`  // Jadikan task utama (bukan subtask)
+	Recurrence   *string    `json:"recurrence"`   // RRULE baru, "" = hentikan pengulangan
+	// CompleteSubtasks menentukan perilaku saat status diubah ke done sementara subtask belum selesai:
+	// true = subtask ikut diselesaikan, false = perubahan status ditolak
+	CompleteSubtasks bool `json:"completeSubtasks"`
+}
+
+// TaskListQuery adalah query string untuk GET /api/tasks
+// Juga dipakai sebagai "filter" di body POST /api/tasks/bulk (kecuali sort dan cursor)
+// Tanggal menerima RFC 3339 atau YYYY-MM-DD (tanggal saja dibaca di timezone tz)
+type TaskListQuery struct {
+	Due         string `query:"due" json:"due"`                 // none/overdue/today/upcoming
+	TimeZone    string `query:"tz" json:"tz"`                   // IANA timezone untuk batas "hari ini" dan tanggal tanpa jam, default: UTC
+	Tags        string `query:"tags" json:"tags"`               // ID tag dipisah koma, contoh: 1,4
+	TagMode     string `query:"tagMode" json:"tagMode"`         // any (default) atau all
+	ProjectID   string `query:"projectId" json:"projectId"`     // Filter berdasarkan project
+	Status      string `query:"status" json:"status"`           // Status dipisah koma, contoh: todo,in_progress
+	Completed   string `query:"completed" json:"completed"`     // true = done/cancelled saja, false = belum selesai saja
+	Search      string `query:"q" json:"q"`                     // Cari teks di title dan description
+	DueFrom     string `query:"dueFrom" json:"dueFrom"`         // Inklusif
+	DueTo       string `query:"dueTo" json:"dueTo"`             // Inklusif untuk tanggal saja, eksklusif untuk timestamp
+	CreatedFrom string `query:"createdFrom" json:"createdFrom"` // Inklusif
+	CreatedTo   string `query:"createdTo" json:"createdTo"`     // Inklusif untuk tanggal saja, eksklusif untuk timestamp
+	Sort        string `query:"sort" json:"sort"`               // created (default)/updated/due/priority/title
+	Order       string `query:"order" json:"order"`             // asc/desc, default desc untuk created/updated/priority dan asc untuk due/title
+	Cursor      string `query:"cursor" json:"cursor"`           // nextCursor dari halaman sebelumnya
+	Limit       int    `query:"limit" json:"limit"`             // Default 20, maksimum 100
+}
+
+// Aksi yang didukung POST /api/tasks/bulk
+const (
+	BulkActionComplete   = "complete"    // Ubah status ke done, completeSubtasks opsional
+	BulkActionUpdate     = "update"      // Terapkan "changes" (sama seperti PUT /api/tasks/:id)
+	BulkActionDelete     = "delete"      // Pindahkan ke trash
+	BulkActionMove       = "move"        // Pindahkan ke projectId, null = inbox
+	BulkActionAddTags    = "add_tags"    // Tambahkan tagIds
+	BulkActionRemoveTags = "remove_tags" // Lepaskan tagIds
+)
+
+// TaskBulkRequest menerapkan satu aksi ke sekumpulan task
+// Target dipilih lewat ids atau filter (salah satu)
+type TaskBulkRequest struct {
+	Action           string             `json:"action" validate:"required,oneof=complete update delete move add_tags remove_tags"`
+	IDs              []uint             `json:"ids"`
+	Filter           *TaskListQuery     `json:"filter"`
+	Changes          *TaskUpdateRequest `json:"changes"`          // Untuk action update
+	ProjectID        *uint              `json:"projectId"`        // Untuk action move
+	TagIDs           []uint             `json:"tagIds"`           // Untuk action add_tags/remove_tags
+	CompleteSubtasks bool               `json:"completeSubtasks"` // Untuk action complete
+}
+
+// TaskSearchQuery adalah query string untuk GET /api/tasks/search
+type TaskSearchQuery struct {
+	Query  string `query:"q"`      // Kata, "frasa", dan prefix* (contoh: "weekly report" deploy*)
+	Limit  int    `query:"limit"`  // Default 20, maksimum 100
+	Offset int    `query:"offset"` // Jumlah hasil yang dilewati
+}
+
+type ChecklistItemCreateRequest struct {
+	Title string `json:"title" validate:"required,max=255"`
+}
+
+type ChecklistItemUpdateRequest struct {
+	Title    *string `json:"title" validate:"omitempty,min=1,max=255"`
+	IsDone   *bool   `json:"isDone"`
+	Position *int    `json:"position"`
+}
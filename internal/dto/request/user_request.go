@@ -1,7 +1,32 @@
 package request
 
 type UpdateUserRequest struct {
-	Username *string `json:"username"`
-	Email    *string `json:"email"`
+	Username *string `json:"username" validate:"omitempty,min=3,max=30,username"`
+	Email    *string `json:"email" validate:"omitempty,email"`
 	Password *string `json:"password"`
-}
\ No newline at end of file
+	// CurrentPassword wajib diisi saat mengganti password atau email
+	// Untuk email dicek di service, karena mengirim email yang sama dengan sekarang tidak butuh password
+	CurrentPassword *string `json:"currentPassword" validate:"required_with=Password"`
+}
+
+// AdminUserListQuery adalah query string untuk GET /api/admin/users
+type AdminUserListQuery struct {
+	Query  string `query:"q"`      // Cari di username dan email
+	Role   string `query:"role"`   // user/admin
+	Status string `query:"status"` // active/disabled
+	Limit  int    `query:"limit"`  // Default 20, maksimum 100
+	Offset int    `query:"offset"`
+}
+
+// AdminSecurityEventListQuery adalah query string untuk GET /api/admin/security-events
+type AdminSecurityEventListQuery struct {
+	Type   string `query:"type"`   // account_locked/ip_locked
+	UserID uint   `query:"userId"` // Filter berdasarkan user
+	Limit  int    `query:"limit"`  // Default 20, maksimum 100
+	Offset int    `query:"offset"`
+}
+
+// AdminRoleUpdateRequest mengganti role user
+type AdminRoleUpdateRequest struct {
+	Role string `json:"role" validate:"required,oneof=user admin"`
+}
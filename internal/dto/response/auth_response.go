@@ -0,0 +1,24 @@
+package response
+
+import "time"
+
+// TokenResponse berisi pasangan access token dan refresh token
+type TokenResponse struct {
+	Token            string    `json:"token"` // Access token (JWT) berumur pendek
+	ExpiresAt        time.Time `json:"expiresAt"`
+	RefreshToken     string    `json:"refreshToken"` // Hanya bisa dipakai sekali, ditukar lewat POST /api/auth/refresh
+	RefreshExpiresAt time.Time `json:"refreshExpiresAt"`
+	CSRFToken        string    `json:"csrfToken"` // Dikirim di header X-CSRF-Token oleh client yang login lewat cookie
+}
+
+// TwoFactorEnrollResponse berisi secret TOTP baru untuk didaftarkan di aplikasi authenticator
+type TwoFactorEnrollResponse struct {
+	Secret     string `json:"secret"`     // Base32, untuk input manual
+	OTPAuthURI string `json:"otpauthUri"` // Untuk QR code
+}
+
+// TwoFactorChallengeResponse dikembalikan login untuk akun dengan 2FA; token belum diterbitkan
+type TwoFactorChallengeResponse struct {
+	ChallengeToken string    `json:"challengeToken"`
+	ExpiresAt      time.Time `json:"challengeExpiresAt"`
+}
@@ -0,0 +1,15 @@
+package response
+
+import "time"
+
+type PersonalAccessTokenResponse struct {
+	ID         uint       `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expiresAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	Expired    bool       `json:"expired"`
+	Token      string     `json:"token,omitempty"` // Hanya diisi saat token dibuat
+}
@@ -0,0 +1,16 @@
+package response
+
+import "time"
+
+type ProjectResponse struct {
+	ID          uint       `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Color       string     `json:"color"`
+	IsArchived  bool       `json:"isArchived"`
+	ArchivedAt  *time.Time `json:"archivedAt"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+	UserID      uint       `json:"userId"`
+	DeletedAt   *time.Time `json:"deletedAt,omitempty"` // Hanya untuk project di trash
+}
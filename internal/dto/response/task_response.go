@@ -3,11 +3,86 @@ package response
 import "time"
 
 type TaskResponse struct {
-	ID          uint      `json:"id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	IsCompleted bool      `json:"isCompleted"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
-	UserID      uint      `json:"userId"`
+	ID              uint          `json:"id"`
+	Title           string        `json:"title"`
+	Description     string        `json:"description"`
+	Status          string        `json:"status"`
+	Priority        string        `json:"priority"`
+	StartAt         *time.Time    `json:"startAt"`
+	DueAt           *time.Time    `json:"dueAt"`
+	TimeZone        string        `json:"timeZone"`
+	DueState        string        `json:"dueState,omitempty"` // none/overdue/today/upcoming, kosong jika task sudah done/cancelled
+	CompletedAt     *time.Time    `json:"completedAt"`
+	StatusChangedAt *time.Time    `json:"statusChangedAt"`
+	CreatedAt       time.Time     `json:"createdAt"`
+	UpdatedAt       time.Time     `json:"updatedAt"`
+	UserID          uint          `json:"userId"`
+	ProjectID       *uint         `json:"projectId"`
+	Tags            []TagResponse `json:"tags"`
+	ParentID        *uint         `json:"parentId"`
+	RecurrenceRule  string        `json:"recurrenceRule,omitempty"`
+	SeriesID        *uint         `json:"seriesId"`
+	OccurrenceIndex int           `json:"occurrenceIndex"`
+	DeletedAt       *time.Time    `json:"deletedAt,omitempty"` // Hanya untuk task di trash
+
+	Checklist         []ChecklistItemResponse `json:"checklist"`
+	ChecklistProgress ProgressResponse        `json:"checklistProgress"`
+	SubtaskProgress   ProgressResponse        `json:"subtaskProgress"` // Hanya subtask langsung, subtask cancelled tidak dihitung
+}
+
+type ChecklistItemResponse struct {
+	ID        uint      `json:"id"`
+	Title     string    `json:"title"`
+	IsDone    bool      `json:"isDone"`
+	Position  int       `json:"position"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// ProgressResponse menyatakan progress "n dari m selesai"
+type ProgressResponse struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
+
+// TaskListResponse adalah satu halaman hasil listing task
+type TaskListResponse struct {
+	Tasks []TaskResponse `json:"tasks"`
+	Page  PageResponse   `json:"page"`
+}
+
+// PageResponse berisi metadata cursor pagination
+type PageResponse struct {
+	NextCursor *string `json:"nextCursor"` // null jika tidak ada halaman berikutnya
+	TotalCount int64   `json:"totalCount"`
+	Limit      int     `json:"limit"`
+}
+
+// TaskSearchResponse adalah satu halaman hasil pencarian, diurutkan dari yang paling relevan
+type TaskSearchResponse struct {
+	Results    []TaskSearchResult `json:"results"`
+	TotalCount int64              `json:"totalCount"`
+	Limit      int                `json:"limit"`
+	Offset     int                `json:"offset"`
+}
+
+// TaskSearchResult adalah satu task hasil pencarian beserta skor relevansinya
+type TaskSearchResult struct {
+	Task  TaskResponse `json:"task"`
+	Score float64      `json:"score"`
+}
+
+// TaskBulkResponse melaporkan hasil bulk operation per task
+type TaskBulkResponse struct {
+	Action    string               `json:"action"`
+	Succeeded int                  `json:"succeeded"`
+	Failed    int                  `json:"failed"`
+	Results   []TaskBulkItemResult `json:"results"`
+}
+
+// TaskBulkItemResult adalah hasil bulk operation untuk satu task
+type TaskBulkItemResult struct {
+	ID      uint   `json:"id"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
 }
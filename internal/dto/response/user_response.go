@@ -3,9 +3,54 @@ package response
 import "time"
 
 type UserResponse struct {
+	ID               uint      `json:"id"`
+	Username         string    `json:"username"`
+	Email            string    `json:"email"`
+	EmailVerified    bool      `json:"emailVerified"`
+	PendingEmail     string    `json:"pendingEmail,omitempty"` // Email baru yang menunggu konfirmasi
+	TwoFactorEnabled bool      `json:"twoFactorEnabled"`
+	Role             string    `json:"role"`
+	CreatedAt        time.Time `json:"createdAt"`
+	UpdatedAt        time.Time `json:"updatedAt"`
+}
+
+// AdminUserResponse adalah data user yang dilihat admin
+type AdminUserResponse struct {
+	UserResponse
+	Disabled              bool       `json:"disabled"`
+	DisabledAt            *time.Time `json:"disabledAt"`
+	PasswordResetRequired bool       `json:"passwordResetRequired"`
+}
+
+type AdminUserListResponse struct {
+	Users      []AdminUserResponse `json:"users"`
+	TotalCount int64               `json:"totalCount"`
+	Limit      int                 `json:"limit"`
+	Offset     int                 `json:"offset"`
+}
+
+// SecurityEventResponse adalah satu catatan audit keamanan
+type SecurityEventResponse struct {
 	ID        uint      `json:"id"`
-	Username  string    `json:"username"`
-	Email     string    `json:"email"`
+	Type      string    `json:"type"`
+	UserID    *uint     `json:"userId"`
+	IPAddress string    `json:"ipAddress"`
+	Detail    string    `json:"detail"`
 	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type AdminSecurityEventListResponse struct {
+	Events     []SecurityEventResponse `json:"events"`
+	TotalCount int64                   `json:"totalCount"`
+	Limit      int                     `json:"limit"`
+	Offset     int                     `json:"offset"`
+}
+
+// AdminTaskCountsResponse adalah ringkasan task milik seorang user
+type AdminTaskCountsResponse struct {
+	UserID   uint             `json:"userId"`
+	Total    int64            `json:"total"`    // Task aktif, tidak termasuk trash
+	ByStatus map[string]int64 `json:"byStatus"` // Task aktif per status
+	Overdue  int64            `json:"overdue"`
+	Trashed  int64            `json:"trashed"`
 }
@@ -0,0 +1,335 @@
+package jwtkeys
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"rest-api/config"
+	"rest-api/internal/models"
+	"rest-api/internal/repositories"
+	"rest-api/internal/secretbox"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Pengaturan rotasi key
+const (
+	defaultRotationInterval = 30 * 24 * time.Hour
+	defaultAccessTokenTTL   = 15 * time.Minute
+	// prePublish: key baru dipublikasikan di JWKS selama ini sebelum dipakai signing,
+	// agar verifier lain yang meng-cache JWKS (JWKSCacheMaxAge) sudah mengenalnya
+	prePublish = 15 * time.Minute
+	// JWKSCacheMaxAge adalah nilai Cache-Control max-age untuk /.well-known/jwks.json
+	JWKSCacheMaxAge = 5 * time.Minute
+	// clockLeeway ditambahkan ke masa berlaku key lama untuk toleransi perbedaan jam antar instance
+	clockLeeway     = time.Minute
+	refreshInterval = time.Minute
+	// unknownKIDReloadInterval membatasi reload dari database saat token memakai kid yang belum dikenal
+	unknownKIDReloadInterval = 5 * time.Second
+)
+
+// KeyRing menyimpan key yang berlaku di memori dan menyinkronkannya dengan database
+// Beberapa instance aplikasi berbagi key lewat database; aman dipakai bersamaan dari banyak goroutine
+type KeyRing struct {
+	repo          repositories.SigningKeyRepository
+	algorithm     string
+	interval      time.Duration // 0 = tanpa rotasi terjadwal
+	accessTTL     time.Duration
+	encryptionKey []byte
+
+	refreshMu sync.Mutex // Mencegah beberapa goroutine membuat key bersamaan
+	mu        sync.RWMutex
+	keys      []*Key // Urut dari ActivatesAt paling lama
+	loadedAt  time.Time
+}
+
+// SigningKey mengembalikan key terbaru yang sudah aktif untuk algoritma yang dikonfigurasi
+// Key pertama dibuat otomatis jika belum ada
+func (r *KeyRing) SigningKey() (*Key, error) {
+	now := time.Now().UTC()
+	r.mu.RLock()
+	key := r.signingKeyLocked(now)
+	r.mu.RUnlock()
+	if key != nil {
+		return key, nil
+	}
+
+	if err := r.Refresh(); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if key := r.signingKeyLocked(time.Now().UTC()); key != nil {
+		return key, nil
+	}
+	return nil, errors.New("no active signing key")
+}
+
+func (r *KeyRing) signingKeyLocked(now time.Time) *Key {
+	for i := len(r.keys) - 1; i >= 0; i-- {
+		key := r.keys[i]
+		if key.Algorithm == r.algorithm && !key.ActivatesAt.After(now) && !expired(key, now) {
+			return key
+		}
+	}
+	return nil
+}
+
+// Keyfunc dipakai jwt.Parse untuk memilih public key berdasarkan header kid
+// Token ditolak jika kid tidak dikenal atau algoritma di header tidak sama dengan algoritma key
+func (r *KeyRing) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		return nil, errors.New("missing kid")
+	}
+	key, err := r.verificationKey(kid)
+	if err != nil {
+		return nil, err
+	}
+	if token.Method.Alg() != key.Algorithm {
+		return nil, fmt.Errorf("unexpected signing algorithm %q for key %s", token.Method.Alg(), kid)
+	}
+	return key.Public, nil
+}
+
+// verificationKey mencari key berdasarkan kid, reload dari database jika kid belum dikenal
+// (contoh: instance lain baru saja merotasi key)
+func (r *KeyRing) verificationKey(kid string) (*Key, error) {
+	now := time.Now().UTC()
+	r.mu.RLock()
+	key := r.findLocked(kid, now)
+	stale := now.Sub(r.loadedAt) > unknownKIDReloadInterval
+	r.mu.RUnlock()
+	if key != nil {
+		return key, nil
+	}
+	if stale {
+		if err := r.reload(); err != nil {
+			return nil, err
+		}
+		r.mu.RLock()
+		key = r.findLocked(kid, now)
+		r.mu.RUnlock()
+		if key != nil {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown kid %s", kid)
+}
+
+func (r *KeyRing) findLocked(kid string, now time.Time) *Key {
+	for _, key := range r.keys {
+		if key.KID == kid && !expired(key, now) {
+			return key
+		}
+	}
+	return nil
+}
+
+// JWKS mengembalikan semua public key yang masih berlaku, termasuk key yang belum aktif
+func (r *KeyRing) JWKS() (JWKSet, error) {
+	r.mu.RLock()
+	empty := len(r.keys) == 0
+	r.mu.RUnlock()
+	if empty {
+		if err := r.Refresh(); err != nil {
+			return JWKSet{}, err
+		}
+	}
+
+	now := time.Now().UTC()
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	set := JWKSet{Keys: []JWK{}}
+	for i := len(r.keys) - 1; i >= 0; i-- {
+		if !expired(r.keys[i], now) {
+			set.Keys = append(set.Keys, r.keys[i].JWK())
+		}
+	}
+	return set, nil
+}
+
+// Refresh memuat ulang key dari database lalu menjalankan rotasi jika sudah waktunya:
+//   - belum ada key aktif untuk algoritma yang dikonfigurasi (pertama kali, atau JWT_ALGORITHM diganti): buat key yang langsung aktif
+//   - key terbaru sudah berumur JWT_KEY_ROTATION_INTERVAL dikurangi prePublish: buat key berikutnya yang aktif setelah prePublish
+//
+// Key yang digantikan tetap bisa memverifikasi token sampai umur access token terlama habis
+func (r *KeyRing) Refresh() error {
+	r.refreshMu.Lock()
+	defer r.refreshMu.Unlock()
+	if err := r.reload(); err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	r.mu.RLock()
+	current := r.signingKeyLocked(now)
+	var latest *Key
+	var others []uint
+	for _, key := range r.keys {
+		if key.Algorithm == r.algorithm {
+			latest = key
+		}
+		if key != current && key.ExpiresAt == nil {
+			others = append(others, key.ID)
+		}
+	}
+	r.mu.RUnlock()
+
+	switch {
+	case current == nil:
+		// Key lain (algoritma lama) hanya dipakai verifikasi token yang sudah terbit
+		if _, err := r.createKey(now); err != nil {
+			return err
+		}
+		if err := r.repo.SetExpiresAt(others, now.Add(r.accessTTL+clockLeeway)); err != nil {
+			return err
+		}
+	case r.interval > 0 && latest == current && !now.Before(current.ActivatesAt.Add(r.interval-prePublish)):
+		next, err := r.createKey(now.Add(prePublish))
+		if err != nil {
+			return err
+		}
+		if err := r.repo.SetExpiresAt([]uint{current.ID}, next.ActivatesAt.Add(r.accessTTL+clockLeeway)); err != nil {
+			return err
+		}
+		log.Printf("JWT signing key %s scheduled to replace %s at %s", next.KID, current.KID, next.ActivatesAt.Format(time.RFC3339))
+	default:
+		return nil
+	}
+	return r.reload()
+}
+
+// createKey membuat key baru yang aktif mulai activatesAt dan menyimpannya (private key terenkripsi)
+func (r *KeyRing) createKey(activatesAt time.Time) (*models.SigningKey, error) {
+	private, public, err := generateKey(r.algorithm)
+	if err != nil {
+		return nil, err
+	}
+	kid, err := thumbprint(public)
+	if err != nil {
+		return nil, err
+	}
+	privatePEM, err := encodePrivateKey(private)
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := secretbox.Seal(r.encryptionKey, privatePEM)
+	if err != nil {
+		return nil, err
+	}
+	publicPEM, err := encodePublicKey(public)
+	if err != nil {
+		return nil, err
+	}
+	key := &models.SigningKey{
+		KID:         kid,
+		Algorithm:   r.algorithm,
+		PrivateKey:  encrypted,
+		PublicKey:   publicPEM,
+		ActivatesAt: activatesAt,
+	}
+	if err := r.repo.Create(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// reload membaca semua key yang belum expired dari database
+// Key yang tidak bisa dibuka (contoh: JWT_KEY_ENCRYPTION_KEY berubah) dilewati dengan warning
+func (r *KeyRing) reload() error {
+	now := time.Now().UTC()
+	stored, err := r.repo.FindUsable(now)
+	if err != nil {
+		return err
+	}
+	keys := make([]*Key, 0, len(stored))
+	for _, s := range stored {
+		privatePEM, err := secretbox.Open(r.encryptionKey, s.PrivateKey)
+		if err != nil {
+			log.Printf("Warning: failed to decrypt JWT signing key %s: %v", s.KID, err)
+			continue
+		}
+		private, public, err := decodePrivateKey(s.Algorithm, privatePEM)
+		if err != nil {
+			log.Printf("Warning: failed to load JWT signing key %s: %v", s.KID, err)
+			continue
+		}
+		keys = append(keys, &Key{
+			ID:          s.ID,
+			KID:         s.KID,
+			Algorithm:   s.Algorithm,
+			Private:     private,
+			Public:      public,
+			ActivatesAt: s.ActivatesAt,
+			ExpiresAt:   s.ExpiresAt,
+		})
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.keys = keys
+	r.loadedAt = now
+	return nil
+}
+
+// StartRotation menjalankan goroutine yang secara berkala menyinkronkan key, merotasi key yang sudah waktunya,
+// dan menghapus key yang sudah expired
+func (r *KeyRing) StartRotation() {
+	go func() {
+		ticker := time.NewTicker(refreshInterval)
+		defer ticker.Stop()
+		for {
+			if err := r.Refresh(); err != nil {
+				log.Printf("Warning: failed to refresh JWT signing keys: %v", err)
+			}
+			if err := r.repo.DeleteExpired(time.Now().UTC()); err != nil {
+				log.Printf("Warning: failed to delete expired JWT signing keys: %v", err)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+func expired(key *Key, now time.Time) bool {
+	return key.ExpiresAt != nil && !key.ExpiresAt.After(now)
+}
+
+// NewKeyRing membuat KeyRing sesuai konfigurasi
+// Algoritma yang tidak dikenal fallback ke RS256 dengan warning
+func NewKeyRing(repo repositories.SigningKeyRepository, cfg *config.Config) *KeyRing {
+	algorithm := cfg.JWTAlgorithm
+	if algorithm != AlgorithmRS256 && algorithm != AlgorithmEdDSA {
+		log.Printf("Warning: unknown JWT_ALGORITHM %q, using %s", cfg.JWTAlgorithm, AlgorithmRS256)
+		algorithm = AlgorithmRS256
+	}
+
+	interval := defaultRotationInterval
+	if cfg.JWTKeyRotationInterval != "" {
+		parsed, err := time.ParseDuration(cfg.JWTKeyRotationInterval)
+		switch {
+		case err != nil || parsed < 0:
+			log.Printf("Warning: invalid JWT_KEY_ROTATION_INTERVAL %q, using default %s", cfg.JWTKeyRotationInterval, defaultRotationInterval)
+		case parsed > 0 && parsed <= prePublish:
+			log.Printf("Warning: JWT_KEY_ROTATION_INTERVAL must be longer than %s, using %s", prePublish, 2*prePublish)
+			interval = 2 * prePublish
+		default:
+			interval = parsed
+		}
+	}
+
+	accessTTL, err := time.ParseDuration(cfg.JWTExpires)
+	if err != nil || accessTTL <= 0 {
+		accessTTL = defaultAccessTokenTTL
+	}
+
+	return &KeyRing{
+		repo:          repo,
+		algorithm:     algorithm,
+		interval:      interval,
+		accessTTL:     accessTTL,
+		encryptionKey: secretbox.DeriveKey(cfg.JWTEncryptionKey()),
+	}
+}
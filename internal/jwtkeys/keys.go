@@ -0,0 +1,186 @@
+// Package jwtkeys handles the asymmetric keys that sign access tokens
+// Key dirotasi terjadwal; semua key yang masih berlaku dipublikasikan di /.well-known/jwks.json
+package jwtkeys
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Algoritma signing yang didukung
+const (
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+// Algorithms adalah daftar algoritma yang diterima saat verifikasi; algoritma lain (HS256, none, dsb) selalu ditolak
+var Algorithms = []string{AlgorithmRS256, AlgorithmEdDSA}
+
+// rsaKeyBits adalah ukuran key RSA baru
+const rsaKeyBits = 2048
+
+// Key adalah satu key pair yang sudah dibuka dari database
+type Key struct {
+	ID          uint
+	KID         string
+	Algorithm   string
+	Private     crypto.PrivateKey // *rsa.PrivateKey atau ed25519.PrivateKey
+	Public      crypto.PublicKey  // *rsa.PublicKey atau ed25519.PublicKey
+	ActivatesAt time.Time
+	ExpiresAt   *time.Time
+}
+
+// Method mengembalikan signing method JWT untuk algoritma key
+func (k *Key) Method() jwt.SigningMethod {
+	if k.Algorithm == AlgorithmEdDSA {
+		return jwt.SigningMethodEdDSA
+	}
+	return jwt.SigningMethodRS256
+}
+
+// JWK adalah public key dalam format JSON Web Key (RFC 7517)
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`   // RSA modulus
+	E   string `json:"e,omitempty"`   // RSA exponent
+	Crv string `json:"crv,omitempty"` // Kurva OKP (Ed25519)
+	X   string `json:"x,omitempty"`   // Public key OKP
+}
+
+// JWKSet adalah isi /.well-known/jwks.json
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWK mengembalikan public key dalam format JWK
+func (k *Key) JWK() JWK {
+	jwk := publicJWK(k.Public)
+	jwk.Kid = k.KID
+	jwk.Use = "sig"
+	jwk.Alg = k.Algorithm
+	return jwk
+}
+
+// publicJWK membuat JWK berisi parameter public key saja (tanpa kid/use/alg)
+func publicJWK(public crypto.PublicKey) JWK {
+	switch key := public.(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			Kty: "RSA",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}
+	case ed25519.PublicKey:
+		return JWK{
+			Kty: "OKP",
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(key),
+		}
+	}
+	return JWK{}
+}
+
+// thumbprint menghitung JWK thumbprint (RFC 7638) yang dipakai sebagai kid
+// Member wajib diurutkan secara leksikografis tanpa spasi
+func thumbprint(public crypto.PublicKey) (string, error) {
+	jwk := publicJWK(public)
+	var canonical []byte
+	var err error
+	switch jwk.Kty {
+	case "RSA":
+		canonical, err = json.Marshal(struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{jwk.E, jwk.Kty, jwk.N})
+	case "OKP":
+		canonical, err = json.Marshal(struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{jwk.Crv, jwk.Kty, jwk.X})
+	default:
+		return "", errors.New("unsupported public key type")
+	}
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(canonical)
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+// generateKey membuat key pair baru untuk algoritma
+func generateKey(algorithm string) (crypto.PrivateKey, crypto.PublicKey, error) {
+	switch algorithm {
+	case AlgorithmRS256:
+		private, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+		if err != nil {
+			return nil, nil, err
+		}
+		return private, &private.PublicKey, nil
+	case AlgorithmEdDSA:
+		public, private, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, nil, err
+		}
+		return private, public, nil
+	}
+	return nil, nil, fmt.Errorf("unsupported signing algorithm %q", algorithm)
+}
+
+// encodePrivateKey menyimpan private key sebagai PKCS#8 PEM
+func encodePrivateKey(private crypto.PrivateKey) (string, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), nil
+}
+
+// encodePublicKey menyimpan public key sebagai PKIX PEM
+func encodePublicKey(public crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(public)
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})), nil
+}
+
+// decodePrivateKey membaca private key PKCS#8 PEM dan memastikan tipenya sesuai algoritma
+func decodePrivateKey(algorithm, data string) (crypto.PrivateKey, crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, nil, errors.New("invalid private key PEM")
+	}
+	private, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch key := private.(type) {
+	case *rsa.PrivateKey:
+		if algorithm == AlgorithmRS256 {
+			return key, &key.PublicKey, nil
+		}
+	case ed25519.PrivateKey:
+		if algorithm == AlgorithmEdDSA {
+			return key, key.Public(), nil
+		}
+	}
+	return nil, nil, fmt.Errorf("private key does not match algorithm %q", algorithm)
+}
@@ -0,0 +1,46 @@
+package mailer
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+)
+
+// FileMailer menulis setiap email sebagai file .eml di dir
+// Dipakai untuk development lokal dan test agar email bisa diperiksa tanpa server SMTP
+type FileMailer struct {
+	dir     string
+	from    string
+	counter atomic.Uint64
+}
+
+// Send implements Mailer.
+func (m *FileMailer) Send(msg Message) error {
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s-%d.eml", time.Now().UTC().Format("20060102T150405.000000000"), m.counter.Add(1))
+	return os.WriteFile(filepath.Join(m.dir, name), buildMessage(m.from, msg), 0o600)
+}
+
+// LogMailer menulis email ke log aplikasi, tidak ada email yang benar-benar dikirim
+type LogMailer struct {
+	from string
+}
+
+// Send implements Mailer.
+func (m *LogMailer) Send(msg Message) error {
+	log.Printf("📧 Mail from %s to %s\nSubject: %s\n\n%s", m.from, msg.To, msg.Subject, msg.Body)
+	return nil
+}
+
+func NewFileMailer(dir, from string) Mailer {
+	return &FileMailer{dir: dir, from: from}
+}
+
+func NewLogMailer(from string) Mailer {
+	return &LogMailer{from: from}
+}
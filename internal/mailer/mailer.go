@@ -0,0 +1,35 @@
+// Package mailer handles outbound email
+// Implementasi dipilih lewat MAIL_DRIVER: smtp, file, atau log (default)
+package mailer
+
+import (
+	"log"
+	"rest-api/config"
+)
+
+// Message adalah email plain text yang akan dikirim
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer adalah abstraksi pengiriman email
+type Mailer interface {
+	Send(msg Message) error
+}
+
+// New membuat Mailer sesuai konfigurasi
+// Driver yang tidak dikenal fallback ke log dengan warning
+func New(cfg *config.Config) Mailer {
+	switch cfg.MailDriver {
+	case "smtp":
+		return NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.MailFrom)
+	case "file":
+		return NewFileMailer(cfg.MailFileDir, cfg.MailFrom)
+	case "log", "":
+		return NewLogMailer(cfg.MailFrom)
+	}
+	log.Printf("Warning: unknown MAIL_DRIVER %q, using log mailer", cfg.MailDriver)
+	return NewLogMailer(cfg.MailFrom)
+}
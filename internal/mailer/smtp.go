@@ -0,0 +1,46 @@
+package mailer
+
+import (
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// SMTPMailer mengirim email lewat server SMTP (STARTTLS otomatis jika didukung server)
+type SMTPMailer struct {
+	host     string
+	port     string
+	username string
+	password string
+	from     string
+}
+
+// Send implements Mailer.
+func (m *SMTPMailer) Send(msg Message) error {
+	var auth smtp.Auth
+	if m.username != "" {
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+	addr := net.JoinHostPort(m.host, m.port)
+	return smtp.SendMail(addr, auth, m.from, []string{msg.To}, buildMessage(m.from, msg))
+}
+
+// buildMessage menyusun email RFC 5322 sederhana (plain text, UTF-8)
+func buildMessage(from string, msg Message) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
+
+func NewSMTPMailer(host, port, username, password, from string) Mailer {
+	return &SMTPMailer{host: host, port: port, username: username, password: password, from: from}
+}
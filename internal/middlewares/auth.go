@@ -3,43 +3,67 @@
 package middlewares
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"strings"
+	"sync"
 	"time"
 
 	"rest-api/config"
 	"rest-api/internal/database"
+	"rest-api/internal/jwtkeys"
 	"rest-api/internal/models"
+	"rest-api/internal/repositories"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// sessionTouchInterval adalah jeda minimum antar pembaruan last seen session
+const sessionTouchInterval = time.Minute
+
 // Claims adalah struct untuk JWT payload
-// Berisi user ID dan standard JWT claims (exp, iat, dll)
+// Berisi user ID dan standard JWT claims (exp, iat, jti, dll)
+// RegisteredClaims.ID (jti) dipakai untuk mencabut token sebelum expired
 type Claims struct {
-	ID uint `json:"id"` // User ID dari database
+	ID       uint   `json:"id"`  // User ID dari database
+	FamilyID string `json:"fid"` // Family refresh token tempat access token ini diterbitkan
 	jwt.RegisteredClaims
 }
 
 // Auth adalah middleware untuk autentikasi user
 // Middleware ini akan:
 // 1. Mengambil token dari Authorization header atau cookie
-// 2. Memverifikasi dan parse JWT token
+// 2. Memverifikasi dan parse JWT token, atau mencari personal access token (pat_...)
 // 3. Mengambil user dari database berdasarkan ID di token
 // 4. Menyimpan user object di context (c.Locals) untuk digunakan di handler
 // Parameter:
 //   - cfg: Config object yang berisi JWT secret
 // Returns: Fiber handler function
 // Usage: app.Get("/protected", middleware.Auth(cfg), handler)
+// User dengan email belum terverifikasi hanya boleh melakukan action di UNVERIFIED_ALLOWED_ACTIONS
 func Auth(cfg *config.Config) fiber.Handler {
+	return authenticate(cfg, true)
+}
+
+// AuthAllowUnverified sama seperti Auth tetapi tidak membatasi user yang belum verifikasi email
+// Dipakai untuk pengelolaan akun (profil, session, logout, kirim ulang verifikasi)
+func AuthAllowUnverified(cfg *config.Config) fiber.Handler {
+	return authenticate(cfg, false)
+}
+
+func authenticate(cfg *config.Config, enforceVerification bool) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		// Ambil token dari Authorization header (format: "Bearer <token>")
 		token := c.Get("Authorization")
+		fromCookie := false
 		if token != "" && strings.HasPrefix(token, "Bearer ") {
 			token = strings.TrimPrefix(token, "Bearer ")
 		} else {
 			// Jika tidak ada di header, coba ambil dari cookie
 			token = c.Cookies("token")
+			fromCookie = true
 		}
 
 		// Jika token tidak ditemukan di header maupun cookie
@@ -49,59 +73,192 @@ func Auth(cfg *config.Config) fiber.Handler {
 			})
 		}
 
-		// Parse dan verify JWT token
-		claims := &Claims{}
-		tkn, err := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
-			return []byte(cfg.JWTSecret), nil // Secret key untuk verify signature
-		})
+		// Personal access token (pat_...) untuk script dan integrasi, scope dicek oleh RequireScope
+		var userID uint
+		var session *models.Session
+		if strings.HasPrefix(token, PersonalAccessTokenPrefix) {
+			accessToken, ok := authenticateAccessToken(token)
+			if !ok {
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+					"message": "Token tidak valid atau kadaluarsa.",
+				})
+			}
+			c.Locals("accessToken", accessToken)
+			userID = accessToken.UserID
+		} else {
+			var claims *Claims
+			var message string
+			claims, session, message = authenticateJWT(cfg, token)
+			if message != "" {
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+					"message": message,
+				})
+			}
+			// Claims dan session disimpan untuk handler yang butuh jti/family (contoh: logout, daftar session)
+			c.Locals("claims", claims)
+			c.Locals("session", session)
+			userID = claims.ID
+		}
 
-		// Jika token invalid atau expired
-		if err != nil || !tkn.Valid {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"message": "Token tidak valid atau kadaluarsa.",
+		// Cookie dikirim otomatis oleh browser, termasuk dari situs lain (CSRF)
+		// Request yang mengubah data lewat cookie wajib membawa header X-CSRF-Token; client dengan header Authorization tidak terpengaruh
+		if fromCookie && requestAction(c) == ActionWrite && !validCSRF(c, cfg, session) {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"message": "Token CSRF tidak valid atau tidak ditemukan.",
 			})
 		}
 
-		// Ambil user dari database berdasarkan ID di claims
+		// Ambil user dari database berdasarkan ID di token
 		var user models.User
-		if err := database.DB.First(&user, claims.ID).Error; err != nil {
+		if err := database.DB.First(&user, userID).Error; err != nil {
 			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
 				"message": "User tidak ditemukan.",
 			})
 		}
 
+		// Akun yang dinonaktifkan admin tidak bisa dipakai, termasuk lewat personal access token
+		if user.DisabledAt != nil {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"message": "Akun telah dinonaktifkan.",
+			})
+		}
+
+		if enforceVerification && user.EmailVerifiedAt == nil && !UnverifiedAllowed(cfg, requestAction(c)) {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"message": "Email belum diverifikasi. Silakan cek email untuk link verifikasi.",
+			})
+		}
+
 		// Simpan user object di context untuk digunakan di handler
 		// Cara akses di handler: user := c.Locals("user").(*models.User)
 		c.Locals("user", &user)
+
+		// Batas request per user (RATE_LIMIT_USER) dan per personal access token (RATE_LIMIT_TOKEN)
+		limits := loadRateLimits(cfg)
+		if denied := checkRateLimits(c, limits.user, limits.token); denied != nil {
+			return rateLimitExceeded(c, denied)
+		}
 		return c.Next() // Lanjut ke handler berikutnya
 	}
 }
 
-// GenerateToken membuat JWT token baru untuk user
-// Function ini dipanggil saat user login atau register
+// authenticateJWT memverifikasi access token (JWT) beserta session-nya
+// Returns pesan error untuk client jika token tidak bisa dipakai
+func authenticateJWT(cfg *config.Config, token string) (*Claims, *models.Session, string) {
+	// Parse dan verify JWT token
+	claims := &Claims{}
+	// Public key dipilih berdasarkan kid; algoritma selain RS256/EdDSA, atau yang tidak sesuai key, ditolak
+	tkn, err := jwt.ParseWithClaims(token, claims, SigningKeys(cfg).Keyfunc, jwt.WithValidMethods(jwtkeys.Algorithms))
+
+	// Jika token invalid, expired, atau tidak punya jti (token lama sebelum revocation)
+	if err != nil || !tkn.Valid || claims.RegisteredClaims.ID == "" {
+		return nil, nil, "Token tidak valid atau kadaluarsa."
+	}
+
+	// Tolak token yang sudah dicabut (logout atau refresh token reuse)
+	var revoked int64
+	if err := database.DB.Model(&models.RevokedToken{}).
+		Where("jti = ?", claims.RegisteredClaims.ID).
+		Count(&revoked).Error; err != nil || revoked > 0 {
+		return nil, nil, "Token tidak valid atau kadaluarsa."
+	}
+
+	// Tolak token dari session yang sudah diakhiri (logout, log out everywhere, dsb)
+	var session models.Session
+	if err := database.DB.
+		Where("family_id = ? AND user_id = ? AND revoked_at IS NULL", claims.FamilyID, claims.ID).
+		First(&session).Error; err != nil {
+		return nil, nil, "Sesi sudah berakhir. Silakan login kembali."
+	}
+	// Last seen diperbarui paling sering sekali per sessionTouchInterval agar tidak menulis di setiap request
+	if now := time.Now().UTC(); now.Sub(session.LastSeenAt) > sessionTouchInterval {
+		database.DB.Model(&session).UpdateColumn("last_seen_at", now)
+	}
+	return claims, &session, ""
+}
+
+// authenticateAccessToken mencari personal access token yang masih berlaku berdasarkan hash-nya
+func authenticateAccessToken(token string) (*models.PersonalAccessToken, bool) {
+	sum := sha256.Sum256([]byte(token))
+	var accessToken models.PersonalAccessToken
+	if err := database.DB.Where("token_hash = ?", hex.EncodeToString(sum[:])).First(&accessToken).Error; err != nil {
+		return nil, false
+	}
+	now := time.Now().UTC()
+	if accessToken.ExpiresAt != nil && now.After(*accessToken.ExpiresAt) {
+		return nil, false
+	}
+	// Sama seperti session, last used cukup diperbarui sekali per sessionTouchInterval
+	if accessToken.LastUsedAt == nil || now.Sub(*accessToken.LastUsedAt) > sessionTouchInterval {
+		database.DB.Model(&accessToken).UpdateColumn("last_used_at", now)
+		accessToken.LastUsedAt = &now
+	}
+	return &accessToken, true
+}
+
+// GenerateToken membuat access token (JWT) baru untuk user
+// Function ini dipanggil saat user login atau refresh token
 // Parameters:
 //   - userID: ID user dari database
-//   - cfg: Config object yang berisi JWT secret dan expiration time
-// Returns: JWT token string dan error jika ada
-func GenerateToken(userID uint, cfg *config.Config) (string, error) {
-	// Parse duration dari config (contoh: "168h" = 7 hari)
+//   - familyID: ID family refresh token yang menerbitkan access token ini
+//   - cfg: Config object yang berisi algoritma signing dan expiration time
+// Returns: JWT token string, claims (berisi jti dan expiry) dan error jika ada
+func GenerateToken(userID uint, familyID string, cfg *config.Config) (string, *Claims, error) {
+	// Parse duration dari config (contoh: "15m")
 	duration, err := time.ParseDuration(cfg.JWTExpires)
 	if err != nil {
-		duration = 168 * time.Hour // Default 7 hari jika parsing gagal
+		duration = 15 * time.Minute // Default 15 menit jika parsing gagal
 	}
 
-	// Buat claims dengan user ID dan standard claims
-	claims := Claims{
-		ID: userID,
+	// Buat claims dengan user ID, family dan standard claims
+	now := time.Now()
+	claims := &Claims{
+		ID:       userID,
+		FamilyID: familyID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(duration)), // Token expiry time
-			IssuedAt:  jwt.NewNumericDate(time.Now()),               // Token issued time
+			ID:        NewTokenID(),                          // jti, unik per token
+			ExpiresAt: jwt.NewNumericDate(now.Add(duration)), // Token expiry time
+			IssuedAt:  jwt.NewNumericDate(now),               // Token issued time
 		},
 	}
 
-	// Buat token dengan signing method HS256
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	
-	// Sign token dengan secret key dan return token string
-	return token.SignedString([]byte(cfg.JWTSecret))
+	// Ambil key signing yang sedang aktif (RS256 atau EdDSA sesuai JWT_ALGORITHM)
+	key, err := SigningKeys(cfg).SigningKey()
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Buat token dengan kid di header agar verifier bisa memilih public key dari JWKS
+	token := jwt.NewWithClaims(key.Method(), claims)
+	token.Header["kid"] = key.KID
+
+	// Sign token dengan private key dan return token string
+	signed, err := token.SignedString(key.Private)
+	if err != nil {
+		return "", nil, err
+	}
+	return signed, claims, nil
+}
+
+var (
+	signingKeysOnce sync.Once
+	signingKeys     *jwtkeys.KeyRing
+)
+
+// SigningKeys mengembalikan KeyRing bersama untuk signing dan verifikasi access token
+// Dipakai juga oleh endpoint JWKS dan job rotasi key
+func SigningKeys(cfg *config.Config) *jwtkeys.KeyRing {
+	signingKeysOnce.Do(func() {
+		signingKeys = jwtkeys.NewKeyRing(repositories.NewSigningKeyRepository(database.DB), cfg)
+	})
+	return signingKeys
+}
+
+// NewTokenID membuat ID acak 128-bit dalam bentuk hex (dipakai untuk jti dan family ID)
+func NewTokenID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err) // crypto/rand tidak pernah gagal di platform yang didukung
+	}
+	return hex.EncodeToString(b)
 }
@@ -0,0 +1,40 @@
+package middlewares
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+
+	"rest-api/config"
+	"rest-api/internal/models"
+	"rest-api/internal/secretbox"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Nama cookie dan header token CSRF
+// Cookie bisa dibaca JavaScript frontend, lalu nilainya dikirim ulang di header untuk request yang mengubah data
+const (
+	CSRFCookieName = "csrf_token"
+	CSRFHeaderName = "X-CSRF-Token"
+)
+
+// CSRFToken membuat token CSRF untuk session (family refresh token)
+// Token adalah HMAC dari family ID sehingga tidak perlu disimpan, tetap sama selama session,
+// dan cookie yang disisipkan pihak lain (contoh: dari subdomain) tidak cocok dengan session korban
+// Kunci HMAC adalah CSRF_SECRET yang wajib diisi, agar token tidak bisa dibuat hanya dengan mengetahui family ID
+func CSRFToken(cfg *config.Config, familyID string) string {
+	mac := hmac.New(sha256.New, secretbox.DeriveKey(cfg.CSRFSecret))
+	mac.Write([]byte(familyID))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// validCSRF mengecek header X-CSRF-Token terhadap session yang sedang login
+// Request yang login lewat cookie tanpa session (personal access token) selalu ditolak
+func validCSRF(c *fiber.Ctx, cfg *config.Config, session *models.Session) bool {
+	header := c.Get(CSRFHeaderName)
+	if header == "" || session == nil {
+		return false
+	}
+	return hmac.Equal([]byte(header), []byte(CSRFToken(cfg, session.FamilyID)))
+}
@@ -0,0 +1,52 @@
+package middlewares
+
+import (
+	"rest-api/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Permission yang dimiliki role (lihat rolePermissions)
+const (
+	PermissionViewUsers   = "view_users"   // Melihat, mencari dan melihat statistik akun user lain
+	PermissionManageUsers = "manage_users" // Menonaktifkan, menghapus, mengubah role dan memaksa reset password
+)
+
+// rolePermissions memetakan role ke permission-nya; role user biasa tidak punya permission admin
+var rolePermissions = map[string][]string{
+	models.RoleAdmin: {PermissionViewUsers, PermissionManageUsers},
+}
+
+// Roles adalah daftar role yang valid
+var Roles = []string{models.RoleUser, models.RoleAdmin}
+
+// HasPermission mengecek apakah role memiliki permission
+func HasPermission(role, permission string) bool {
+	for _, p := range rolePermissions[role] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// RequirePermission menolak request jika role user tidak memiliki semua permission
+// Dipasang setelah Auth, contoh: admin.Get("/users", middlewares.Auth(cfg), middlewares.RequirePermission(middlewares.PermissionViewUsers), handler)
+func RequirePermission(permissions ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		user, ok := c.Locals("user").(*models.User)
+		if !ok {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"message": "Akses ditolak. Token tidak ditemukan.",
+			})
+		}
+		for _, permission := range permissions {
+			if !HasPermission(user.Role, permission) {
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+					"message": "Akses ditolak. Anda tidak memiliki izin untuk aksi ini.",
+				})
+			}
+		}
+		return c.Next()
+	}
+}
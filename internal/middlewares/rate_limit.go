@@ -0,0 +1,230 @@
+package middlewares
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"rest-api/config"
+	"rest-api/internal/models"
+	"rest-api/internal/ratelimit"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// KeyFunc menentukan key bucket untuk sebuah request
+// String kosong berarti request tidak dibatasi oleh limiter tersebut
+type KeyFunc func(c *fiber.Ctx) string
+
+// KeyByIP membatasi per alamat IP client
+func KeyByIP(c *fiber.Ctx) string {
+	return "ip:" + c.IP()
+}
+
+// KeyByUser membatasi per user yang login, fallback ke IP jika belum ada user
+// Dipasang setelah Auth
+func KeyByUser(c *fiber.Ctx) string {
+	if user, ok := c.Locals("user").(*models.User); ok {
+		return fmt.Sprintf("user:%d", user.ID)
+	}
+	return KeyByIP(c)
+}
+
+// KeyByToken membatasi per personal access token; request dengan login biasa tidak dibatasi
+// Dipasang setelah Auth
+func KeyByToken(c *fiber.Ctx) string {
+	if token, ok := c.Locals("accessToken").(*models.PersonalAccessToken); ok {
+		return fmt.Sprintf("token:%d", token.ID)
+	}
+	return ""
+}
+
+// rateLimiter adalah satu aturan rate limit dengan nama sebagai namespace key
+type rateLimiter struct {
+	store ratelimit.Store
+	name  string
+	limit ratelimit.Limit
+	key   KeyFunc
+}
+
+// RateLimit membatasi request dengan token bucket per key dan mengirim header RateLimit-*
+// Contoh per route: tasks.Post("/bulk", middlewares.Auth(cfg), middlewares.RateLimit(store, "tasks-bulk", limit, middlewares.KeyByUser), handler)
+func RateLimit(store ratelimit.Store, name string, limit ratelimit.Limit, key KeyFunc) fiber.Handler {
+	limiter := &rateLimiter{store: store, name: name, limit: limit, key: key}
+	return func(c *fiber.Ctx) error {
+		if denied := checkRateLimits(c, limiter); denied != nil {
+			return rateLimitExceeded(c, denied)
+		}
+		return c.Next()
+	}
+}
+
+// checkRateLimits mengambil satu token dari setiap limiter
+// Mengembalikan hasil limiter pertama yang menolak, nil jika request diizinkan
+// Jika store gagal (contoh: Redis tidak bisa dihubungi) request tetap diizinkan
+func checkRateLimits(c *fiber.Ctx, limiters ...*rateLimiter) *ratelimit.Result {
+	for _, limiter := range limiters {
+		if limiter == nil {
+			continue
+		}
+		key := limiter.key(c)
+		if key == "" {
+			continue
+		}
+		result, err := limiter.store.Take(limiter.name+":"+key, limiter.limit)
+		if err != nil {
+			log.Printf("Warning: rate limit store error: %v", err)
+			continue
+		}
+		setRateLimitHeaders(c, limiter.limit, result)
+		if !result.Allowed {
+			return &result
+		}
+	}
+	return nil
+}
+
+// setRateLimitHeaders mengirim header RateLimit-Limit/Remaining/Reset/Policy
+// Jika beberapa limiter berlaku, header menampilkan limiter dengan sisa paling sedikit
+func setRateLimitHeaders(c *fiber.Ctx, limit ratelimit.Limit, result ratelimit.Result) {
+	if current := c.GetRespHeader("RateLimit-Remaining"); current != "" {
+		if remaining, err := strconv.Atoi(current); err == nil && remaining <= result.Remaining {
+			return
+		}
+	}
+	c.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+	c.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	c.Set("RateLimit-Reset", strconv.Itoa(int(math.Ceil(result.Reset.Seconds()))))
+	c.Set("RateLimit-Policy", limit.Policy())
+}
+
+func rateLimitExceeded(c *fiber.Ctx, result *ratelimit.Result) error {
+	c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(result.RetryAfter.Seconds()))))
+	return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+		"message": "Terlalu banyak request. Silakan coba lagi nanti.",
+	})
+}
+
+// routeRateLimit adalah aturan RATE_LIMIT_ROUTES untuk satu method dan path
+type routeRateLimit struct {
+	method  string // Kosong = semua method
+	path    string
+	prefix  bool // Path diakhiri *, cocok dengan semua path yang diawali path
+	limiter *rateLimiter
+}
+
+func (r *routeRateLimit) matches(method, path string) bool {
+	if r.method != "" && r.method != method {
+		return false
+	}
+	if r.prefix {
+		return strings.HasPrefix(path, r.path)
+	}
+	return strings.TrimRight(path, "/") == strings.TrimRight(r.path, "/")
+}
+
+// rateLimits adalah aturan rate limit dari konfigurasi, dipakai bersama oleh semua route
+type rateLimits struct {
+	global *rateLimiter
+	user   *rateLimiter
+	token  *rateLimiter
+	routes []routeRateLimit
+}
+
+var (
+	rateLimitsOnce sync.Once
+	sharedLimits   *rateLimits
+)
+
+// loadRateLimits membaca aturan rate limit dari konfigurasi sekali untuk seluruh aplikasi
+// Semua limiter memakai store yang sama agar bucket per user dibagi oleh semua route
+func loadRateLimits(cfg *config.Config) *rateLimits {
+	rateLimitsOnce.Do(func() {
+		store := ratelimit.New(cfg)
+		sharedLimits = &rateLimits{
+			global: newConfiguredLimiter(store, "global", "RATE_LIMIT_GLOBAL", cfg.RateLimitGlobal, KeyByIP),
+			user:   newConfiguredLimiter(store, "user", "RATE_LIMIT_USER", cfg.RateLimitUser, KeyByUser),
+			token:  newConfiguredLimiter(store, "token", "RATE_LIMIT_TOKEN", cfg.RateLimitToken, KeyByToken),
+			routes: parseRouteRateLimits(store, cfg.RateLimitRoutes),
+		}
+	})
+	return sharedLimits
+}
+
+// newConfiguredLimiter membuat limiter dari nilai konfigurasi, nil jika tanpa batas atau tidak valid
+func newConfiguredLimiter(store ratelimit.Store, name, setting, value string, key KeyFunc) *rateLimiter {
+	limit, ok, err := ratelimit.ParseLimit(value)
+	if err != nil {
+		log.Printf("Warning: invalid %s %q, rate limit disabled", setting, value)
+		return nil
+	}
+	if !ok {
+		return nil
+	}
+	return &rateLimiter{store: store, name: name, limit: limit, key: key}
+}
+
+// parseRouteRateLimits membaca RATE_LIMIT_ROUTES, contoh: POST /api/auth/login=10/1m;/api/tasks*=120/1m
+// Setiap aturan memiliki bucket sendiri per IP
+func parseRouteRateLimits(store ratelimit.Store, value string) []routeRateLimit {
+	var routes []routeRateLimit
+	for _, rule := range strings.Split(value, ";") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		separator := strings.LastIndex(rule, "=")
+		if separator < 0 {
+			log.Printf("Warning: invalid RATE_LIMIT_ROUTES rule %q", rule)
+			continue
+		}
+		pattern := strings.Fields(rule[:separator])
+		route := routeRateLimit{}
+		switch len(pattern) {
+		case 1:
+			route.path = pattern[0]
+		case 2:
+			route.method = strings.ToUpper(pattern[0])
+			route.path = pattern[1]
+		default:
+			log.Printf("Warning: invalid RATE_LIMIT_ROUTES rule %q", rule)
+			continue
+		}
+		if strings.HasSuffix(route.path, "*") {
+			route.prefix = true
+			route.path = strings.TrimSuffix(route.path, "*")
+		}
+		route.limiter = newConfiguredLimiter(store, "route:"+strings.Join(pattern, " "), "RATE_LIMIT_ROUTES", rule[separator+1:], KeyByIP)
+		if route.limiter == nil {
+			continue
+		}
+		routes = append(routes, route)
+	}
+	return routes
+}
+
+// RateLimitRequests menerapkan RATE_LIMIT_GLOBAL dan RATE_LIMIT_ROUTES (per IP) ke semua request
+// Dipasang di level app sebelum route: app.Use(middlewares.RateLimitRequests(cfg))
+// Batas per user dan per token (RATE_LIMIT_USER, RATE_LIMIT_TOKEN) diterapkan oleh Auth
+func RateLimitRequests(cfg *config.Config) fiber.Handler {
+	limits := loadRateLimits(cfg)
+	return func(c *fiber.Ctx) error {
+		// Preflight CORS tidak dihitung
+		if c.Method() == fiber.MethodOptions {
+			return c.Next()
+		}
+		limiters := []*rateLimiter{limits.global}
+		for i := range limits.routes {
+			if limits.routes[i].matches(c.Method(), c.Path()) {
+				limiters = append(limiters, limits.routes[i].limiter)
+			}
+		}
+		if denied := checkRateLimits(c, limiters...); denied != nil {
+			return rateLimitExceeded(c, denied)
+		}
+		return c.Next()
+	}
+}
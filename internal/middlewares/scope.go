@@ -0,0 +1,75 @@
+package middlewares
+
+import (
+	"strings"
+
+	"rest-api/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// PersonalAccessTokenPrefix membedakan personal access token dari JWT di header Authorization
+const PersonalAccessTokenPrefix = "pat_"
+
+// Scope yang bisa diberikan ke personal access token
+// Login biasa (JWT) selalu memiliki semua scope
+const (
+	ScopeTasksRead     = "tasks:read"
+	ScopeTasksWrite    = "tasks:write"
+	ScopeProjectsRead  = "projects:read"
+	ScopeProjectsWrite = "projects:write"
+	ScopeTagsRead      = "tags:read"
+	ScopeTagsWrite     = "tags:write"
+	ScopeUserRead      = "user:read"
+	ScopeUserWrite     = "user:write"
+	// ScopeSession hanya dimiliki login biasa, tidak bisa diberikan ke personal access token
+	// Dipakai untuk pengelolaan akun yang sensitif (session, 2FA, token API, logout)
+	ScopeSession = "session"
+)
+
+// TokenScopes adalah daftar scope yang boleh dipilih saat membuat personal access token
+var TokenScopes = []string{
+	ScopeTasksRead, ScopeTasksWrite,
+	ScopeProjectsRead, ScopeProjectsWrite,
+	ScopeTagsRead, ScopeTagsWrite,
+	ScopeUserRead, ScopeUserWrite,
+}
+
+// RequireScope menolak request dengan personal access token yang tidak memiliki semua scope
+// Dipasang setelah Auth, contoh: tasks.Get("/", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeTasksRead), handler)
+func RequireScope(scopes ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		for _, scope := range scopes {
+			if !HasScope(c, scope) {
+				return ScopeMissing(c, scope)
+			}
+		}
+		return c.Next()
+	}
+}
+
+// HasScope mengecek scope untuk request saat ini; dipakai handler yang butuh scope tambahan tergantung isi request
+func HasScope(c *fiber.Ctx, scope string) bool {
+	token, ok := c.Locals("accessToken").(*models.PersonalAccessToken)
+	if !ok {
+		// Login biasa (JWT)
+		return true
+	}
+	return containsScope(strings.Split(token.Scopes, ","), scope)
+}
+
+// ScopeMissing mengirim 403 untuk token yang tidak memiliki scope
+func ScopeMissing(c *fiber.Ctx, scope string) error {
+	return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+		"message": "Token tidak memiliki scope " + scope + ".",
+	})
+}
+
+func containsScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package middlewares
+
+import (
+	"rest-api/config"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Aksi yang bisa diizinkan untuk akun dengan email belum terverifikasi (UNVERIFIED_ALLOWED_ACTIONS)
+const (
+	ActionLogin = "login" // Login dan mendapatkan token
+	ActionRead  = "read"  // Request GET/HEAD ke endpoint yang dilindungi
+	ActionWrite = "write" // Request lain (POST, PUT, DELETE, ...) ke endpoint yang dilindungi
+)
+
+// UnverifiedAllowed mengecek apakah akun yang belum verifikasi email boleh melakukan action
+func UnverifiedAllowed(cfg *config.Config, action string) bool {
+	for _, allowed := range strings.Split(cfg.UnverifiedAllowedActions, ",") {
+		if strings.TrimSpace(allowed) == action {
+			return true
+		}
+	}
+	return false
+}
+
+// requestAction menentukan action dari HTTP method
+func requestAction(c *fiber.Ctx) string {
+	if c.Method() == fiber.MethodGet || c.Method() == fiber.MethodHead {
+		return ActionRead
+	}
+	return ActionWrite
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// ChecklistItem adalah item checklist ringan di dalam sebuah task
+// Berbeda dengan subtask, item checklist tidak punya status workflow, due date, atau tag
+type ChecklistItem struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	TaskID    uint      `gorm:"index;not null" json:"taskId"`
+	Title     string    `gorm:"size:255;not null" json:"title"`
+	IsDone    bool      `gorm:"default:false" json:"isDone"`
+	Position  int       `gorm:"not null;default:0" json:"position"` // Urutan item di dalam checklist
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
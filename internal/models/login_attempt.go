@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// LoginAttempt mencatat login gagal berturut-turut per key (akun atau IP)
+// Key berformat "account:<email>" atau "ip:<alamat IP>"
+type LoginAttempt struct {
+	Key           string     `gorm:"primaryKey;size:191" json:"key"`
+	Failures      int        `gorm:"not null;default:0" json:"failures"`
+	LastFailureAt time.Time  `gorm:"index" json:"lastFailureAt"`
+	LockedUntil   *time.Time `json:"lockedUntil"` // Terisi saat jumlah gagal mencapai batas lockout
+}
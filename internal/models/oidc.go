@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// OIDCState menyimpan state login OIDC yang sedang berjalan, dari redirect ke provider sampai callback
+// State hanya disimpan dalam bentuk hash dan dihapus saat callback (sekali pakai)
+type OIDCState struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	StateHash    string    `gorm:"size:64;uniqueIndex;not null" json:"-"` // SHA-256 hex dari parameter state
+	Nonce        string    `gorm:"size:64;not null" json:"-"`             // Harus sama dengan claim nonce di ID token
+	CodeVerifier string    `gorm:"size:128;not null" json:"-"`            // PKCE code verifier
+	ExpiresAt    time.Time `gorm:"index" json:"expiresAt"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// UserIdentity menghubungkan user dengan akun di OpenID provider (pasangan issuer + subject)
+type UserIdentity struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	UserID      uint       `gorm:"index;not null" json:"userId"`
+	Issuer      string     `gorm:"size:191;uniqueIndex:idx_identity_issuer_subject;not null" json:"issuer"`
+	Subject     string     `gorm:"size:191;uniqueIndex:idx_identity_issuer_subject;not null" json:"subject"` // Claim sub, stabil walau email di provider berubah
+	Email       string     `gorm:"size:255" json:"email"`                                                    // Email dari provider saat terakhir login
+	LastLoginAt *time.Time `json:"lastLoginAt"`
+	CreatedAt   time.Time  `json:"createdAt"`
+
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+}
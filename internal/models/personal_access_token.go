@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// PersonalAccessToken adalah token API buatan user untuk script dan integrasi
+// Token asli hanya ditampilkan sekali saat dibuat, database menyimpan hash-nya
+type PersonalAccessToken struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	UserID     uint       `gorm:"index;not null" json:"userId"`
+	Name       string     `gorm:"size:100;not null" json:"name"`
+	Prefix     string     `gorm:"size:12;not null" json:"prefix"`        // Awal token untuk membantu user mengenali token
+	TokenHash  string     `gorm:"size:64;uniqueIndex;not null" json:"-"` // SHA-256 dari token
+	Scopes     string     `gorm:"size:255;not null" json:"scopes"`       // Dipisah koma, contoh: tasks:read,tasks:write
+	ExpiresAt  *time.Time `json:"expiresAt"`                             // Kosong = tidak pernah expired
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	CreatedAt  time.Time  `json:"createdAt"`
+
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+}
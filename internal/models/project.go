@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Project mengelompokkan task milik user (contoh: Work, Personal)
+// Task tanpa project dianggap berada di inbox
+type Project struct {
+	ID          uint           `gorm:"primaryKey" json:"id"`
+	UserID      uint           `gorm:"index;not null" json:"userId"`
+	Name        string         `gorm:"size:100;not null" json:"name"`
+	Description string         `json:"description"`
+	Color       string         `gorm:"size:7" json:"color"` // Hex color, contoh: #ff8800
+	ArchivedAt  *time.Time     `gorm:"index" json:"archivedAt"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"deletedAt"` // Soft delete: project berada di trash
+
+	User  User   `gorm:"foreignKey:UserID" json:"-"`
+	Tasks []Task `gorm:"foreignKey:ProjectID" json:"tasks,omitempty"`
+}
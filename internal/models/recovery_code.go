@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// RecoveryCode adalah kode cadangan 2FA sekali pakai, dipakai jika aplikasi authenticator hilang
+// Kode asli hanya ditampilkan sekali saat dibuat, database menyimpan hash-nya
+type RecoveryCode struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"index;not null" json:"userId"`
+	CodeHash  string     `gorm:"size:64;not null" json:"-"` // SHA-256 dari kode yang sudah dinormalisasi
+	UsedAt    *time.Time `json:"usedAt"`
+	CreatedAt time.Time  `json:"createdAt"`
+
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+}
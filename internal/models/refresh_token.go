@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// RefreshToken adalah refresh token yang disimpan di server
+// Setiap refresh menghasilkan token baru dalam family yang sama (rotasi);
+// token yang sudah dirotasi tapi dipakai lagi dianggap bocor dan seluruh family dicabut
+type RefreshToken struct {
+	ID              uint       `gorm:"primaryKey" json:"id"`
+	UserID          uint       `gorm:"index;not null" json:"userId"`
+	FamilyID        string     `gorm:"size:36;index;not null" json:"familyId"` // Sama untuk semua token hasil rotasi dari satu login
+	TokenHash       string     `gorm:"size:64;uniqueIndex;not null" json:"-"`  // SHA-256 dari token, token asli tidak disimpan
+	AccessJTI       string     `gorm:"size:36;not null" json:"-"`              // jti access token yang diterbitkan bersama token ini
+	AccessExpiresAt time.Time  `json:"-"`                                      // Expiry access token tersebut
+	ExpiresAt       time.Time  `gorm:"index;not null" json:"expiresAt"`
+	UsedAt          *time.Time `json:"usedAt"`    // Diisi saat token dirotasi
+	RevokedAt       *time.Time `json:"revokedAt"` // Diisi saat logout atau reuse terdeteksi
+	CreatedAt       time.Time  `json:"createdAt"`
+
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// RevokedToken mencatat jti access token yang dicabut sebelum expired
+// Baris boleh dihapus setelah ExpiresAt karena token sudah tidak valid
+type RevokedToken struct {
+	JTI       string    `gorm:"primaryKey;size:36" json:"jti"`
+	ExpiresAt time.Time `gorm:"index;not null" json:"expiresAt"`
+	CreatedAt time.Time `json:"createdAt"`
+}
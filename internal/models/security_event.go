@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// Jenis security event
+const (
+	SecurityEventAccountLocked = "account_locked" // Login ke satu akun dikunci sementara
+	SecurityEventIPLocked      = "ip_locked"      // Login dari satu IP dikunci sementara
+)
+
+// SecurityEvent adalah catatan audit kejadian keamanan (contoh: lockout karena brute force)
+type SecurityEvent struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Type      string    `gorm:"size:50;index;not null" json:"type"`
+	UserID    *uint     `gorm:"index" json:"userId"` // Kosong jika tidak terkait akun yang ada
+	IPAddress string    `gorm:"size:45" json:"ipAddress"`
+	Detail    string    `gorm:"size:255" json:"detail"`
+	CreatedAt time.Time `gorm:"index" json:"createdAt"`
+}
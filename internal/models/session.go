@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// Session mencatat satu login (perangkat/browser) milik user
+// Satu session sama dengan satu family refresh token; mengakhiri session
+// mencabut semua token dari login tersebut dan langsung ditolak middleware Auth
+type Session struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	UserID     uint       `gorm:"index;not null" json:"userId"`
+	FamilyID   string     `gorm:"size:36;uniqueIndex;not null" json:"-"` // Family refresh token dari login ini
+	UserAgent  string     `gorm:"size:255" json:"userAgent"`
+	IPAddress  string     `gorm:"size:45" json:"ipAddress"`
+	LastSeenAt time.Time  `gorm:"index" json:"lastSeenAt"`
+	RevokedAt  *time.Time `gorm:"index" json:"revokedAt"` // Diisi saat logout atau session diakhiri
+	CreatedAt  time.Time  `json:"createdAt"`
+
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+}
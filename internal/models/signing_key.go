@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// SigningKey adalah key pair untuk menandatangani access token (JWT)
+// Key terbaru yang sudah aktif dipakai untuk signing; key lama tetap dipakai verifikasi sampai ExpiresAt
+type SigningKey struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	KID         string     `gorm:"column:kid;size:64;uniqueIndex;not null" json:"kid"` // JWK thumbprint (RFC 7638)
+	Algorithm   string     `gorm:"size:10;not null" json:"algorithm"`                  // RS256 atau EdDSA
+	PrivateKey  string     `gorm:"type:text;not null" json:"-"`                        // PKCS#8 PEM, terenkripsi
+	PublicKey   string     `gorm:"type:text;not null" json:"publicKey"`                // PKIX PEM
+	ActivatesAt time.Time  `gorm:"index" json:"activatesAt"`                           // Sebelum waktu ini key hanya dipublikasikan di JWKS
+	ExpiresAt   *time.Time `gorm:"index" json:"expiresAt"`                             // Terisi saat key digantikan, setelahnya token dengan key ini ditolak
+	CreatedAt   time.Time  `json:"createdAt"`
+}
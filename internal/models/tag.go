@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// Tag adalah label milik user yang bisa dipasang ke banyak task
+// Relasi many-to-many disimpan di tabel join task_tags
+type Tag struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UserID    uint      `gorm:"not null;uniqueIndex:idx_tags_user_name" json:"userId"`
+	Name      string    `gorm:"size:50;not null;uniqueIndex:idx_tags_user_name" json:"name"`
+	Color     string    `gorm:"size:7" json:"color"` // Hex color, contoh: #ff8800
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+
+	User  User   `gorm:"foreignKey:UserID" json:"-"`
+	Tasks []Task `gorm:"many2many:task_tags" json:"tasks,omitempty"`
+}
@@ -1,17 +1,63 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Due state sebuah task, dihitung dari DueAt relatif terhadap waktu sekarang
+// di timezone milik task
+const (
+	DueStateNone     = "none"     // Task tidak punya due date
+	DueStateOverdue  = "overdue"  // Due date sudah lewat dan task belum selesai
+	DueStateToday    = "today"    // Due date jatuh hari ini
+	DueStateUpcoming = "upcoming" // Due date setelah hari ini
+)
+
+// Status workflow sebuah task
+// Transisi yang diizinkan antar status diatur oleh services.TaskWorkflow
+const (
+	TaskStatusTodo       = "todo"
+	TaskStatusInProgress = "in_progress"
+	TaskStatusBlocked    = "blocked"
+	TaskStatusDone       = "done"
+	TaskStatusCancelled  = "cancelled"
+)
+
+// Priority task disimpan sebagai integer agar bisa diurutkan
+const (
+	TaskPriorityLow    = 1
+	TaskPriorityMedium = 2
+	TaskPriorityHigh   = 3
+	TaskPriorityUrgent = 4
+)
 
 type Task struct {
-	ID          uint      `gorm:"primaryKey" json:"id"`
-	UserID      uint      `json:"userId"`
-	Title       string    `gorm:"not null" json:"title"`
-	Description string    `json:"description"`
-	IsCompleted bool      `gorm:"default:false" json:"isCompleted"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
-	
-	User        User      `gorm:"foreignKey:UserID" json:"user"`
-}
+	ID              uint           `gorm:"primaryKey" json:"id"`
+	UserID          uint           `json:"userId"`
+	ProjectID       *uint          `gorm:"index" json:"projectId"` // nil = task berada di inbox
+	ParentID        *uint          `gorm:"index" json:"parentId"`  // nil = task utama, selain itu subtask
+	Title           string         `gorm:"not null;index:idx_tasks_fulltext,class:FULLTEXT" json:"title"`
+	Description     string         `gorm:"index:idx_tasks_fulltext,class:FULLTEXT" json:"description"`
+	Status          string         `gorm:"size:20;not null;default:todo;index" json:"status"`
+	Priority        int            `gorm:"not null;default:2;index" json:"priority"`
+	StartAt         *time.Time     `gorm:"index" json:"startAt"`
+	DueAt           *time.Time     `gorm:"index" json:"dueAt"`
+	TimeZone        string         `gorm:"size:64;not null;default:UTC" json:"timeZone"` // IANA timezone, contoh: Asia/Jakarta
+	CompletedAt     *time.Time     `json:"completedAt"`                                  // Diisi saat status menjadi done
+	StatusChangedAt *time.Time     `json:"statusChangedAt"`                              // Waktu perubahan status terakhir
+	RecurrenceRule  string         `gorm:"size:255" json:"recurrenceRule"`               // RRULE, contoh: FREQ=WEEKLY;BYDAY=MO
+	SeriesID        *uint          `gorm:"index" json:"seriesId"`                        // ID occurrence pertama, nil untuk occurrence pertama
+	OccurrenceIndex int            `gorm:"not null;default:1" json:"occurrenceIndex"`    // Urutan occurrence di dalam series
+	CreatedAt       time.Time      `json:"createdAt"`
+	UpdatedAt       time.Time      `json:"updatedAt"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"deletedAt"` // Soft delete: task berada di trash, subtask ikut dengan waktu yang sama
 
+	User    User     `gorm:"foreignKey:UserID" json:"user"`
+	Project *Project `gorm:"foreignKey:ProjectID;constraint:OnDelete:SET NULL" json:"project,omitempty"`
+	Tags    []Tag    `gorm:"many2many:task_tags" json:"tags"`
 
+	Children       []Task          `gorm:"foreignKey:ParentID;constraint:OnDelete:CASCADE" json:"children,omitempty"`
+	ChecklistItems []ChecklistItem `gorm:"foreignKey:TaskID;constraint:OnDelete:CASCADE" json:"checklistItems,omitempty"`
+}
@@ -2,14 +2,26 @@ package models
 
 import "time"
 
+// Role user, menentukan permission (lihat middlewares.RequirePermission)
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
 type User struct {
-	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement"`
-	Username  string    `json:"username" gorm:"unique;not null"`
-	Email     string    `json:"email" gorm:"unique;not null"`
-	Password  string    `json:"-" gorm:"not null"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time    `json:"updated_at"`
+	ID                    uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	Username              string     `json:"username" gorm:"unique;not null"`
+	Email                 string     `json:"email" gorm:"unique;not null"`
+	Password              string     `json:"-" gorm:"not null"`
+	EmailVerifiedAt       *time.Time `json:"email_verified_at"`                    // Kosong = email belum diverifikasi
+	TOTPSecret            string     `json:"-" gorm:"column:totp_secret;size:255"` // Secret TOTP terenkripsi, terisi sejak enrollment dimulai
+	TOTPLastStep          int64      `json:"-" gorm:"column:totp_last_step"`       // Time step kode TOTP terakhir yang diterima, mencegah kode dipakai ulang
+	TwoFactorEnabledAt    *time.Time `json:"two_factor_enabled_at"`                // Kosong = 2FA tidak aktif
+	Role                  string     `json:"role" gorm:"size:20;not null;default:user;index"`
+	DisabledAt            *time.Time `json:"disabled_at"`                                           // Diisi admin saat akun dinonaktifkan
+	PasswordResetRequired bool       `json:"password_reset_required" gorm:"not null;default:false"` // Dipaksa admin, login ditolak sampai password direset
+	CreatedAt             time.Time  `json:"created_at"`
+	UpdatedAt             time.Time  `json:"updated_at"`
 
 	Tasks []Task `gorm:"foreignKey:UserID" json:"tasks"`
 }
-
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// Tujuan token sekali pakai yang dikirim lewat email
+const (
+	UserTokenPasswordReset  = "password_reset"
+	UserTokenEmailVerify    = "email_verify"    // Verifikasi email saat ini (setelah registrasi)
+	UserTokenEmailChange    = "email_change"    // Konfirmasi alamat email baru
+	UserTokenLoginChallenge = "login_challenge" // Langkah kedua login untuk akun dengan 2FA
+)
+
+// UserToken adalah token sekali pakai milik user (contoh: reset password, verifikasi email)
+// Token asli hanya dikirim ke user, database menyimpan hash-nya
+type UserToken struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"index;not null" json:"userId"`
+	Purpose   string     `gorm:"size:32;index;not null" json:"purpose"`
+	TokenHash string     `gorm:"size:64;uniqueIndex;not null" json:"-"` // SHA-256 dari token
+	Email     string     `gorm:"size:255" json:"email"`                 // Alamat tujuan token, untuk email_change berisi email baru
+	ExpiresAt time.Time  `gorm:"index;not null" json:"expiresAt"`
+	Attempts  int        `gorm:"not null;default:0" json:"-"` // Jumlah percobaan gagal (login challenge)
+	UsedAt    *time.Time `json:"usedAt"`                      // Diisi saat token dipakai atau dibatalkan
+	CreatedAt time.Time  `json:"createdAt"`
+
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+}
@@ -0,0 +1,209 @@
+package oidc
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Pengaturan validasi ID token
+const (
+	clockLeeway = time.Minute
+	// jwksReloadInterval membatasi fetch ulang JWKS saat ID token memakai kid yang belum dikenal
+	jwksReloadInterval = 10 * time.Second
+)
+
+// signingAlgorithms adalah algoritma ID token yang diterima; HS* dan none selalu ditolak
+var signingAlgorithms = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+// ErrInvalidIDToken dibungkus oleh semua error validasi ID token
+var ErrInvalidIDToken = errors.New("invalid id token")
+
+// IDTokenClaims adalah claim ID token yang dipakai untuk login
+type IDTokenClaims struct {
+	jwt.RegisteredClaims
+	Nonce             string `json:"nonce"`
+	AuthorizedParty   string `json:"azp,omitempty"`
+	Email             string `json:"email"`
+	EmailVerified     Bool   `json:"email_verified"`
+	Name              string `json:"name"`
+	PreferredUsername string `json:"preferred_username"`
+}
+
+// Bool menerima true/false maupun "true"/"false"; beberapa provider mengirim email_verified sebagai string
+type Bool bool
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *Bool) UnmarshalJSON(data []byte) error {
+	switch strings.Trim(string(data), `"`) {
+	case "true":
+		*b = true
+	case "false", "null", "":
+		*b = false
+	default:
+		return fmt.Errorf("invalid boolean %s", data)
+	}
+	return nil
+}
+
+// VerifyIDToken memvalidasi signature dan claim ID token dari token endpoint
+// Dicek: algoritma, signature lewat JWKS provider, iss, aud, azp, exp (wajib), iat, dan nonce dari awal login
+func (p *Provider) VerifyIDToken(raw, nonce string) (*IDTokenClaims, error) {
+	discovery, err := p.Discovery()
+	if err != nil {
+		return nil, err
+	}
+	claims := &IDTokenClaims{}
+	_, err = jwt.ParseWithClaims(raw, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.keys.lookup(p, discovery.JWKSURI, kid, token.Method.Alg())
+	},
+		jwt.WithValidMethods(signingAlgorithms),
+		jwt.WithIssuer(p.config.Issuer),
+		jwt.WithAudience(p.config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(clockLeeway),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+	// OpenID Connect Core 3.1.3.7: azp wajib sama dengan client_id jika ada, dan wajib ada jika audience lebih dari satu
+	if claims.AuthorizedParty != "" && claims.AuthorizedParty != p.config.ClientID {
+		return nil, fmt.Errorf("%w: azp does not match client id", ErrInvalidIDToken)
+	}
+	if len(claims.Audience) > 1 && claims.AuthorizedParty == "" {
+		return nil, fmt.Errorf("%w: azp is required for multiple audiences", ErrInvalidIDToken)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: missing sub", ErrInvalidIDToken)
+	}
+	if nonce == "" || claims.Nonce != nonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidIDToken)
+	}
+	return claims, nil
+}
+
+// jwk adalah public key dari JWKS provider (RSA, EC, atau OKP)
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// keySet adalah cache JWKS provider
+type keySet struct {
+	mu        sync.Mutex
+	keys      []jwk
+	fetchedAt time.Time
+}
+
+// lookup mencari public key untuk kid dan algoritma token, fetch ulang JWKS jika kid belum dikenal
+// (contoh: provider baru saja merotasi key)
+func (s *keySet) lookup(p *Provider, jwksURI, kid, alg string) (crypto.PublicKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := s.findLocked(kid, alg)
+	if key == nil && time.Since(s.fetchedAt) > jwksReloadInterval {
+		var set struct {
+			Keys []jwk `json:"keys"`
+		}
+		if err := p.getJSON(jwksURI, &set); err != nil {
+			return nil, fmt.Errorf("failed to fetch jwks: %w", err)
+		}
+		s.keys = set.Keys
+		s.fetchedAt = time.Now()
+		key = s.findLocked(kid, alg)
+	}
+	if key == nil {
+		return nil, fmt.Errorf("no key for kid %q", kid)
+	}
+	return key.publicKey(alg)
+}
+
+// findLocked memilih key signing berdasarkan kid; tanpa kid hanya boleh jika JWKS berisi tepat satu key yang cocok
+func (s *keySet) findLocked(kid, alg string) *jwk {
+	var match *jwk
+	for i := range s.keys {
+		key := &s.keys[i]
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+		if key.Alg != "" && key.Alg != alg {
+			continue
+		}
+		if kid != "" {
+			if key.Kid == kid {
+				return key
+			}
+			continue
+		}
+		if match != nil {
+			return nil
+		}
+		match = key
+	}
+	return match
+}
+
+// publicKey mengubah JWK menjadi public key dan memastikan tipenya sesuai algoritma token
+func (k *jwk) publicKey(alg string) (crypto.PublicKey, error) {
+	switch {
+	case k.Kty == "RSA" && (strings.HasPrefix(alg, "RS") || strings.HasPrefix(alg, "PS")):
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			return nil, errors.New("invalid rsa exponent")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case k.Kty == "EC" && strings.HasPrefix(alg, "ES"):
+		curve, ok := map[string]elliptic.Curve{
+			"P-256": elliptic.P256(),
+			"P-384": elliptic.P384(),
+			"P-521": elliptic.P521(),
+		}[k.Crv]
+		if !ok {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		key := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !curve.IsOnCurve(key.X, key.Y) {
+			return nil, errors.New("invalid ec public key")
+		}
+		return key, nil
+	case k.Kty == "OKP" && k.Crv == "Ed25519" && alg == "EdDSA":
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid ed25519 public key")
+		}
+		return ed25519.PublicKey(x), nil
+	}
+	return nil, fmt.Errorf("key type %s does not match algorithm %s", k.Kty, alg)
+}
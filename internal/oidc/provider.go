@@ -0,0 +1,253 @@
+// Package oidc handles login through an external OpenID Connect provider (relying party)
+// Mendukung discovery, authorization code flow dengan PKCE (S256), serta validasi ID token lewat JWKS provider
+package oidc
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"rest-api/config"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Pengaturan HTTP dan cache metadata provider
+const (
+	httpTimeout       = 10 * time.Second
+	discoveryCacheTTL = time.Hour
+	maxResponseSize   = 1 << 20 // 1 MB
+)
+
+// ErrNotConfigured dikembalikan jika OIDC_ISSUER atau OIDC_CLIENT_ID belum diisi
+var ErrNotConfigured = errors.New("oidc login is not configured")
+
+// Discovery adalah bagian dari /.well-known/openid-configuration yang dipakai relying party
+type Discovery struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	JWKSURI                           string   `json:"jwks_uri"`
+	IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+}
+
+// Config adalah pengaturan client di provider
+type Config struct {
+	Issuer       string // Harus sama persis dengan "issuer" di discovery dan claim iss ID token
+	ClientID     string
+	ClientSecret string   // Kosong = public client (hanya PKCE)
+	RedirectURL  string   // Harus terdaftar di provider
+	Scopes       []string // Selalu berisi openid
+}
+
+// TokenResponse adalah hasil penukaran authorization code
+type TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	IDToken     string `json:"id_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// Provider adalah client untuk satu OpenID provider
+// Metadata discovery dan JWKS di-cache; aman dipakai bersamaan dari banyak goroutine
+type Provider struct {
+	config Config
+	client *http.Client
+
+	mu           sync.Mutex
+	discovery    *Discovery
+	discoveredAt time.Time
+	keys         *keySet
+}
+
+// Enabled mengembalikan true jika provider sudah dikonfigurasi
+func (p *Provider) Enabled() bool {
+	return p.config.Issuer != "" && p.config.ClientID != ""
+}
+
+// AuthCodeURL membuat URL authorization endpoint untuk memulai login
+// codeVerifier disimpan server dan dikirim saat Exchange; provider hanya menerima challenge-nya
+func (p *Provider) AuthCodeURL(state, nonce, codeVerifier string) (string, error) {
+	discovery, err := p.Discovery()
+	if err != nil {
+		return "", err
+	}
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", p.config.ClientID)
+	query.Set("redirect_uri", p.config.RedirectURL)
+	query.Set("scope", strings.Join(p.config.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", CodeChallenge(codeVerifier))
+	query.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(discovery.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return discovery.AuthorizationEndpoint + separator + query.Encode(), nil
+}
+
+// Exchange menukar authorization code dengan token di token endpoint
+func (p *Provider) Exchange(code, codeVerifier string) (*TokenResponse, error) {
+	discovery, err := p.Discovery()
+	if err != nil {
+		return nil, err
+	}
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.config.RedirectURL)
+	form.Set("code_verifier", codeVerifier)
+
+	req, err := http.NewRequest(http.MethodPost, discovery.TokenEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	// client_secret_basic adalah default spesifikasi; client_secret_post hanya jika provider tidak mendukung basic
+	switch {
+	case p.config.ClientSecret == "":
+		form.Set("client_id", p.config.ClientID)
+	case supportsBasicAuth(discovery.TokenEndpointAuthMethodsSupported):
+		req.SetBasicAuth(url.QueryEscape(p.config.ClientID), url.QueryEscape(p.config.ClientSecret))
+	default:
+		form.Set("client_id", p.config.ClientID)
+		form.Set("client_secret", p.config.ClientSecret)
+	}
+	body := form.Encode()
+	req.Body = io.NopCloser(strings.NewReader(body))
+	req.ContentLength = int64(len(body))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var oauthErr struct {
+			Error            string `json:"error"`
+			ErrorDescription string `json:"error_description"`
+		}
+		json.Unmarshal(data, &oauthErr)
+		return nil, fmt.Errorf("token endpoint returned %d: %s %s", resp.StatusCode, oauthErr.Error, oauthErr.ErrorDescription)
+	}
+
+	var tokens TokenResponse
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return nil, err
+	}
+	if tokens.IDToken == "" {
+		return nil, errors.New("token response has no id_token")
+	}
+	return &tokens, nil
+}
+
+// Discovery mengambil metadata provider dari <issuer>/.well-known/openid-configuration (di-cache)
+func (p *Provider) Discovery() (*Discovery, error) {
+	if !p.Enabled() {
+		return nil, ErrNotConfigured
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.discovery != nil && time.Since(p.discoveredAt) < discoveryCacheTTL {
+		return p.discovery, nil
+	}
+
+	var discovery Discovery
+	if err := p.getJSON(strings.TrimRight(p.config.Issuer, "/")+"/.well-known/openid-configuration", &discovery); err != nil {
+		return nil, fmt.Errorf("oidc discovery failed: %w", err)
+	}
+	// OpenID Connect Discovery 1.0 section 4.3: issuer harus identik dengan URL yang dipakai
+	if discovery.Issuer != p.config.Issuer {
+		return nil, fmt.Errorf("oidc discovery issuer mismatch: got %q, want %q", discovery.Issuer, p.config.Issuer)
+	}
+	if discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		return nil, errors.New("oidc discovery document is incomplete")
+	}
+	if len(discovery.CodeChallengeMethodsSupported) > 0 && !containsString(discovery.CodeChallengeMethodsSupported, "S256") {
+		return nil, errors.New("oidc provider does not support PKCE S256")
+	}
+	p.discovery = &discovery
+	p.discoveredAt = time.Now()
+	return p.discovery, nil
+}
+
+func (p *Provider) getJSON(endpoint string, target interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s returned %d", endpoint, resp.StatusCode)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(target)
+}
+
+// CodeChallenge menghitung PKCE code challenge S256 dari code verifier (RFC 7636)
+func CodeChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func supportsBasicAuth(methods []string) bool {
+	return len(methods) == 0 || containsString(methods, "client_secret_basic")
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// NewProvider membuat client provider; tidak ada request jaringan sampai login pertama
+// Scope openid selalu ditambahkan
+func NewProvider(config Config) *Provider {
+	if !containsString(config.Scopes, "openid") {
+		config.Scopes = append([]string{"openid"}, config.Scopes...)
+	}
+	return &Provider{
+		config: config,
+		client: &http.Client{Timeout: httpTimeout},
+		keys:   &keySet{},
+	}
+}
+
+// New membuat provider dari konfigurasi aplikasi
+// Tanpa OIDC_ISSUER provider tetap dibuat tetapi Enabled() false
+// Redirect URI default: <APP_URL>/auth/oidc/callback
+func New(cfg *config.Config) *Provider {
+	redirectURL := cfg.OIDCRedirectURL
+	if redirectURL == "" {
+		redirectURL = strings.TrimRight(cfg.AppURL, "/") + "/auth/oidc/callback"
+	}
+	return NewProvider(Config{
+		Issuer:       cfg.OIDCIssuer,
+		ClientID:     cfg.OIDCClientID,
+		ClientSecret: cfg.OIDCClientSecret,
+		RedirectURL:  redirectURL,
+		Scopes:       strings.Fields(cfg.OIDCScopes),
+	})
+}
@@ -0,0 +1,126 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// Default argon2id mengikuti rekomendasi OWASP (19 MiB, 2 iterasi, 1 thread)
+const (
+	defaultArgon2Memory      = 19 * 1024 // KiB
+	defaultArgon2Iterations  = 2
+	defaultArgon2Parallelism = 1
+	// Batas atas juga berlaku untuk hash dari database agar hash rusak tidak menghabiskan memori
+	maxArgon2Memory      = 1024 * 1024 // 1 GiB
+	maxArgon2Iterations  = 100
+	maxArgon2Parallelism = 64
+	argon2SaltLength     = 16
+	argon2KeyLength      = 32
+)
+
+// Argon2idParams adalah parameter biaya argon2id
+type Argon2idParams struct {
+	Memory      uint32 // KiB
+	Iterations  uint32
+	Parallelism uint8
+}
+
+// Argon2id membuat hash dalam format PHC: $argon2id$v=19$m=<memory>,t=<iterations>,p=<parallelism>$<salt>$<hash>
+type Argon2id struct {
+	params Argon2idParams
+}
+
+// Hash implements Hasher.
+func (a *Argon2id) Hash(password string) (string, error) {
+	salt := make([]byte, argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt, a.params.Iterations, a.params.Memory, a.params.Parallelism, argon2KeyLength)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, a.params.Memory, a.params.Iterations, a.params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// Verify implements Hasher.
+// Parameter dibaca dari hash sehingga hash dengan parameter lama tetap bisa diverifikasi
+func (a *Argon2id) Verify(hash, password string) (bool, error) {
+	decoded, err := decodeArgon2id(hash)
+	if err != nil {
+		return false, err
+	}
+	key := argon2.IDKey([]byte(password), decoded.salt, decoded.params.Iterations, decoded.params.Memory, decoded.params.Parallelism, uint32(len(decoded.key)))
+	return subtle.ConstantTimeCompare(key, decoded.key) == 1, nil
+}
+
+// NeedsRehash implements Hasher.
+func (a *Argon2id) NeedsRehash(hash string) bool {
+	decoded, err := decodeArgon2id(hash)
+	if err != nil {
+		return true
+	}
+	return decoded.params != a.params || len(decoded.key) != argon2KeyLength || len(decoded.salt) != argon2SaltLength
+}
+
+// Identifies mengenali hash argon2id
+func (a *Argon2id) Identifies(hash string) bool {
+	return strings.HasPrefix(hash, "$argon2id$")
+}
+
+type argon2idHash struct {
+	params Argon2idParams
+	salt   []byte
+	key    []byte
+}
+
+func decodeArgon2id(hash string) (*argon2idHash, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return nil, errors.New("invalid argon2id hash")
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return nil, errors.New("unsupported argon2id version")
+	}
+	var memory, iterations uint32
+	var parallelism uint8
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &iterations, &parallelism); err != nil {
+		return nil, errors.New("invalid argon2id parameters")
+	}
+	if memory == 0 || memory > maxArgon2Memory || iterations == 0 || iterations > maxArgon2Iterations || parallelism == 0 || parallelism > maxArgon2Parallelism {
+		return nil, errors.New("argon2id parameters out of range")
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil || len(salt) == 0 {
+		return nil, errors.New("invalid argon2id salt")
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return nil, errors.New("invalid argon2id hash")
+	}
+	return &argon2idHash{
+		params: Argon2idParams{Memory: memory, Iterations: iterations, Parallelism: parallelism},
+		salt:   salt,
+		key:    key,
+	}, nil
+}
+
+// NewArgon2id membuat hasher argon2id; parameter nol diganti default
+func NewArgon2id(params Argon2idParams) *Argon2id {
+	if params.Memory == 0 {
+		params.Memory = defaultArgon2Memory
+	}
+	if params.Iterations == 0 {
+		params.Iterations = defaultArgon2Iterations
+	}
+	if params.Parallelism == 0 {
+		params.Parallelism = defaultArgon2Parallelism
+	}
+	return &Argon2id{params: params}
+}
@@ -0,0 +1,135 @@
+package password
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// testArgon2Params kecil agar test cepat; tidak untuk dipakai di production
+var testArgon2Params = Argon2idParams{Memory: 64, Iterations: 1, Parallelism: 1}
+
+// argon2idTestHash membuat hash PHC dengan salt dan key berukuran tertentu
+func argon2idTestHash(params string, saltLength, keyLength int) string {
+	salt := base64.RawStdEncoding.EncodeToString(make([]byte, saltLength))
+	key := base64.RawStdEncoding.EncodeToString(make([]byte, keyLength))
+	return fmt.Sprintf("$argon2id$v=19$%s$%s$%s", params, salt, key)
+}
+
+func TestDecodeArgon2id(t *testing.T) {
+	valid := argon2idTestHash("m=19456,t=2,p=1", 16, 32)
+	tests := []struct {
+		name    string
+		hash    string
+		want    Argon2idParams
+		wantErr bool
+	}{
+		{name: "valid", hash: valid, want: Argon2idParams{Memory: 19456, Iterations: 2, Parallelism: 1}},
+		{name: "batas atas parameter", hash: argon2idTestHash("m=1048576,t=100,p=64", 16, 32), want: Argon2idParams{Memory: maxArgon2Memory, Iterations: maxArgon2Iterations, Parallelism: maxArgon2Parallelism}},
+		{name: "kosong", hash: "", wantErr: true},
+		{name: "algoritma lain", hash: strings.Replace(valid, "$argon2id$", "$argon2i$", 1), wantErr: true},
+		{name: "bagian kurang", hash: strings.TrimSuffix(valid, valid[strings.LastIndex(valid, "$"):]), wantErr: true},
+		{name: "versi lama", hash: strings.Replace(valid, "v=19", "v=16", 1), wantErr: true},
+		{name: "versi bukan angka", hash: strings.Replace(valid, "v=19", "v=x", 1), wantErr: true},
+		{name: "parameter rusak", hash: argon2idTestHash("m=19456,t=2", 16, 32), wantErr: true},
+		{name: "memory nol", hash: argon2idTestHash("m=0,t=2,p=1", 16, 32), wantErr: true},
+		{name: "memory terlalu besar", hash: argon2idTestHash("m=1048577,t=2,p=1", 16, 32), wantErr: true},
+		{name: "iterasi terlalu banyak", hash: argon2idTestHash("m=19456,t=101,p=1", 16, 32), wantErr: true},
+		{name: "parallelism nol", hash: argon2idTestHash("m=19456,t=2,p=0", 16, 32), wantErr: true},
+		{name: "parallelism terlalu besar", hash: argon2idTestHash("m=19456,t=2,p=65", 16, 32), wantErr: true},
+		{name: "salt bukan base64", hash: "$argon2id$v=19$m=19456,t=2,p=1$!!!$" + base64.RawStdEncoding.EncodeToString(make([]byte, 32)), wantErr: true},
+		{name: "salt kosong", hash: argon2idTestHash("m=19456,t=2,p=1", 0, 32), wantErr: true},
+		{name: "key kosong", hash: argon2idTestHash("m=19456,t=2,p=1", 16, 0), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoded, err := decodeArgon2id(tt.hash)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("decodeArgon2id(%q) = %+v, want error", tt.hash, decoded.params)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodeArgon2id(%q): %v", tt.hash, err)
+			}
+			if decoded.params != tt.want {
+				t.Errorf("params = %+v, want %+v", decoded.params, tt.want)
+			}
+		})
+	}
+}
+
+func TestArgon2idNeedsRehash(t *testing.T) {
+	argon := NewArgon2id(Argon2idParams{Memory: 19456, Iterations: 2, Parallelism: 1})
+	tests := []struct {
+		name string
+		hash string
+		want bool
+	}{
+		{name: "parameter sama", hash: argon2idTestHash("m=19456,t=2,p=1", 16, 32), want: false},
+		{name: "memory berbeda", hash: argon2idTestHash("m=65536,t=2,p=1", 16, 32), want: true},
+		{name: "iterasi berbeda", hash: argon2idTestHash("m=19456,t=3,p=1", 16, 32), want: true},
+		{name: "parallelism berbeda", hash: argon2idTestHash("m=19456,t=2,p=2", 16, 32), want: true},
+		{name: "salt lebih pendek", hash: argon2idTestHash("m=19456,t=2,p=1", 8, 32), want: true},
+		{name: "key lebih pendek", hash: argon2idTestHash("m=19456,t=2,p=1", 16, 16), want: true},
+		{name: "hash rusak", hash: "$argon2id$broken", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := argon.NeedsRehash(tt.hash); got != tt.want {
+				t.Errorf("NeedsRehash(%q) = %v, want %v", tt.hash, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArgon2idHashAndVerify(t *testing.T) {
+	argon := NewArgon2id(testArgon2Params)
+	hash, err := argon.Hash("correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(hash, "$argon2id$v=19$m=64,t=1,p=1$") {
+		t.Errorf("Hash() = %q, want PHC format with configured parameters", hash)
+	}
+	if argon.NeedsRehash(hash) {
+		t.Error("NeedsRehash(fresh hash) = true, want false")
+	}
+	if ok, err := argon.Verify(hash, "correct horse"); err != nil || !ok {
+		t.Errorf("Verify(correct password) = %v, %v; want true", ok, err)
+	}
+	if ok, err := argon.Verify(hash, "wrong horse"); err != nil || ok {
+		t.Errorf("Verify(wrong password) = %v, %v; want false", ok, err)
+	}
+	// Hash dengan parameter lain tetap bisa diverifikasi, tetapi perlu di-rehash
+	stronger := NewArgon2id(Argon2idParams{Memory: 128, Iterations: 2, Parallelism: 1})
+	if ok, err := stronger.Verify(hash, "correct horse"); err != nil || !ok {
+		t.Errorf("Verify with other parameters = %v, %v; want true", ok, err)
+	}
+	if !stronger.NeedsRehash(hash) {
+		t.Error("NeedsRehash with other parameters = false, want true")
+	}
+}
+
+func TestHasherAcceptsOtherAlgorithms(t *testing.T) {
+	argon := NewArgon2id(testArgon2Params)
+	bcryptHasher := NewBcrypt(minBcryptCost)
+	h := newHasher(argon, bcryptHasher)
+
+	legacy, err := bcryptHasher.Hash("correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := h.Verify(legacy, "correct horse"); err != nil || !ok {
+		t.Errorf("Verify(bcrypt hash) = %v, %v; want true", ok, err)
+	}
+	if !h.NeedsRehash(legacy) {
+		t.Error("NeedsRehash(bcrypt hash with argon2id primary) = false, want true")
+	}
+	if _, err := h.Verify("plain-text-password", "plain-text-password"); !errors.Is(err, ErrUnknownHash) {
+		t.Errorf("Verify(unknown format) err = %v, want ErrUnknownHash", err)
+	}
+}
@@ -0,0 +1,60 @@
+package password
+
+import (
+	"errors"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Batas cost bcrypt
+const (
+	defaultBcryptCost = 12
+	minBcryptCost     = 10
+	maxBcryptCost     = bcrypt.MaxCost
+)
+
+// Bcrypt membuat hash bcrypt ($2a$<cost>$...)
+type Bcrypt struct {
+	cost int
+}
+
+// Hash implements Hasher.
+func (b *Bcrypt) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), b.cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// Verify implements Hasher.
+func (b *Bcrypt) Verify(hash, password string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// NeedsRehash implements Hasher.
+func (b *Bcrypt) NeedsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err != nil || cost != b.cost
+}
+
+// Identifies mengenali hash bcrypt ($2a$, $2b$, $2y$)
+func (b *Bcrypt) Identifies(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") || strings.HasPrefix(hash, "$2b$") || strings.HasPrefix(hash, "$2y$")
+}
+
+// NewBcrypt membuat hasher bcrypt dengan cost tertentu
+func NewBcrypt(cost int) *Bcrypt {
+	if cost < minBcryptCost || cost > maxBcryptCost {
+		cost = defaultBcryptCost
+	}
+	return &Bcrypt{cost: cost}
+}
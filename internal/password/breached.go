@@ -0,0 +1,166 @@
+package password
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultFalsePositiveRate = 0.001
+	maxBloomHashes           = 30
+	maxLineLength            = 1024
+)
+
+// BreachedList adalah daftar password bocor dalam bentuk bloom filter
+// Hanya menyimpan bit, bukan password; kemungkinan salah positif sesuai PASSWORD_BREACHED_FALSE_POSITIVE_RATE, tanpa salah negatif
+type BreachedList struct {
+	bits   []uint64
+	size   uint64 // Jumlah bit
+	hashes uint64 // Jumlah fungsi hash
+	count  int
+}
+
+// Contains mengembalikan true jika password (kemungkinan besar) ada di daftar
+func (b *BreachedList) Contains(password string) bool {
+	digest := sha1.Sum([]byte(password))
+	return b.test(digest[:])
+}
+
+// Len mengembalikan jumlah entri yang dimuat
+func (b *BreachedList) Len() int {
+	return b.count
+}
+
+// positions memakai double hashing dari digest SHA-1: h1 + i*h2
+func (b *BreachedList) positions(digest []byte, visit func(bit uint64) bool) {
+	h1 := binary.BigEndian.Uint64(digest[0:8])
+	h2 := binary.BigEndian.Uint64(digest[8:16]) | 1
+	for i := uint64(0); i < b.hashes; i++ {
+		if !visit((h1 + i*h2) % b.size) {
+			return
+		}
+	}
+}
+
+func (b *BreachedList) add(digest []byte) {
+	b.positions(digest, func(bit uint64) bool {
+		b.bits[bit/64] |= 1 << (bit % 64)
+		return true
+	})
+	b.count++
+}
+
+func (b *BreachedList) test(digest []byte) bool {
+	found := true
+	b.positions(digest, func(bit uint64) bool {
+		if b.bits[bit/64]&(1<<(bit%64)) == 0 {
+			found = false
+		}
+		return found
+	})
+	return found
+}
+
+// LoadBreachedList membangun bloom filter dari file berisi satu entri per baris:
+//   - password plain text, atau
+//   - SHA-1 hex 40 karakter, opsional diikuti :<jumlah> (format unduhan Have I Been Pwned)
+//
+// Baris kosong dan baris yang diawali # dilewati
+func LoadBreachedList(path string, falsePositiveRate float64) (*BreachedList, error) {
+	started := time.Now()
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	// Baca dua kali: hitung entri untuk menentukan ukuran filter, lalu isi filter
+	n := 0
+	if err := scanEntries(file, func([]byte) { n++ }); err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, errors.New("breached password list is empty")
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	list := newBreachedList(n, falsePositiveRate)
+	if err := scanEntries(file, list.add); err != nil {
+		return nil, err
+	}
+	log.Printf("Loaded %d breached passwords (%d KiB bloom filter) in %s", list.count, len(list.bits)*8/1024, time.Since(started).Round(time.Millisecond))
+	return list, nil
+}
+
+// newBreachedList menghitung ukuran optimal: m = -n ln p / (ln 2)^2, k = m/n ln 2
+func newBreachedList(n int, falsePositiveRate float64) *BreachedList {
+	size := uint64(math.Ceil(-float64(n) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2)))
+	if size < 64 {
+		size = 64
+	}
+	hashes := uint64(math.Round(float64(size) / float64(n) * math.Ln2))
+	if hashes < 1 {
+		hashes = 1
+	}
+	if hashes > maxBloomHashes {
+		hashes = maxBloomHashes
+	}
+	return &BreachedList{
+		bits:   make([]uint64, (size+63)/64),
+		size:   size,
+		hashes: hashes,
+	}
+}
+
+// scanEntries membaca setiap entri file sebagai digest SHA-1
+func scanEntries(r io.Reader, entry func(digest []byte)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineLength)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		entry(entryDigest(line))
+	}
+	return scanner.Err()
+}
+
+func entryDigest(line string) []byte {
+	hash := line
+	if separator := strings.IndexByte(line, ':'); separator == 40 {
+		hash = line[:separator]
+	}
+	if len(hash) == 40 {
+		if digest, err := hex.DecodeString(hash); err == nil {
+			return digest
+		}
+	}
+	digest := sha1.Sum([]byte(line))
+	return digest[:]
+}
+
+// parseRate membaca PASSWORD_BREACHED_FALSE_POSITIVE_RATE, fallback jika kosong atau di luar (0, 0.5)
+func parseRate(value string) float64 {
+	if value == "" {
+		return defaultFalsePositiveRate
+	}
+	rate, err := strconv.ParseFloat(value, 64)
+	if err != nil || rate <= 0 || rate >= 0.5 {
+		log.Printf("Warning: invalid PASSWORD_BREACHED_FALSE_POSITIVE_RATE %q, using %g", value, defaultFalsePositiveRate)
+		return defaultFalsePositiveRate
+	}
+	return rate
+}
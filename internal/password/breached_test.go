@@ -0,0 +1,114 @@
+package password
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func sha1Digest(value string) []byte {
+	digest := sha1.Sum([]byte(value))
+	return digest[:]
+}
+
+func writeList(t *testing.T, lines ...string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadBreachedList(t *testing.T) {
+	path := writeList(t,
+		"# komentar dilewati",
+		"",
+		"123456",
+		"qwerty\r",
+		// SHA-1 dari "letmein", huruf besar dengan jumlah seperti unduhan Have I Been Pwned
+		"B7A875FC1EA228B9061041B7CEC4BD3C52AB3CE3:18",
+		// SHA-1 dari "monkey" tanpa jumlah
+		"ab87d24bdc7452e55738deb5f868e1f16dea5ace",
+	)
+	list, err := LoadBreachedList(path, defaultFalsePositiveRate)
+	if err != nil {
+		t.Fatalf("LoadBreachedList: %v", err)
+	}
+	if list.Len() != 4 {
+		t.Errorf("Len() = %d, want 4", list.Len())
+	}
+
+	tests := []struct {
+		password string
+		want     bool
+	}{
+		{"123456", true},
+		{"qwerty", true},
+		{"letmein", true},
+		{"monkey", true},
+		{"# komentar dilewati", false},
+		{"B7A875FC1EA228B9061041B7CEC4BD3C52AB3CE3:18", false},
+		{"correct horse battery staple", false},
+	}
+	for _, tt := range tests {
+		if got := list.Contains(tt.password); got != tt.want {
+			t.Errorf("Contains(%q) = %v, want %v", tt.password, got, tt.want)
+		}
+	}
+}
+
+func TestLoadBreachedListErrors(t *testing.T) {
+	if _, err := LoadBreachedList(writeList(t, "# hanya komentar", ""), defaultFalsePositiveRate); err == nil {
+		t.Error("LoadBreachedList(empty list) succeeded, want error")
+	}
+	if _, err := LoadBreachedList(filepath.Join(t.TempDir(), "missing.txt"), defaultFalsePositiveRate); err == nil {
+		t.Error("LoadBreachedList(missing file) succeeded, want error")
+	}
+}
+
+func TestBreachedListFalsePositiveRate(t *testing.T) {
+	const entries, probes, rate = 2000, 20000, 0.01
+	list := newBreachedList(entries, rate)
+	for i := 0; i < entries; i++ {
+		list.add(sha1Digest(fmt.Sprintf("breached-%d", i)))
+	}
+	// Tidak ada salah negatif
+	for i := 0; i < entries; i++ {
+		if !list.Contains(fmt.Sprintf("breached-%d", i)) {
+			t.Fatalf("Contains(breached-%d) = false, want true", i)
+		}
+	}
+	falsePositives := 0
+	for i := 0; i < probes; i++ {
+		if list.Contains(fmt.Sprintf("safe-%d", i)) {
+			falsePositives++
+		}
+	}
+	// Beri toleransi dua kali lipat dari rate yang diminta
+	if got := float64(falsePositives) / probes; got > 2*rate {
+		t.Errorf("false positive rate = %.4f, want at most %.4f", got, 2*rate)
+	}
+}
+
+func TestParseRate(t *testing.T) {
+	tests := []struct {
+		value string
+		want  float64
+	}{
+		{"", defaultFalsePositiveRate},
+		{"0.01", 0.01},
+		{"0", defaultFalsePositiveRate},
+		{"0.5", defaultFalsePositiveRate},
+		{"-0.1", defaultFalsePositiveRate},
+		{"abc", defaultFalsePositiveRate},
+	}
+	for _, tt := range tests {
+		if got := parseRate(tt.value); got != tt.want {
+			t.Errorf("parseRate(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// Package password handles hashing and verification of user passwords
+// Hash baru memakai algoritma PASSWORD_HASH_ALGORITHM (argon2id atau bcrypt); hash lama dari algoritma lain tetap bisa diverifikasi
+package password
+
+import (
+	"errors"
+	"log"
+	"rest-api/config"
+	"strconv"
+)
+
+// Algoritma hash yang didukung
+const (
+	AlgorithmArgon2id = "argon2id"
+	AlgorithmBcrypt   = "bcrypt"
+)
+
+// ErrUnknownHash dikembalikan Verify jika format hash tidak dikenali algoritma mana pun
+var ErrUnknownHash = errors.New("unknown password hash format")
+
+// Hasher membuat dan memverifikasi hash password
+type Hasher interface {
+	// Hash membuat hash dengan algoritma dan parameter yang dikonfigurasi
+	Hash(password string) (string, error)
+	// Verify mengembalikan true jika password cocok dengan hash
+	Verify(hash, password string) (bool, error)
+	// NeedsRehash mengembalikan true jika hash dibuat dengan algoritma atau parameter yang sudah tidak dipakai
+	NeedsRehash(hash string) bool
+}
+
+// algorithm adalah satu algoritma hash; Identifies mengenali hash dari prefix-nya
+type algorithm interface {
+	Hasher
+	Identifies(hash string) bool
+}
+
+// hasher membuat hash dengan algoritma utama dan memverifikasi hash dari semua algoritma yang didukung
+type hasher struct {
+	primary    algorithm
+	algorithms []algorithm
+}
+
+// Hash implements Hasher.
+func (h *hasher) Hash(password string) (string, error) {
+	return h.primary.Hash(password)
+}
+
+// Verify implements Hasher.
+func (h *hasher) Verify(hash, password string) (bool, error) {
+	for _, a := range h.algorithms {
+		if a.Identifies(hash) {
+			return a.Verify(hash, password)
+		}
+	}
+	return false, ErrUnknownHash
+}
+
+// NeedsRehash implements Hasher.
+func (h *hasher) NeedsRehash(hash string) bool {
+	return !h.primary.Identifies(hash) || h.primary.NeedsRehash(hash)
+}
+
+// newHasher membuat Hasher dengan primary sebagai algoritma untuk hash baru
+func newHasher(primary algorithm, others ...algorithm) Hasher {
+	return &hasher{primary: primary, algorithms: append([]algorithm{primary}, others...)}
+}
+
+// New membuat Hasher sesuai konfigurasi
+// Algoritma yang tidak dikenal fallback ke argon2id dengan warning
+func New(cfg *config.Config) Hasher {
+	argon := NewArgon2id(Argon2idParams{
+		Memory:      uint32(parseParam("PASSWORD_ARGON2_MEMORY", cfg.PasswordArgon2Memory, defaultArgon2Memory, 8, maxArgon2Memory)),
+		Iterations:  uint32(parseParam("PASSWORD_ARGON2_ITERATIONS", cfg.PasswordArgon2Iterations, defaultArgon2Iterations, 1, maxArgon2Iterations)),
+		Parallelism: uint8(parseParam("PASSWORD_ARGON2_PARALLELISM", cfg.PasswordArgon2Parallelism, defaultArgon2Parallelism, 1, maxArgon2Parallelism)),
+	})
+	bcryptHasher := NewBcrypt(parseParam("PASSWORD_BCRYPT_COST", cfg.PasswordBcryptCost, defaultBcryptCost, minBcryptCost, maxBcryptCost))
+
+	switch cfg.PasswordHashAlgorithm {
+	case AlgorithmArgon2id, "":
+		return newHasher(argon, bcryptHasher)
+	case AlgorithmBcrypt:
+		return newHasher(bcryptHasher, argon)
+	}
+	log.Printf("Warning: unknown PASSWORD_HASH_ALGORITHM %q, using %s", cfg.PasswordHashAlgorithm, AlgorithmArgon2id)
+	return newHasher(argon, bcryptHasher)
+}
+
+// parseParam membaca parameter angka dari konfigurasi, fallback jika kosong, tidak valid, atau di luar batas
+func parseParam(name, value string, fallback, min, max int) int {
+	if value == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < min || n > max {
+		log.Printf("Warning: invalid %s %q (allowed %d-%d), using default %d", name, value, min, max, fallback)
+		return fallback
+	}
+	return n
+}
@@ -0,0 +1,163 @@
+package password
+
+import (
+	"fmt"
+	"log"
+	"rest-api/config"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Default kebijakan password
+const (
+	defaultMinLength = 8
+	defaultMaxLength = 128
+	// minPersonalInfoLength: username/bagian email yang lebih pendek tidak dicek agar password biasa tidak ikut ditolak
+	minPersonalInfoLength = 3
+)
+
+// Kelas karakter untuk PASSWORD_REQUIRED_CHARACTERS
+const (
+	ClassLower  = "lower"
+	ClassUpper  = "upper"
+	ClassDigit  = "digit"
+	ClassSymbol = "symbol"
+)
+
+// Rule pada Violation
+const (
+	RuleMinLength    = "min_length"
+	RuleMaxLength    = "max_length"
+	RuleLower        = "lowercase"
+	RuleUpper        = "uppercase"
+	RuleDigit        = "digit"
+	RuleSymbol       = "symbol"
+	RulePersonalInfo = "personal_info"
+	RuleBreached     = "breached"
+)
+
+// Violation adalah satu aturan kebijakan password yang dilanggar
+type Violation struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// PolicyError dikembalikan Validate jika password melanggar satu atau lebih aturan
+type PolicyError struct {
+	Violations []Violation
+}
+
+func (e *PolicyError) Error() string {
+	return "password does not meet the password policy"
+}
+
+// Policy adalah kebijakan password untuk registrasi, reset, dan ganti password
+type Policy struct {
+	MinLength            int
+	MaxLength            int
+	RequiredClasses      []string // Kombinasi ClassLower, ClassUpper, ClassDigit, ClassSymbol
+	DisallowPersonalInfo bool     // Tolak password yang memuat username atau email
+	Breached             *BreachedList
+}
+
+// Validate mengecek password terhadap semua aturan dan mengembalikan *PolicyError berisi semua pelanggaran
+// personalInfo berisi username dan email pemilik password
+func (p *Policy) Validate(password string, personalInfo ...string) error {
+	var violations []Violation
+	length := utf8.RuneCountInString(password)
+	if length < p.MinLength {
+		violations = append(violations, Violation{RuleMinLength, fmt.Sprintf("Password must be at least %d characters long.", p.MinLength)})
+	}
+	if p.MaxLength > 0 && length > p.MaxLength {
+		violations = append(violations, Violation{RuleMaxLength, fmt.Sprintf("Password must be at most %d characters long.", p.MaxLength)})
+	}
+
+	var hasLower, hasUpper, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case !unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+	for _, class := range p.RequiredClasses {
+		switch {
+		case class == ClassLower && !hasLower:
+			violations = append(violations, Violation{RuleLower, "Password must contain a lowercase letter."})
+		case class == ClassUpper && !hasUpper:
+			violations = append(violations, Violation{RuleUpper, "Password must contain an uppercase letter."})
+		case class == ClassDigit && !hasDigit:
+			violations = append(violations, Violation{RuleDigit, "Password must contain a digit."})
+		case class == ClassSymbol && !hasSymbol:
+			violations = append(violations, Violation{RuleSymbol, "Password must contain a symbol."})
+		}
+	}
+
+	if p.DisallowPersonalInfo && containsPersonalInfo(password, personalInfo) {
+		violations = append(violations, Violation{RulePersonalInfo, "Password must not contain your username or email."})
+	}
+	if p.Breached != nil && p.Breached.Contains(password) {
+		violations = append(violations, Violation{RuleBreached, "This password has appeared in a data breach. Please choose a different one."})
+	}
+
+	if len(violations) > 0 {
+		return &PolicyError{Violations: violations}
+	}
+	return nil
+}
+
+// containsPersonalInfo mengecek (tanpa membedakan huruf besar/kecil) apakah password memuat username, email, atau bagian lokal email
+func containsPersonalInfo(password string, personalInfo []string) bool {
+	lower := strings.ToLower(password)
+	for _, info := range personalInfo {
+		info = strings.ToLower(strings.TrimSpace(info))
+		candidates := []string{info}
+		if at := strings.Index(info, "@"); at > 0 {
+			candidates = append(candidates, info[:at])
+		}
+		for _, candidate := range candidates {
+			if utf8.RuneCountInString(candidate) >= minPersonalInfoLength && strings.Contains(lower, candidate) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// NewPolicy membuat Policy sesuai konfigurasi
+// Daftar password bocor (PASSWORD_BREACHED_LIST) dimuat saat startup; jika gagal dimuat pengecekan tersebut dilewati dengan warning
+func NewPolicy(cfg *config.Config) *Policy {
+	policy := &Policy{
+		MinLength:            parseParam("PASSWORD_MIN_LENGTH", cfg.PasswordMinLength, defaultMinLength, 1, 1024),
+		MaxLength:            parseParam("PASSWORD_MAX_LENGTH", cfg.PasswordMaxLength, defaultMaxLength, 1, 4096),
+		DisallowPersonalInfo: cfg.PasswordDisallowPersonalInfo != "false",
+	}
+	if policy.MaxLength < policy.MinLength {
+		log.Printf("Warning: PASSWORD_MAX_LENGTH is shorter than PASSWORD_MIN_LENGTH, using %d", policy.MinLength)
+		policy.MaxLength = policy.MinLength
+	}
+	for _, class := range strings.Split(cfg.PasswordRequiredCharacters, ",") {
+		switch class = strings.TrimSpace(strings.ToLower(class)); class {
+		case "":
+		case ClassLower, ClassUpper, ClassDigit, ClassSymbol:
+			policy.RequiredClasses = append(policy.RequiredClasses, class)
+		default:
+			log.Printf("Warning: unknown character class %q in PASSWORD_REQUIRED_CHARACTERS", class)
+		}
+	}
+	if cfg.PasswordBreachedList != "" {
+		list, err := LoadBreachedList(cfg.PasswordBreachedList, parseRate(cfg.PasswordBreachedFalsePositiveRate))
+		if err != nil {
+			log.Printf("Warning: failed to load PASSWORD_BREACHED_LIST %q, breached password check disabled: %v", cfg.PasswordBreachedList, err)
+		} else {
+			policy.Breached = list
+		}
+	}
+	return policy
+}
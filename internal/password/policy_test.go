@@ -0,0 +1,114 @@
+package password
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPolicyValidate(t *testing.T) {
+	strict := &Policy{
+		MinLength:            8,
+		MaxLength:            16,
+		RequiredClasses:      []string{ClassLower, ClassUpper, ClassDigit, ClassSymbol},
+		DisallowPersonalInfo: true,
+	}
+	lenient := &Policy{MinLength: 8, MaxLength: 128}
+
+	tests := []struct {
+		name         string
+		policy       *Policy
+		password     string
+		personalInfo []string
+		want         []string // Rule yang dilanggar, kosong jika valid
+	}{
+		{name: "memenuhi semua aturan", policy: strict, password: "Correct-Horse1"},
+		{name: "terlalu pendek", policy: lenient, password: "short", want: []string{RuleMinLength}},
+		{name: "panjang dihitung per karakter, bukan byte", policy: lenient, password: "пароль12"},
+		{name: "terlalu panjang", policy: strict, password: "Correct-Horse1-Battery", want: []string{RuleMaxLength}},
+		{name: "tanpa batas maksimum", policy: &Policy{MinLength: 1}, password: strings.Repeat("a", 5000)},
+		{
+			name:     "semua kelas karakter kurang",
+			policy:   strict,
+			password: "        ",
+			want:     []string{RuleLower, RuleUpper, RuleDigit, RuleSymbol},
+		},
+		{name: "tanpa huruf besar", policy: strict, password: "correct-horse1", want: []string{RuleUpper}},
+		{name: "tanpa simbol", policy: strict, password: "CorrectHorse1", want: []string{RuleSymbol}},
+		{name: "spasi bukan simbol", policy: strict, password: "Correct Horse1", want: []string{RuleSymbol}},
+		{
+			name:         "memuat username tanpa membedakan huruf besar",
+			policy:       strict,
+			password:     "Alice-Rocks1",
+			personalInfo: []string{"alice", "someone@example.com"},
+			want:         []string{RulePersonalInfo},
+		},
+		{
+			name:         "memuat bagian lokal email",
+			policy:       strict,
+			password:     "Bob.Smith-99",
+			personalInfo: []string{"bobby", "bob.smith@example.com"},
+			want:         []string{RulePersonalInfo},
+		},
+		{
+			name:         "username pendek tidak dicek",
+			policy:       strict,
+			password:     "Al-Gorithm99",
+			personalInfo: []string{"al", "al@example.com"},
+		},
+		{
+			name:         "personal info diabaikan jika tidak diaktifkan",
+			policy:       lenient,
+			password:     "alice-rocks",
+			personalInfo: []string{"alice"},
+		},
+		{
+			name:         "beberapa pelanggaran sekaligus",
+			policy:       strict,
+			password:     "alice",
+			personalInfo: []string{"alice"},
+			want:         []string{RuleMinLength, RuleUpper, RuleDigit, RuleSymbol, RulePersonalInfo},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate(tt.password, tt.personalInfo...)
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Fatalf("Validate(%q) = %v, want nil", tt.password, err)
+				}
+				return
+			}
+			var policyErr *PolicyError
+			if !errors.As(err, &policyErr) {
+				t.Fatalf("Validate(%q) = %v, want *PolicyError", tt.password, err)
+			}
+			if got := violationRules(policyErr); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate(%q) rules = %v, want %v", tt.password, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolicyValidateBreached(t *testing.T) {
+	list := newBreachedList(1, defaultFalsePositiveRate)
+	list.add(sha1Digest("password123"))
+	policy := &Policy{MinLength: 8, Breached: list}
+
+	var policyErr *PolicyError
+	if err := policy.Validate("password123"); !errors.As(err, &policyErr) || !reflect.DeepEqual(violationRules(policyErr), []string{RuleBreached}) {
+		t.Errorf("Validate(breached) = %v, want breached violation", err)
+	}
+	if err := policy.Validate("an unlisted passphrase"); err != nil {
+		t.Errorf("Validate(not breached) = %v, want nil", err)
+	}
+}
+
+func violationRules(err *PolicyError) []string {
+	rules := make([]string, 0, len(err.Violations))
+	for _, violation := range err.Violations {
+		rules = append(rules, violation.Rule)
+	}
+	return rules
+}
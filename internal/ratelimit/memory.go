@@ -0,0 +1,62 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// memorySweepInterval adalah jarak minimal antar pembersihan bucket yang sudah penuh
+const memorySweepInterval = time.Minute
+
+type memoryBucket struct {
+	tokens    float64
+	updatedAt time.Time
+	fullAt    time.Time // Setelah waktu ini bucket penuh dan boleh dihapus
+}
+
+// memoryStore menyimpan bucket di memori proses, limit tidak dibagi antar instance
+// Aman dipakai bersamaan dari banyak goroutine
+type memoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*memoryBucket
+	lastSweep time.Time
+}
+
+// Take implements Store.
+func (s *memoryStore) Take(key string, limit Limit) (Result, error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sweepLocked(now)
+
+	bucket, ok := s.buckets[key]
+	if !ok {
+		bucket = &memoryBucket{tokens: float64(limit.Capacity()), updatedAt: now}
+		s.buckets[key] = bucket
+	}
+	tokens, allowed := limit.take(bucket.tokens, now.Sub(bucket.updatedAt))
+	bucket.tokens = tokens
+	bucket.updatedAt = now
+
+	result := limit.result(tokens, allowed)
+	bucket.fullAt = now.Add(result.Reset)
+	return result, nil
+}
+
+// sweepLocked menghapus bucket yang sudah penuh kembali; bucket penuh sama dengan bucket baru
+func (s *memoryStore) sweepLocked(now time.Time) {
+	if now.Sub(s.lastSweep) < memorySweepInterval {
+		return
+	}
+	s.lastSweep = now
+	for key, bucket := range s.buckets {
+		if now.After(bucket.fullAt) {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+func NewMemoryStore() Store {
+	return &memoryStore{buckets: make(map[string]*memoryBucket), lastSweep: time.Now()}
+}
@@ -0,0 +1,137 @@
+// Package ratelimit handles token-bucket rate limiting
+// Storage dipilih lewat RATE_LIMIT_STORE: memory (default, per instance) atau redis (dibagi antar instance)
+package ratelimit
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"rest-api/config"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limit adalah aturan token bucket: Requests per Period, dengan kapasitas Burst
+type Limit struct {
+	Requests int
+	Period   time.Duration
+	Burst    int // Kapasitas bucket, 0 = sama dengan Requests
+}
+
+// Result adalah hasil pengambilan satu token dari bucket
+type Result struct {
+	Allowed    bool
+	Limit      int           // Kapasitas bucket
+	Remaining  int           // Token tersisa setelah request ini
+	Reset      time.Duration // Waktu sampai bucket penuh kembali
+	RetryAfter time.Duration // Waktu sampai satu token tersedia, 0 jika Allowed
+}
+
+// Store menyimpan state bucket
+type Store interface {
+	// Take mengambil satu token dari bucket key
+	Take(key string, limit Limit) (Result, error)
+}
+
+// ParseLimit membaca aturan berformat "<requests>/<period>[/<burst>]", contoh: 100/1m atau 100/1m/20
+// String kosong, "0" atau "off" berarti tanpa batas (ok = false)
+func ParseLimit(value string) (limit Limit, ok bool, err error) {
+	value = strings.TrimSpace(value)
+	if value == "" || value == "0" || value == "off" {
+		return Limit{}, false, nil
+	}
+	parts := strings.Split(value, "/")
+	if len(parts) < 2 || len(parts) > 3 {
+		return Limit{}, false, fmt.Errorf("invalid rate limit %q", value)
+	}
+	limit.Requests, err = strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil || limit.Requests <= 0 {
+		return Limit{}, false, fmt.Errorf("invalid rate limit %q", value)
+	}
+	limit.Period, err = time.ParseDuration(strings.TrimSpace(parts[1]))
+	if err != nil || limit.Period <= 0 {
+		return Limit{}, false, fmt.Errorf("invalid rate limit %q", value)
+	}
+	if len(parts) == 3 {
+		limit.Burst, err = strconv.Atoi(strings.TrimSpace(parts[2]))
+		if err != nil || limit.Burst <= 0 {
+			return Limit{}, false, fmt.Errorf("invalid rate limit %q", value)
+		}
+	}
+	return limit, true, nil
+}
+
+// Capacity mengembalikan kapasitas bucket
+func (l Limit) Capacity() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+	return l.Requests
+}
+
+// rate adalah jumlah token yang diisi ulang per detik
+func (l Limit) rate() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+// Policy mengembalikan nilai header RateLimit-Policy, contoh: 100;w=60
+func (l Limit) Policy() string {
+	policy := fmt.Sprintf("%d;w=%d", l.Requests, int(math.Ceil(l.Period.Seconds())))
+	if l.Burst > 0 {
+		policy += fmt.Sprintf(";burst=%d", l.Burst)
+	}
+	return policy
+}
+
+// take mengisi ulang bucket sesuai waktu yang berlalu lalu mengambil satu token jika tersedia
+func (l Limit) take(tokens float64, elapsed time.Duration) (float64, bool) {
+	if elapsed > 0 {
+		tokens = math.Min(float64(l.Capacity()), tokens+elapsed.Seconds()*l.rate())
+	}
+	if tokens >= 1 {
+		return tokens - 1, true
+	}
+	return tokens, false
+}
+
+// result membuat Result dari isi bucket setelah pengambilan
+func (l Limit) result(tokens float64, allowed bool) Result {
+	capacity := float64(l.Capacity())
+	result := Result{
+		Allowed:   allowed,
+		Limit:     l.Capacity(),
+		Remaining: int(math.Floor(tokens)),
+		Reset:     l.durationFor(capacity - tokens),
+	}
+	if !allowed {
+		result.RetryAfter = l.durationFor(1 - tokens)
+	}
+	return result
+}
+
+// durationFor menghitung waktu untuk mengisi sejumlah token
+func (l Limit) durationFor(tokens float64) time.Duration {
+	if tokens <= 0 {
+		return 0
+	}
+	return time.Duration(tokens / l.rate() * float64(time.Second))
+}
+
+// New membuat Store sesuai konfigurasi
+// Store yang tidak dikenal fallback ke memory dengan warning
+func New(cfg *config.Config) Store {
+	switch cfg.RateLimitStore {
+	case "redis":
+		db, err := strconv.Atoi(cfg.RedisDB)
+		if err != nil {
+			log.Printf("Warning: invalid REDIS_DB %q, using 0", cfg.RedisDB)
+			db = 0
+		}
+		return NewRedisStore(NewRedisClient(cfg.RedisAddr, cfg.RedisPassword, db))
+	case "memory", "":
+		return NewMemoryStore()
+	}
+	log.Printf("Warning: unknown RATE_LIMIT_STORE %q, using memory store", cfg.RateLimitStore)
+	return NewMemoryStore()
+}
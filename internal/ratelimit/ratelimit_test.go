@@ -0,0 +1,149 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseLimit(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Limit
+		wantOK  bool
+		wantErr bool
+	}{
+		{value: "100/1m", want: Limit{Requests: 100, Period: time.Minute}, wantOK: true},
+		{value: " 10 / 1s / 20 ", want: Limit{Requests: 10, Period: time.Second, Burst: 20}, wantOK: true},
+		{value: "5/1h30m", want: Limit{Requests: 5, Period: 90 * time.Minute}, wantOK: true},
+		{value: ""},
+		{value: "0"},
+		{value: "off"},
+		{value: "100", wantErr: true},
+		{value: "100/1m/5/1", wantErr: true},
+		{value: "x/1m", wantErr: true},
+		{value: "0/1m", wantErr: true},
+		{value: "-1/1m", wantErr: true},
+		{value: "100/minute", wantErr: true},
+		{value: "100/0s", wantErr: true},
+		{value: "100/1m/0", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, ok, err := ParseLimit(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLimit(%q) err = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if ok != tt.wantOK {
+				t.Fatalf("ParseLimit(%q) ok = %v, want %v", tt.value, ok, tt.wantOK)
+			}
+			if got != tt.want {
+				t.Errorf("ParseLimit(%q) = %+v, want %+v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLimitTake(t *testing.T) {
+	// 60/1m = 1 token per detik, kapasitas 10
+	limit := Limit{Requests: 60, Period: time.Minute, Burst: 10}
+	tests := []struct {
+		name        string
+		tokens      float64
+		elapsed     time.Duration
+		wantTokens  float64
+		wantAllowed bool
+	}{
+		{name: "bucket penuh", tokens: 10, wantTokens: 9, wantAllowed: true},
+		{name: "token terakhir", tokens: 1, wantTokens: 0, wantAllowed: true},
+		{name: "bucket kosong", tokens: 0.5, wantTokens: 0.5},
+		{name: "isi ulang sesuai waktu", tokens: 0, elapsed: 3 * time.Second, wantTokens: 2, wantAllowed: true},
+		{name: "isi ulang tidak melebihi kapasitas", tokens: 5, elapsed: time.Hour, wantTokens: 9, wantAllowed: true},
+		{name: "isi ulang sebagian belum cukup", tokens: 0, elapsed: 500 * time.Millisecond, wantTokens: 0.5},
+		{name: "jam mundur tidak mengisi ulang", tokens: 0, elapsed: -time.Minute, wantTokens: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, allowed := limit.take(tt.tokens, tt.elapsed)
+			if allowed != tt.wantAllowed || tokens != tt.wantTokens {
+				t.Errorf("take(%v, %v) = %v, %v; want %v, %v", tt.tokens, tt.elapsed, tokens, allowed, tt.wantTokens, tt.wantAllowed)
+			}
+		})
+	}
+}
+
+func TestLimitResult(t *testing.T) {
+	limit := Limit{Requests: 60, Period: time.Minute, Burst: 10}
+
+	allowed := limit.result(4.5, true)
+	if !allowed.Allowed || allowed.Limit != 10 || allowed.Remaining != 4 || allowed.RetryAfter != 0 {
+		t.Errorf("result(4.5, true) = %+v", allowed)
+	}
+	if allowed.Reset != 5500*time.Millisecond {
+		t.Errorf("Reset = %v, want 5.5s", allowed.Reset)
+	}
+
+	denied := limit.result(0.25, false)
+	if denied.Allowed || denied.Remaining != 0 {
+		t.Errorf("result(0.25, false) = %+v", denied)
+	}
+	if denied.RetryAfter != 750*time.Millisecond {
+		t.Errorf("RetryAfter = %v, want 750ms", denied.RetryAfter)
+	}
+}
+
+func TestLimitPolicy(t *testing.T) {
+	tests := []struct {
+		limit Limit
+		want  string
+	}{
+		{Limit{Requests: 100, Period: time.Minute}, "100;w=60"},
+		{Limit{Requests: 10, Period: time.Second, Burst: 20}, "10;w=1;burst=20"},
+		{Limit{Requests: 5, Period: 1500 * time.Millisecond}, "5;w=2"},
+	}
+	for _, tt := range tests {
+		if got := tt.limit.Policy(); got != tt.want {
+			t.Errorf("Policy(%+v) = %q, want %q", tt.limit, got, tt.want)
+		}
+	}
+}
+
+func TestMemoryStoreTake(t *testing.T) {
+	store := NewMemoryStore()
+	// Periode panjang agar tidak ada token yang terisi ulang selama test
+	limit := Limit{Requests: 3, Period: time.Hour}
+
+	for i := 2; i >= 0; i-- {
+		result, err := store.Take("a", limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !result.Allowed || result.Remaining != i {
+			t.Fatalf("Take #%d = %+v, want allowed with %d remaining", 3-i, result, i)
+		}
+	}
+	denied, err := store.Take("a", limit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if denied.Allowed || denied.RetryAfter <= 0 || denied.RetryAfter > 20*time.Minute {
+		t.Errorf("Take after bucket is empty = %+v, want denied with RetryAfter up to 20m", denied)
+	}
+
+	// Setiap key punya bucket sendiri
+	if other, _ := store.Take("b", limit); !other.Allowed || other.Remaining != 2 {
+		t.Errorf("Take on another key = %+v, want allowed with 2 remaining", other)
+	}
+}
+
+func TestMemoryStoreBurst(t *testing.T) {
+	store := NewMemoryStore()
+	limit := Limit{Requests: 1, Period: time.Hour, Burst: 5}
+	for i := 0; i < 5; i++ {
+		if result, _ := store.Take("k", limit); !result.Allowed {
+			t.Fatalf("Take #%d denied, want burst of 5", i+1)
+		}
+	}
+	if result, _ := store.Take("k", limit); result.Allowed {
+		t.Error("Take #6 allowed, want denied after burst")
+	}
+}
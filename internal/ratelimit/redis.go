@@ -0,0 +1,80 @@
+package ratelimit
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// redisKeyPrefix memisahkan key rate limit dari data lain di Redis
+const redisKeyPrefix = "ratelimit:"
+
+// tokenBucketScript menjalankan token bucket secara atomik di Redis
+// Waktu diambil dari server Redis agar semua instance memakai jam yang sama
+// KEYS[1] = key bucket, ARGV[1] = kapasitas, ARGV[2] = token per detik
+// Hasil: {1 jika diizinkan, sisa token (string karena Lua membulatkan angka ke integer)}
+const tokenBucketScript = `
+redis.replicate_commands()
+local capacity = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) + tonumber(time[2]) / 1000000
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+  tokens = capacity
+  ts = now
+end
+tokens = math.min(capacity, tokens + math.max(0, now - ts) * rate)
+local allowed = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(now))
+redis.call('PEXPIRE', KEYS[1], math.ceil((capacity - tokens) / rate * 1000) + 1000)
+return {allowed, tostring(tokens)}
+`
+
+// RedisClient adalah koneksi ke server yang berbicara protokol Redis (RESP), contoh: Redis, Valkey, KeyDB
+// Do mengirim satu command dan mengembalikan reply: string, int64, []interface{}, nil, atau error dari server
+type RedisClient interface {
+	Do(args ...string) (interface{}, error)
+}
+
+// redisStore menyimpan bucket di Redis sehingga limit dibagi oleh semua instance aplikasi
+type redisStore struct {
+	client RedisClient
+}
+
+// Take implements Store.
+func (s *redisStore) Take(key string, limit Limit) (Result, error) {
+	reply, err := s.client.Do("EVAL", tokenBucketScript, "1", redisKeyPrefix+key,
+		strconv.Itoa(limit.Capacity()),
+		strconv.FormatFloat(limit.rate(), 'f', -1, 64),
+	)
+	if err != nil {
+		return Result{}, err
+	}
+	values, ok := reply.([]interface{})
+	if !ok || len(values) != 2 {
+		return Result{}, fmt.Errorf("unexpected rate limit reply: %v", reply)
+	}
+	allowed, ok := values[0].(int64)
+	if !ok {
+		return Result{}, fmt.Errorf("unexpected rate limit reply: %v", reply)
+	}
+	tokenString, ok := values[1].(string)
+	if !ok {
+		return Result{}, fmt.Errorf("unexpected rate limit reply: %v", reply)
+	}
+	tokens, err := strconv.ParseFloat(tokenString, 64)
+	if err != nil {
+		return Result{}, fmt.Errorf("unexpected rate limit reply: %v", reply)
+	}
+	return limit.result(tokens, allowed == 1), nil
+}
+
+func NewRedisStore(client RedisClient) Store {
+	return &redisStore{client: client}
+}
@@ -0,0 +1,198 @@
+package ratelimit
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Pengaturan koneksi Redis
+const (
+	redisDialTimeout = 2 * time.Second
+	redisIOTimeout   = 2 * time.Second
+	redisMaxIdle     = 8 // Jumlah koneksi idle yang disimpan untuk dipakai ulang
+)
+
+// RedisError adalah error yang dikembalikan server (reply "-ERR ...")
+type RedisError string
+
+func (e RedisError) Error() string {
+	return string(e)
+}
+
+// redisConn adalah satu koneksi RESP
+type redisConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// redisClient adalah client RESP2 minimal dengan pool koneksi
+type redisClient struct {
+	addr     string
+	password string
+	db       int
+
+	mu   sync.Mutex
+	idle []*redisConn
+}
+
+// Do implements RedisClient.
+// Koneksi yang gagal di tingkat jaringan ditutup; error dari server tidak merusak koneksi
+func (c *redisClient) Do(args ...string) (interface{}, error) {
+	conn, err := c.get()
+	if err != nil {
+		return nil, err
+	}
+	reply, err := conn.do(args...)
+	var serverErr RedisError
+	if err != nil && !errors.As(err, &serverErr) {
+		conn.conn.Close()
+		return nil, err
+	}
+	c.put(conn)
+	return reply, err
+}
+
+func (c *redisClient) get() (*redisConn, error) {
+	c.mu.Lock()
+	if n := len(c.idle); n > 0 {
+		conn := c.idle[n-1]
+		c.idle = c.idle[:n-1]
+		c.mu.Unlock()
+		return conn, nil
+	}
+	c.mu.Unlock()
+	return c.dial()
+}
+
+func (c *redisClient) put(conn *redisConn) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.idle) >= redisMaxIdle {
+		conn.conn.Close()
+		return
+	}
+	c.idle = append(c.idle, conn)
+}
+
+// dial membuka koneksi baru lalu menjalankan AUTH dan SELECT jika dikonfigurasi
+func (c *redisClient) dial() (*redisConn, error) {
+	netConn, err := net.DialTimeout("tcp", c.addr, redisDialTimeout)
+	if err != nil {
+		return nil, err
+	}
+	conn := &redisConn{conn: netConn, reader: bufio.NewReader(netConn)}
+	if c.password != "" {
+		if _, err := conn.do("AUTH", c.password); err != nil {
+			netConn.Close()
+			return nil, err
+		}
+	}
+	if c.db != 0 {
+		if _, err := conn.do("SELECT", strconv.Itoa(c.db)); err != nil {
+			netConn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+// do menulis command sebagai array bulk string lalu membaca satu reply
+func (conn *redisConn) do(args ...string) (interface{}, error) {
+	if err := conn.conn.SetDeadline(time.Now().Add(redisIOTimeout)); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, 0, 64)
+	buf = append(buf, '*')
+	buf = strconv.AppendInt(buf, int64(len(args)), 10)
+	buf = append(buf, '\r', '\n')
+	for _, arg := range args {
+		buf = append(buf, '$')
+		buf = strconv.AppendInt(buf, int64(len(arg)), 10)
+		buf = append(buf, '\r', '\n')
+		buf = append(buf, arg...)
+		buf = append(buf, '\r', '\n')
+	}
+	if _, err := conn.conn.Write(buf); err != nil {
+		return nil, err
+	}
+	return conn.readReply()
+}
+
+// readReply membaca satu reply RESP2
+func (conn *redisConn) readReply() (interface{}, error) {
+	line, err := conn.readLine()
+	if err != nil {
+		return nil, err
+	}
+	if len(line) == 0 {
+		return nil, errors.New("redis: empty reply")
+	}
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, RedisError(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("redis: invalid bulk length %q", line)
+		}
+		if size < 0 {
+			return nil, nil
+		}
+		data := make([]byte, size+2) // Termasuk \r\n
+		if _, err := io.ReadFull(conn.reader, data); err != nil {
+			return nil, err
+		}
+		return string(data[:size]), nil
+	case '*':
+		count, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("redis: invalid array length %q", line)
+		}
+		if count < 0 {
+			return nil, nil
+		}
+		values := make([]interface{}, count)
+		for i := range values {
+			// Error di dalam array dikembalikan sebagai nilai, bukan menggagalkan seluruh reply
+			value, err := conn.readReply()
+			var serverErr RedisError
+			if errors.As(err, &serverErr) {
+				values[i] = serverErr
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			values[i] = value
+		}
+		return values, nil
+	}
+	return nil, fmt.Errorf("redis: unexpected reply %q", line)
+}
+
+func (conn *redisConn) readLine() (string, error) {
+	line, err := conn.reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	if len(line) < 2 || line[len(line)-2] != '\r' {
+		return "", fmt.Errorf("redis: malformed line %q", line)
+	}
+	return line[:len(line)-2], nil
+}
+
+// NewRedisClient membuat client untuk server Redis di addr (host:port)
+// Koneksi dibuka saat dibutuhkan, sehingga aplikasi tetap bisa start saat Redis belum siap
+func NewRedisClient(addr, password string, db int) RedisClient {
+	return &redisClient{addr: addr, password: password, db: db}
+}
@@ -0,0 +1,181 @@
+package repositories
+
+import (
+	"rest-api/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AdminUserFilter adalah filter pencarian user untuk admin
+type AdminUserFilter struct {
+	Query    string // Cocok sebagian dengan username atau email
+	Role     string
+	Disabled *bool
+	Limit    int
+	Offset   int
+}
+
+// TaskCounts adalah ringkasan jumlah task milik user
+type TaskCounts struct {
+	Total    int64            // Task aktif (tidak termasuk trash)
+	ByStatus map[string]int64 // Task aktif per status
+	Overdue  int64
+	Trashed  int64
+}
+
+type AdminRepository interface {
+	SearchUsers(filter AdminUserFilter) ([]models.User, int64, error)
+	FindUserByID(id uint) (*models.User, error)
+	SetRole(userID uint, role string) error
+	SetDisabledAt(userID uint, disabledAt *time.Time) error
+	RequirePasswordReset(userID uint) error
+	DeleteUser(userID uint) ([]uint, error)
+	CountTasks(userID uint, now time.Time) (*TaskCounts, error)
+	PromoteAdmins(emails []string) (int64, error)
+}
+
+type adminRepository struct {
+	db *gorm.DB
+}
+
+// SearchUsers implements AdminRepository.
+func (r *adminRepository) SearchUsers(filter AdminUserFilter) ([]models.User, int64, error) {
+	query := r.db.Model(&models.User{})
+	if filter.Query != "" {
+		like := "%" + escapeLike(filter.Query) + "%"
+		query = query.Where("(username LIKE ? OR email LIKE ?)", like, like)
+	}
+	if filter.Role != "" {
+		query = query.Where("role = ?", filter.Role)
+	}
+	if filter.Disabled != nil {
+		if *filter.Disabled {
+			query = query.Where("disabled_at IS NOT NULL")
+		} else {
+			query = query.Where("disabled_at IS NULL")
+		}
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var users []models.User
+	if err := query.Session(&gorm.Session{}).
+		Order("id asc").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&users).Error; err != nil {
+		return nil, 0, err
+	}
+	return users, total, nil
+}
+
+// FindUserByID implements AdminRepository.
+func (r *adminRepository) FindUserByID(id uint) (*models.User, error) {
+	var user models.User
+	if err := r.db.First(&user, id).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// SetRole implements AdminRepository.
+func (r *adminRepository) SetRole(userID uint, role string) error {
+	return r.db.Model(&models.User{}).Where("id = ?", userID).Update("role", role).Error
+}
+
+// SetDisabledAt implements AdminRepository.
+// nil = aktifkan kembali
+func (r *adminRepository) SetDisabledAt(userID uint, disabledAt *time.Time) error {
+	return r.db.Model(&models.User{}).Where("id = ?", userID).Update("disabled_at", disabledAt).Error
+}
+
+// RequirePasswordReset implements AdminRepository.
+func (r *adminRepository) RequirePasswordReset(userID uint) error {
+	return r.db.Model(&models.User{}).Where("id = ?", userID).Update("password_reset_required", true).Error
+}
+
+// DeleteUser implements AdminRepository.
+// Menghapus permanen user beserta task (termasuk trash), project dan tag miliknya
+// Token, session dan data auth lain ikut terhapus lewat ON DELETE CASCADE
+// Returns ID task yang dihapus agar bisa dikeluarkan dari index pencarian
+func (r *adminRepository) DeleteUser(userID uint) ([]uint, error) {
+	var taskIDs []uint
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&models.Task{}).Where("user_id = ?", userID).Pluck("id", &taskIDs).Error; err != nil {
+			return err
+		}
+		if len(taskIDs) > 0 {
+			if err := tx.Exec("DELETE FROM task_tags WHERE task_id IN ?", taskIDs).Error; err != nil {
+				return err
+			}
+			// Lepas relasi parent dulu agar urutan penghapusan baris tidak melanggar foreign key
+			if err := tx.Unscoped().Model(&models.Task{}).Where("user_id = ?", userID).UpdateColumn("parent_id", nil).Error; err != nil {
+				return err
+			}
+			if err := tx.Unscoped().Where("user_id = ?", userID).Delete(&models.Task{}).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Unscoped().Where("user_id = ?", userID).Delete(&models.Project{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.Tag{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.User{}, userID).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return taskIDs, nil
+}
+
+// CountTasks implements AdminRepository.
+func (r *adminRepository) CountTasks(userID uint, now time.Time) (*TaskCounts, error) {
+	counts := &TaskCounts{ByStatus: make(map[string]int64)}
+
+	var rows []struct {
+		Status string
+		Count  int64
+	}
+	if err := r.db.Model(&models.Task{}).
+		Select("status, COUNT(*) AS count").
+		Where("user_id = ?", userID).
+		Group("status").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts.ByStatus[row.Status] = row.Count
+		counts.Total += row.Count
+	}
+
+	closed := []string{models.TaskStatusDone, models.TaskStatusCancelled}
+	if err := r.db.Model(&models.Task{}).
+		Where("user_id = ? AND due_at < ? AND status NOT IN ?", userID, now, closed).
+		Count(&counts.Overdue).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Unscoped().Model(&models.Task{}).
+		Where("user_id = ? AND deleted_at IS NOT NULL", userID).
+		Count(&counts.Trashed).Error; err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// PromoteAdmins implements AdminRepository.
+// Menjadikan admin user dengan email terverifikasi di daftar, returns jumlah user yang berubah
+func (r *adminRepository) PromoteAdmins(emails []string) (int64, error) {
+	result := r.db.Model(&models.User{}).
+		Where("email IN ? AND email_verified_at IS NOT NULL AND role <> ?", emails, models.RoleAdmin).
+		Update("role", models.RoleAdmin)
+	return result.RowsAffected, result.Error
+}
+
+func NewAdminRepository(db *gorm.DB) AdminRepository {
+	return &adminRepository{db: db}
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"rest-api/internal/models"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -9,8 +10,13 @@ import (
 type AuthRepository interface {
 	FindByEmail(email string) (*models.User, error)
 	FindEmailOrUsername(email, username string) (*models.User, error)
+	FindByUsername(username string) (*models.User, error)
 	Register(user *models.User) error
 	FindByID(id uint) (*models.User, error)
+	UpdatePassword(userID uint, hashedPassword string) error
+	UpdatePasswordHash(userID uint, hashedPassword string) error
+	VerifyEmail(userID uint, email string, verifiedAt time.Time) error
+	SetRole(userID uint, role string) error
 }
 
 type authRepository struct {
@@ -44,6 +50,40 @@ func (a *authRepository) FindEmailOrUsername(email string, username string) (*mo
 	return &user, nil
 }
 
+// FindByUsername implements AuthRepository.
+func (a *authRepository) FindByUsername(username string) (*models.User, error) {
+	var user models.User
+	if err := a.db.Where("username = ?", username).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// UpdatePassword implements AuthRepository.
+// Kewajiban reset password dari admin ikut dihapus
+func (a *authRepository) UpdatePassword(userID uint, hashedPassword string) error {
+	return a.db.Model(&models.User{}).Where("id = ?", userID).
+		Updates(map[string]interface{}{"password": hashedPassword, "password_reset_required": false}).Error
+}
+
+// UpdatePasswordHash implements AuthRepository.
+// Hanya mengganti hash (upgrade algoritma saat login), status lain user tidak berubah
+func (a *authRepository) UpdatePasswordHash(userID uint, hashedPassword string) error {
+	return a.db.Model(&models.User{}).Where("id = ?", userID).Update("password", hashedPassword).Error
+}
+
+// VerifyEmail implements AuthRepository.
+// Menyimpan email yang sudah dikonfirmasi (bisa berbeda dari email lama saat perubahan email)
+func (a *authRepository) VerifyEmail(userID uint, email string, verifiedAt time.Time) error {
+	return a.db.Model(&models.User{}).Where("id = ?", userID).
+		Updates(map[string]interface{}{"email": email, "email_verified_at": verifiedAt}).Error
+}
+
+// SetRole implements AuthRepository.
+func (a *authRepository) SetRole(userID uint, role string) error {
+	return a.db.Model(&models.User{}).Where("id = ?", userID).Update("role", role).Error
+}
+
 // Register implements AuthRepository.
 func (a *authRepository) Register(user *models.User) error {
 	return a.db.Create(user).Error
@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"rest-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type ChecklistRepository interface {
+	Create(item *models.ChecklistItem) error
+	Update(item *models.ChecklistItem) error
+	FindByID(id uint) (*models.ChecklistItem, error)
+	NextPosition(taskID uint) (int, error)
+	Delete(item *models.ChecklistItem) error
+}
+
+type checklistRepository struct {
+	db *gorm.DB
+}
+
+// Create implements ChecklistRepository.
+func (r *checklistRepository) Create(item *models.ChecklistItem) error {
+	return r.db.Create(item).Error
+}
+
+// Update implements ChecklistRepository.
+func (r *checklistRepository) Update(item *models.ChecklistItem) error {
+	return r.db.Save(item).Error
+}
+
+// FindByID implements ChecklistRepository.
+func (r *checklistRepository) FindByID(id uint) (*models.ChecklistItem, error) {
+	var item models.ChecklistItem
+	if err := r.db.First(&item, id).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// NextPosition implements ChecklistRepository.
+// Mengembalikan posisi setelah item terakhir agar item baru ditambahkan di akhir checklist
+func (r *checklistRepository) NextPosition(taskID uint) (int, error) {
+	var maxPosition *int
+	if err := r.db.Model(&models.ChecklistItem{}).
+		Where("task_id = ?", taskID).
+		Select("MAX(position)").
+		Scan(&maxPosition).Error; err != nil {
+		return 0, err
+	}
+	if maxPosition == nil {
+		return 0, nil
+	}
+	return *maxPosition + 1, nil
+}
+
+// Delete implements ChecklistRepository.
+func (r *checklistRepository) Delete(item *models.ChecklistItem) error {
+	return r.db.Delete(item).Error
+}
+
+func NewChecklistRepository(db *gorm.DB) ChecklistRepository {
+	return &checklistRepository{db: db}
+}
@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"errors"
+	"rest-api/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LoginAttemptRepository interface {
+	FindByKeys(keys ...string) ([]models.LoginAttempt, error)
+	RecordFailure(key string, now time.Time, window time.Duration) (*models.LoginAttempt, error)
+	Lock(key string, until time.Time) error
+	Reset(key string) error
+	DeleteStale(before time.Time) error
+}
+
+type loginAttemptRepository struct {
+	db *gorm.DB
+}
+
+// FindByKeys implements LoginAttemptRepository.
+func (r *loginAttemptRepository) FindByKeys(keys ...string) ([]models.LoginAttempt, error) {
+	var attempts []models.LoginAttempt
+	if err := r.db.Where("`key` IN ?", keys).Find(&attempts).Error; err != nil {
+		return nil, err
+	}
+	return attempts, nil
+}
+
+// RecordFailure implements LoginAttemptRepository.
+// Menambah jumlah gagal; hitungan dimulai ulang jika gagal terakhir lebih lama dari window
+// Baris dikunci (SELECT ... FOR UPDATE) agar request bersamaan tidak saling menimpa
+func (r *loginAttemptRepository) RecordFailure(key string, now time.Time, window time.Duration) (*models.LoginAttempt, error) {
+	var attempt models.LoginAttempt
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("`key` = ?", key).First(&attempt).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			attempt = models.LoginAttempt{Key: key, Failures: 1, LastFailureAt: now}
+			// Baris bisa dibuat request lain di antara SELECT dan INSERT, cukup tambahkan hitungannya
+			return tx.Clauses(clause.OnConflict{
+				DoUpdates: clause.Assignments(map[string]interface{}{
+					"failures":        gorm.Expr("failures + 1"),
+					"last_failure_at": now,
+				}),
+			}).Create(&attempt).Error
+		}
+		if err != nil {
+			return err
+		}
+		if now.Sub(attempt.LastFailureAt) > window {
+			attempt.Failures = 0
+			attempt.LockedUntil = nil
+		}
+		attempt.Failures++
+		attempt.LastFailureAt = now
+		return tx.Save(&attempt).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+// Lock implements LoginAttemptRepository.
+func (r *loginAttemptRepository) Lock(key string, until time.Time) error {
+	return r.db.Model(&models.LoginAttempt{}).Where("`key` = ?", key).Update("locked_until", until).Error
+}
+
+// Reset implements LoginAttemptRepository.
+func (r *loginAttemptRepository) Reset(key string) error {
+	return r.db.Where("`key` = ?", key).Delete(&models.LoginAttempt{}).Error
+}
+
+// DeleteStale implements LoginAttemptRepository.
+// Menghapus catatan yang gagal terakhirnya sebelum batas dan tidak sedang terkunci
+func (r *loginAttemptRepository) DeleteStale(before time.Time) error {
+	return r.db.Where("last_failure_at < ? AND (locked_until IS NULL OR locked_until < ?)", before, time.Now().UTC()).
+		Delete(&models.LoginAttempt{}).Error
+}
+
+func NewLoginAttemptRepository(db *gorm.DB) LoginAttemptRepository {
+	return &loginAttemptRepository{db: db}
+}
@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"rest-api/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type OIDCRepository interface {
+	CreateState(state *models.OIDCState) error
+	ConsumeState(stateHash string) (*models.OIDCState, error)
+	DeleteExpiredStates(now time.Time) error
+	FindIdentity(issuer, subject string) (*models.UserIdentity, error)
+	CreateIdentity(identity *models.UserIdentity) error
+	CreateUserWithIdentity(user *models.User, identity *models.UserIdentity) error
+	TouchIdentity(id uint, email string, loginAt time.Time) error
+}
+
+type oidcRepository struct {
+	db *gorm.DB
+}
+
+// CreateState implements OIDCRepository.
+func (r *oidcRepository) CreateState(state *models.OIDCState) error {
+	return r.db.Create(state).Error
+}
+
+// ConsumeState implements OIDCRepository.
+// State dihapus saat dibaca; jika dua callback datang bersamaan hanya satu yang berhasil menghapus
+func (r *oidcRepository) ConsumeState(stateHash string) (*models.OIDCState, error) {
+	var state models.OIDCState
+	if err := r.db.Where("state_hash = ?", stateHash).First(&state).Error; err != nil {
+		return nil, err
+	}
+	result := r.db.Where("id = ?", state.ID).Delete(&models.OIDCState{})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &state, nil
+}
+
+// DeleteExpiredStates implements OIDCRepository.
+func (r *oidcRepository) DeleteExpiredStates(now time.Time) error {
+	return r.db.Where("expires_at < ?", now).Delete(&models.OIDCState{}).Error
+}
+
+// FindIdentity implements OIDCRepository.
+func (r *oidcRepository) FindIdentity(issuer, subject string) (*models.UserIdentity, error) {
+	var identity models.UserIdentity
+	if err := r.db.Where("issuer = ? AND subject = ?", issuer, subject).First(&identity).Error; err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+// CreateIdentity implements OIDCRepository.
+func (r *oidcRepository) CreateIdentity(identity *models.UserIdentity) error {
+	return r.db.Create(identity).Error
+}
+
+// CreateUserWithIdentity implements OIDCRepository.
+// User baru dan identity dibuat dalam satu transaksi agar tidak ada user tanpa identity jika salah satunya gagal
+func (r *oidcRepository) CreateUserWithIdentity(user *models.User, identity *models.UserIdentity) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		identity.UserID = user.ID
+		return tx.Create(identity).Error
+	})
+}
+
+// TouchIdentity implements OIDCRepository.
+// Menyimpan email terbaru dari provider dan waktu login terakhir
+func (r *oidcRepository) TouchIdentity(id uint, email string, loginAt time.Time) error {
+	return r.db.Model(&models.UserIdentity{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"email": email, "last_login_at": loginAt}).Error
+}
+
+func NewOIDCRepository(db *gorm.DB) OIDCRepository {
+	return &oidcRepository{db: db}
+}
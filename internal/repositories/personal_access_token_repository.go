@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"rest-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type PersonalAccessTokenRepository interface {
+	Create(token *models.PersonalAccessToken) error
+	FindByID(id uint) (*models.PersonalAccessToken, error)
+	FindByUserID(userID uint) ([]models.PersonalAccessToken, error)
+	CountByUserID(userID uint) (int64, error)
+	Delete(token *models.PersonalAccessToken) error
+	DeleteByUserID(userID uint) error
+}
+
+type personalAccessTokenRepository struct {
+	db *gorm.DB
+}
+
+// Create implements PersonalAccessTokenRepository.
+func (r *personalAccessTokenRepository) Create(token *models.PersonalAccessToken) error {
+	return r.db.Create(token).Error
+}
+
+// FindByID implements PersonalAccessTokenRepository.
+func (r *personalAccessTokenRepository) FindByID(id uint) (*models.PersonalAccessToken, error) {
+	var token models.PersonalAccessToken
+	if err := r.db.First(&token, id).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// FindByUserID implements PersonalAccessTokenRepository.
+func (r *personalAccessTokenRepository) FindByUserID(userID uint) ([]models.PersonalAccessToken, error) {
+	var tokens []models.PersonalAccessToken
+	if err := r.db.Where("user_id = ?", userID).Order("created_at desc, id desc").Find(&tokens).Error; err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// CountByUserID implements PersonalAccessTokenRepository.
+func (r *personalAccessTokenRepository) CountByUserID(userID uint) (int64, error) {
+	var count int64
+	if err := r.db.Model(&models.PersonalAccessToken{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// Delete implements PersonalAccessTokenRepository.
+func (r *personalAccessTokenRepository) Delete(token *models.PersonalAccessToken) error {
+	return r.db.Delete(token).Error
+}
+
+// DeleteByUserID implements PersonalAccessTokenRepository.
+func (r *personalAccessTokenRepository) DeleteByUserID(userID uint) error {
+	return r.db.Where("user_id = ?", userID).Delete(&models.PersonalAccessToken{}).Error
+}
+
+func NewPersonalAccessTokenRepository(db *gorm.DB) PersonalAccessTokenRepository {
+	return &personalAccessTokenRepository{db: db}
+}
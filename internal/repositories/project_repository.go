@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"rest-api/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ProjectRepository interface {
+	Create(project *models.Project) error
+	Update(project *models.Project) error
+	FindByID(id uint) (*models.Project, error)
+	FindByName(userID uint, name string) (*models.Project, error)
+	FindAllByUserID(userID uint, includeArchived bool) ([]models.Project, error)
+	SetArchived(project *models.Project, archived bool) error
+	Delete(project *models.Project) error
+	FindTrashedByUserID(userID uint) ([]models.Project, error)
+	FindTrashedByID(id uint) (*models.Project, error)
+	Restore(project *models.Project) error
+	Purge(project *models.Project) error
+	PurgeDeletedBefore(cutoff time.Time) (int64, error)
+}
+
+type projectRepository struct {
+	db *gorm.DB
+}
+
+// Create implements ProjectRepository.
+func (p *projectRepository) Create(project *models.Project) error {
+	return p.db.Create(project).Error
+}
+
+// Update implements ProjectRepository.
+func (p *projectRepository) Update(project *models.Project) error {
+	return p.db.Save(project).Error
+}
+
+// FindByID implements ProjectRepository.
+func (p *projectRepository) FindByID(id uint) (*models.Project, error) {
+	var project models.Project
+	if err := p.db.First(&project, id).Error; err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+// FindByName implements ProjectRepository.
+func (p *projectRepository) FindByName(userID uint, name string) (*models.Project, error) {
+	var project models.Project
+	if err := p.db.Where("user_id = ? AND name = ?", userID, name).First(&project).Error; err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+// FindAllByUserID implements ProjectRepository.
+// Project yang diarsipkan hanya ikut jika includeArchived = true
+func (p *projectRepository) FindAllByUserID(userID uint, includeArchived bool) ([]models.Project, error) {
+	var projects []models.Project
+	query := p.db.Where("user_id = ?", userID)
+	if !includeArchived {
+		query = query.Where("archived_at IS NULL")
+	}
+	if err := query.Order("name asc").Find(&projects).Error; err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+// SetArchived implements ProjectRepository.
+func (p *projectRepository) SetArchived(project *models.Project, archived bool) error {
+	if archived {
+		now := time.Now().UTC()
+		project.ArchivedAt = &now
+	} else {
+		project.ArchivedAt = nil
+	}
+	return p.db.Model(project).Update("archived_at", project.ArchivedAt).Error
+}
+
+// Delete implements ProjectRepository.
+// Soft delete: project dipindahkan ke trash. project_id task di dalamnya tidak diubah agar Restore
+// mengembalikan task ke project; selama project di trash, task ditampilkan sebagai task inbox
+func (p *projectRepository) Delete(project *models.Project) error {
+	return p.db.Delete(project).Error
+}
+
+// FindTrashedByUserID implements ProjectRepository.
+func (p *projectRepository) FindTrashedByUserID(userID uint) ([]models.Project, error) {
+	var projects []models.Project
+	if err := p.db.Unscoped().
+		Where("user_id = ? AND deleted_at IS NOT NULL", userID).
+		Order("deleted_at desc, id desc").
+		Find(&projects).Error; err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+// FindTrashedByID implements ProjectRepository.
+func (p *projectRepository) FindTrashedByID(id uint) (*models.Project, error) {
+	var project models.Project
+	if err := p.db.Unscoped().Where("deleted_at IS NOT NULL").First(&project, id).Error; err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+// Restore implements ProjectRepository.
+func (p *projectRepository) Restore(project *models.Project) error {
+	if err := p.db.Unscoped().Model(project).Update("deleted_at", nil).Error; err != nil {
+		return err
+	}
+	project.DeletedAt = gorm.DeletedAt{}
+	return nil
+}
+
+// Purge implements ProjectRepository.
+// Menghapus permanen project dari trash, foreign key ON DELETE SET NULL memindahkan task-nya ke inbox
+func (p *projectRepository) Purge(project *models.Project) error {
+	return p.db.Unscoped().Delete(project).Error
+}
+
+// PurgeDeletedBefore implements ProjectRepository.
+// Menghapus permanen project yang berada di trash sejak sebelum cutoff (semua user), task-nya pindah ke inbox
+func (p *projectRepository) PurgeDeletedBefore(cutoff time.Time) (int64, error) {
+	result := p.db.Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+		Delete(&models.Project{})
+	return result.RowsAffected, result.Error
+}
+
+func NewProjectRepository(db *gorm.DB) ProjectRepository {
+	return &projectRepository{db: db}
+}
@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"rest-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// SecurityEventFilter adalah filter daftar security event
+type SecurityEventFilter struct {
+	Type   string
+	UserID uint
+	Limit  int
+	Offset int
+}
+
+type SecurityEventRepository interface {
+	Create(event *models.SecurityEvent) error
+	Find(filter SecurityEventFilter) ([]models.SecurityEvent, int64, error)
+}
+
+type securityEventRepository struct {
+	db *gorm.DB
+}
+
+// Create implements SecurityEventRepository.
+func (r *securityEventRepository) Create(event *models.SecurityEvent) error {
+	return r.db.Create(event).Error
+}
+
+// Find implements SecurityEventRepository.
+// Diurutkan dari yang terbaru
+func (r *securityEventRepository) Find(filter SecurityEventFilter) ([]models.SecurityEvent, int64, error) {
+	query := r.db.Model(&models.SecurityEvent{})
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.UserID != 0 {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var events []models.SecurityEvent
+	if err := query.Session(&gorm.Session{}).
+		Order("created_at desc, id desc").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&events).Error; err != nil {
+		return nil, 0, err
+	}
+	return events, total, nil
+}
+
+func NewSecurityEventRepository(db *gorm.DB) SecurityEventRepository {
+	return &securityEventRepository{db: db}
+}
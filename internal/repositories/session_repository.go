@@ -0,0 +1,113 @@
+package repositories
+
+import (
+	"rest-api/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type SessionRepository interface {
+	Create(session *models.Session) error
+	FindByID(id uint) (*models.Session, error)
+	FindActiveByFamilyID(familyID string) (*models.Session, error)
+	FindActiveByUserID(userID uint) ([]models.Session, error)
+	Touch(session *models.Session, seenAt time.Time) error
+	Revoke(session *models.Session, revokedAt time.Time) error
+	RevokeByFamilyID(familyID string, revokedAt time.Time) error
+	RevokeAllByUserID(userID uint, revokedAt time.Time) error
+	RevokeOthersByUserID(userID uint, keepFamilyID string, revokedAt time.Time) error
+	DeleteInactive(before time.Time) error
+}
+
+type sessionRepository struct {
+	db *gorm.DB
+}
+
+// Create implements SessionRepository.
+func (s *sessionRepository) Create(session *models.Session) error {
+	return s.db.Create(session).Error
+}
+
+// FindByID implements SessionRepository.
+func (s *sessionRepository) FindByID(id uint) (*models.Session, error) {
+	var session models.Session
+	if err := s.db.First(&session, id).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// FindActiveByFamilyID implements SessionRepository.
+func (s *sessionRepository) FindActiveByFamilyID(familyID string) (*models.Session, error) {
+	var session models.Session
+	if err := s.db.Where("family_id = ? AND revoked_at IS NULL", familyID).First(&session).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// FindActiveByUserID implements SessionRepository.
+// Diurutkan dari yang terakhir aktif
+func (s *sessionRepository) FindActiveByUserID(userID uint) ([]models.Session, error) {
+	var sessions []models.Session
+	if err := s.db.
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Order("last_seen_at desc, id desc").
+		Find(&sessions).Error; err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// Touch implements SessionRepository.
+func (s *sessionRepository) Touch(session *models.Session, seenAt time.Time) error {
+	if err := s.db.Model(session).UpdateColumn("last_seen_at", seenAt).Error; err != nil {
+		return err
+	}
+	session.LastSeenAt = seenAt
+	return nil
+}
+
+// Revoke implements SessionRepository.
+func (s *sessionRepository) Revoke(session *models.Session, revokedAt time.Time) error {
+	if err := s.db.Model(session).UpdateColumn("revoked_at", revokedAt).Error; err != nil {
+		return err
+	}
+	session.RevokedAt = &revokedAt
+	return nil
+}
+
+// RevokeByFamilyID implements SessionRepository.
+func (s *sessionRepository) RevokeByFamilyID(familyID string, revokedAt time.Time) error {
+	return s.db.Model(&models.Session{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		UpdateColumn("revoked_at", revokedAt).Error
+}
+
+// RevokeAllByUserID implements SessionRepository.
+func (s *sessionRepository) RevokeAllByUserID(userID uint, revokedAt time.Time) error {
+	return s.db.Model(&models.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		UpdateColumn("revoked_at", revokedAt).Error
+}
+
+// RevokeOthersByUserID implements SessionRepository.
+// Mengakhiri semua session user kecuali session dengan family keepFamilyID
+func (s *sessionRepository) RevokeOthersByUserID(userID uint, keepFamilyID string, revokedAt time.Time) error {
+	return s.db.Model(&models.Session{}).
+		Where("user_id = ? AND family_id <> ? AND revoked_at IS NULL", userID, keepFamilyID).
+		UpdateColumn("revoked_at", revokedAt).Error
+}
+
+// DeleteInactive implements SessionRepository.
+// Menghapus session yang sudah diakhiri atau tidak aktif sejak sebelum before
+func (s *sessionRepository) DeleteInactive(before time.Time) error {
+	return s.db.
+		Where("revoked_at < ? OR last_seen_at < ?", before, before).
+		Delete(&models.Session{}).Error
+}
+
+func NewSessionRepository(db *gorm.DB) SessionRepository {
+	return &sessionRepository{db: db}
+}
@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"rest-api/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type SigningKeyRepository interface {
+	Create(key *models.SigningKey) error
+	FindUsable(now time.Time) ([]models.SigningKey, error)
+	SetExpiresAt(ids []uint, expiresAt time.Time) error
+	DeleteExpired(now time.Time) error
+}
+
+type signingKeyRepository struct {
+	db *gorm.DB
+}
+
+// Create implements SigningKeyRepository.
+func (r *signingKeyRepository) Create(key *models.SigningKey) error {
+	return r.db.Create(key).Error
+}
+
+// FindUsable implements SigningKeyRepository.
+// Key yang belum expired, diurutkan dari yang paling lama aktif
+func (r *signingKeyRepository) FindUsable(now time.Time) ([]models.SigningKey, error) {
+	var keys []models.SigningKey
+	if err := r.db.Where("expires_at IS NULL OR expires_at > ?", now).
+		Order("activates_at asc, id asc").
+		Find(&keys).Error; err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// SetExpiresAt implements SigningKeyRepository.
+// Hanya mengisi key yang belum punya ExpiresAt agar masa berlaku yang sudah dijadwalkan tidak diperpanjang
+func (r *signingKeyRepository) SetExpiresAt(ids []uint, expiresAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.Model(&models.SigningKey{}).
+		Where("id IN ? AND expires_at IS NULL", ids).
+		Update("expires_at", expiresAt).Error
+}
+
+// DeleteExpired implements SigningKeyRepository.
+func (r *signingKeyRepository) DeleteExpired(now time.Time) error {
+	return r.db.Where("expires_at IS NOT NULL AND expires_at < ?", now).Delete(&models.SigningKey{}).Error
+}
+
+func NewSigningKeyRepository(db *gorm.DB) SigningKeyRepository {
+	return &signingKeyRepository{db: db}
+}
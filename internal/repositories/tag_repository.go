@@ -0,0 +1,126 @@
+package repositories
+
+import (
+	"rest-api/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type TagRepository interface {
+	Create(tag *models.Tag) error
+	FindByID(id uint) (*models.Tag, error)
+	FindByName(userID uint, name string) (*models.Tag, error)
+	FindByIDs(userID uint, ids []uint) ([]models.Tag, error)
+	FindAllByUserID(userID uint) ([]models.Tag, error)
+	Update(tag *models.Tag) error
+	Merge(source, target *models.Tag) error
+	Delete(tag *models.Tag) error
+}
+
+type tagRepository struct {
+	db *gorm.DB
+}
+
+// Create implements TagRepository.
+func (r *tagRepository) Create(tag *models.Tag) error {
+	return r.db.Create(tag).Error
+}
+
+// FindByID implements TagRepository.
+func (r *tagRepository) FindByID(id uint) (*models.Tag, error) {
+	var tag models.Tag
+	if err := r.db.First(&tag, id).Error; err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// FindByName implements TagRepository.
+func (r *tagRepository) FindByName(userID uint, name string) (*models.Tag, error) {
+	var tag models.Tag
+	if err := r.db.Where("user_id = ? AND name = ?", userID, name).First(&tag).Error; err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// FindByIDs implements TagRepository.
+// Hanya mengembalikan tag milik user, caller membandingkan jumlahnya untuk cek kepemilikan
+func (r *tagRepository) FindByIDs(userID uint, ids []uint) ([]models.Tag, error) {
+	var tags []models.Tag
+	if len(ids) == 0 {
+		return tags, nil
+	}
+	if err := r.db.Where("user_id = ? AND id IN ?", userID, ids).Find(&tags).Error; err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// FindAllByUserID implements TagRepository.
+func (r *tagRepository) FindAllByUserID(userID uint) ([]models.Tag, error) {
+	var tags []models.Tag
+	if err := r.db.Where("user_id = ?", userID).Order("name asc").Find(&tags).Error; err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// Update implements TagRepository.
+// Perubahan tag (rename/warna) dan updated_at semua task yang memakai tag dijalankan dalam satu transaksi
+func (r *tagRepository) Update(tag *models.Tag) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(tag).Error; err != nil {
+			return err
+		}
+		return touchTaggedTasks(tx, tag.ID)
+	})
+}
+
+// Merge implements TagRepository.
+// Semua task yang memakai source dipindahkan ke target, lalu source dihapus, dalam satu transaksi
+func (r *tagRepository) Merge(source, target *models.Tag) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := touchTaggedTasks(tx, source.ID); err != nil {
+			return err
+		}
+		// INSERT IGNORE melewati task yang sudah memiliki tag target (primary key task_id+tag_id)
+		if err := tx.Exec(
+			"INSERT IGNORE INTO task_tags (task_id, tag_id) SELECT task_id, ? FROM task_tags WHERE tag_id = ?",
+			target.ID, source.ID,
+		).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM task_tags WHERE tag_id = ?", source.ID).Error; err != nil {
+			return err
+		}
+		return tx.Delete(source).Error
+	})
+}
+
+// Delete implements TagRepository.
+// Relasi di task_tags ikut dihapus, task-nya sendiri tetap ada
+func (r *tagRepository) Delete(tag *models.Tag) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := touchTaggedTasks(tx, tag.ID); err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM task_tags WHERE tag_id = ?", tag.ID).Error; err != nil {
+			return err
+		}
+		return tx.Delete(tag).Error
+	})
+}
+
+// touchTaggedTasks memperbarui updated_at semua task yang memakai tag
+// agar client yang melakukan sync tahu task tersebut berubah
+func touchTaggedTasks(tx *gorm.DB, tagID uint) error {
+	return tx.Model(&models.Task{}).
+		Where("id IN (?)", tx.Table("task_tags").Select("task_id").Where("tag_id = ?", tagID)).
+		UpdateColumn("updated_at", time.Now().UTC()).Error
+}
+
+func NewTagRepository(db *gorm.DB) TagRepository {
+	return &tagRepository{db: db}
+}
@@ -0,0 +1,201 @@
+package repositories
+
+import (
+	"errors"
+	"rest-api/internal/models"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Field yang bisa dipakai untuk mengurutkan listing task
+const (
+	TaskSortCreated  = "created"
+	TaskSortUpdated  = "updated"
+	TaskSortDue      = "due"
+	TaskSortPriority = "priority"
+	TaskSortTitle    = "title"
+)
+
+// ErrInvalidCursor dikembalikan jika cursor tidak cocok dengan sort atau urutan yang diminta
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// TaskFilter berisi kriteria opsional untuk listing task
+type TaskFilter struct {
+	TagIDs       []uint   // Filter berdasarkan tag
+	MatchAllTags bool     // true = task harus punya semua tag, false = minimal satu tag
+	ProjectID    *uint    // Filter berdasarkan project
+	Statuses     []string // Filter berdasarkan status
+	Search       string   // Pencarian teks sederhana di title dan description
+	DueFrom      *time.Time
+	DueTo        *time.Time // Eksklusif
+	CreatedFrom  *time.Time
+	CreatedTo    *time.Time // Eksklusif
+
+	// Filter due state: overdue/none memakai Now, today/upcoming memakai batas hari [DayStart, DayEnd)
+	DueState string
+	Now      time.Time
+	DayStart time.Time
+	DayEnd   time.Time
+}
+
+// TaskPage berisi pengaturan sort dan cursor pagination
+type TaskPage struct {
+	Sort  string // Salah satu TaskSort*
+	Desc  bool
+	Limit int
+	After *TaskCursor // nil = halaman pertama
+}
+
+// TaskCursor menunjuk baris terakhir dari halaman sebelumnya (keyset pagination)
+// Value adalah nilai kolom sort dalam bentuk string, ID dipakai sebagai tie-breaker
+// Order (asc/desc) disimpan karena cursor hanya berlaku untuk arah urutan yang sama
+type TaskCursor struct {
+	Sort  string `json:"s"`
+	Order string `json:"o"`
+	Value string `json:"v"`
+	ID    uint   `json:"id"`
+}
+
+// Batas COALESCE agar task tanpa due date selalu berada di akhir, baik asc maupun desc
+var (
+	dueSortMax = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+	dueSortMin = time.Date(1000, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// applyTaskFilter menambahkan kondisi WHERE sesuai filter
+func (t *taskRepository) applyTaskFilter(query *gorm.DB, filter TaskFilter) *gorm.DB {
+	if len(filter.TagIDs) > 0 {
+		taggedTasks := t.db.Table("task_tags").Select("task_id").Where("tag_id IN ?", filter.TagIDs)
+		if filter.MatchAllTags {
+			taggedTasks = taggedTasks.Group("task_id").Having("COUNT(DISTINCT tag_id) = ?", len(filter.TagIDs))
+		}
+		query = query.Where("id IN (?)", taggedTasks)
+	}
+	if filter.ProjectID != nil {
+		// Project di trash tidak punya task: task-nya dianggap berada di inbox sampai project di-restore
+		activeProject := t.db.Model(&models.Project{}).Select("id").Where("id = ?", *filter.ProjectID)
+		query = query.Where("project_id IN (?)", activeProject)
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+	if filter.Search != "" {
+		pattern := "%" + escapeLike(filter.Search) + "%"
+		query = query.Where("(title LIKE ? OR description LIKE ?)", pattern, pattern)
+	}
+	if filter.DueFrom != nil {
+		query = query.Where("due_at >= ?", *filter.DueFrom)
+	}
+	if filter.DueTo != nil {
+		query = query.Where("due_at < ?", *filter.DueTo)
+	}
+	if filter.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where("created_at < ?", *filter.CreatedTo)
+	}
+
+	closed := []string{models.TaskStatusDone, models.TaskStatusCancelled}
+	switch filter.DueState {
+	case models.DueStateNone:
+		query = query.Where("due_at IS NULL AND status NOT IN ?", closed)
+	case models.DueStateOverdue:
+		query = query.Where("due_at < ? AND status NOT IN ?", filter.Now, closed)
+	case models.DueStateToday:
+		query = query.Where("due_at >= ? AND due_at >= ? AND due_at < ? AND status NOT IN ?",
+			filter.Now, filter.DayStart, filter.DayEnd, closed)
+	case models.DueStateUpcoming:
+		query = query.Where("due_at >= ? AND due_at >= ? AND status NOT IN ?", filter.Now, filter.DayEnd, closed)
+	}
+	return query
+}
+
+// taskSortColumn mengembalikan ekspresi SQL untuk field sort
+func taskSortColumn(sort string, desc bool) (string, error) {
+	switch sort {
+	case TaskSortCreated:
+		return "created_at", nil
+	case TaskSortUpdated:
+		return "updated_at", nil
+	case TaskSortPriority:
+		return "priority", nil
+	case TaskSortTitle:
+		return "title", nil
+	case TaskSortDue:
+		bound := dueSortMax
+		if desc {
+			bound = dueSortMin
+		}
+		return "COALESCE(due_at, '" + bound.Format("2006-01-02 15:04:05") + "')", nil
+	}
+	return "", errors.New("invalid sort field")
+}
+
+// taskCursorValue membaca nilai cursor sesuai tipe kolom sort
+func taskCursorValue(cursor *TaskCursor) (interface{}, error) {
+	switch cursor.Sort {
+	case TaskSortCreated, TaskSortUpdated, TaskSortDue:
+		value, err := time.Parse(time.RFC3339Nano, cursor.Value)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		return value, nil
+	case TaskSortPriority:
+		value, err := strconv.Atoi(cursor.Value)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		return value, nil
+	case TaskSortTitle:
+		return cursor.Value, nil
+	}
+	return nil, ErrInvalidCursor
+}
+
+// newTaskCursor membuat cursor dari baris terakhir sebuah halaman
+func newTaskCursor(task *models.Task, sort string, desc bool) *TaskCursor {
+	cursor := &TaskCursor{Sort: sort, Order: taskSortOrder(desc), ID: task.ID}
+	switch sort {
+	case TaskSortCreated:
+		cursor.Value = task.CreatedAt.UTC().Format(time.RFC3339Nano)
+	case TaskSortUpdated:
+		cursor.Value = task.UpdatedAt.UTC().Format(time.RFC3339Nano)
+	case TaskSortPriority:
+		cursor.Value = strconv.Itoa(task.Priority)
+	case TaskSortTitle:
+		cursor.Value = task.Title
+	case TaskSortDue:
+		dueAt := dueSortMax
+		if desc {
+			dueAt = dueSortMin
+		}
+		if task.DueAt != nil {
+			dueAt = *task.DueAt
+		}
+		cursor.Value = dueAt.UTC().Format(time.RFC3339Nano)
+	}
+	return cursor
+}
+
+// taskSortOrder mengembalikan arah urutan untuk cursor
+func taskSortOrder(desc bool) string {
+	if desc {
+		return "desc"
+	}
+	return "asc"
+}
+
+// escapeLike meng-escape karakter wildcard LIKE agar input user dicari apa adanya
+func escapeLike(value string) string {
+	escaped := make([]rune, 0, len(value))
+	for _, r := range value {
+		if r == '%' || r == '_' || r == '\\' {
+			escaped = append(escaped, '\\')
+		}
+		escaped = append(escaped, r)
+	}
+	return string(escaped)
+}
@@ -2,8 +2,10 @@ package repositories
 
 import (
 	"rest-api/internal/models"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type TaskRepository interface {
@@ -11,21 +13,51 @@ type TaskRepository interface {
 	Update(task *models.Task) error
 	FindByID(id uint) (*models.Task, error)
 	Delete(task *models.Task) error
-	FindAllByUserID(userID uint) ([]models.Task, error)
+	FindAllByUserID(userID uint, filter TaskFilter, page TaskPage) ([]models.Task, *TaskCursor, int64, error)
+	FindIDsByUserID(userID uint, filter TaskFilter, limit int) ([]uint, error)
+	FindAllByProjectID(projectID uint) ([]models.Task, error)
+	MoveToProject(task *models.Task, projectID *uint) error
+	FindChildren(parentID uint) ([]models.Task, error)
+	FindSubtreeLevels(taskID uint) ([][]uint, error)
+	CompleteMany(ids []uint, completedAt time.Time) error
+	FindOccurrence(seriesID uint, occurrenceIndex int) (*models.Task, error)
+	FindSeries(seriesID uint) ([]models.Task, error)
+	FindByIDs(ids []uint) ([]models.Task, error)
+	FindAllForSearchIndex() ([]models.Task, error)
+	FindTrashedByUserID(userID uint) ([]models.Task, error)
+	FindTrashedByID(id uint) (*models.Task, error)
+	Restore(task *models.Task) ([]uint, error)
+	Purge(task *models.Task) ([]uint, error)
+	PurgeTrashedByUserID(userID uint) ([]uint, error)
+	PurgeDeletedBefore(cutoff time.Time) ([]uint, error)
+	Transaction(fn func(txRepo TaskRepository) error) error
 }
 
 type taskRepository struct {
 	db *gorm.DB
 }
 
+// withDetails menambahkan preload relasi yang dibutuhkan untuk menampilkan task:
+// project (kosong jika project ada di trash), tag, subtask langsung (untuk progress) dan item checklist sesuai urutan
+func withDetails(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Project").
+		Preload("Tags").
+		Preload("Children").
+		Preload("ChecklistItems", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position asc, id asc")
+		})
+}
+
 // Create implements TaskRepository.
 func (t *taskRepository) Create(task *models.Task) error {
-	if err := t.db.Create(task).Error; err != nil {
+	// Omit "Tags.*" agar GORM hanya membuat baris di task_tags tanpa menyentuh tabel tags
+	if err := t.db.Omit("Tags.*").Create(task).Error; err != nil {
 		return err
 	}
 
-	// Setelah berhasil insert, ambil ulang data lengkap dengan relasi User
-	if err := t.db.Preload("User").First(task, task.ID).Error; err != nil {
+	// Setelah berhasil insert, ambil ulang data lengkap dengan relasi User dan Tags
+	if err := t.db.Preload("User").Scopes(withDetails).First(task, task.ID).Error; err != nil {
 		return err
 	}
 
@@ -34,16 +66,98 @@ func (t *taskRepository) Create(task *models.Task) error {
 
 
 // Delete implements TaskRepository.
+// Soft delete: task dan seluruh subtask yang masih aktif dipindahkan ke trash dengan waktu yang sama
+// sehingga bisa di-restore bersama. Tag dan item checklist tetap tersimpan
 func (t *taskRepository) Delete(task *models.Task) error {
-	return t.db.Delete(task).Error
+	return t.db.Transaction(func(tx *gorm.DB) error {
+		txRepo := &taskRepository{db: tx}
+		levels, err := txRepo.FindSubtreeLevels(task.ID)
+		if err != nil {
+			return err
+		}
+		ids := []uint{task.ID}
+		for _, level := range levels {
+			ids = append(ids, level...)
+		}
+		return tx.Model(&models.Task{}).
+			Where("id IN ?", ids).
+			Update("deleted_at", time.Now().UTC()).Error
+	})
 }
 
 // FindAllByUserID implements TaskRepository.
-func (t *taskRepository) FindAllByUserID(userID uint) ([]models.Task, error) {
+// Mengembalikan satu halaman task, cursor untuk halaman berikutnya (nil jika sudah habis)
+// dan total task yang cocok dengan filter (tanpa memperhitungkan cursor)
+func (t *taskRepository) FindAllByUserID(userID uint, filter TaskFilter, page TaskPage) ([]models.Task, *TaskCursor, int64, error) {
+	column, err := taskSortColumn(page.Sort, page.Desc)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	base := t.applyTaskFilter(t.db.Model(&models.Task{}).Where("user_id = ?", userID), filter)
+
+	var total int64
+	if err := base.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, nil, 0, err
+	}
+
+	query := base.Session(&gorm.Session{}).Scopes(withDetails)
+	direction, comparator := "asc", ">"
+	if page.Desc {
+		direction, comparator = "desc", "<"
+	}
+	if page.After != nil {
+		if page.After.Sort != page.Sort || page.After.Order != taskSortOrder(page.Desc) {
+			return nil, nil, 0, ErrInvalidCursor
+		}
+		value, err := taskCursorValue(page.After)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		query = query.Where(
+			"("+column+" "+comparator+" ? OR ("+column+" = ? AND id "+comparator+" ?))",
+			value, value, page.After.ID,
+		)
+	}
+
+	// Ambil satu baris lebih untuk mengetahui apakah masih ada halaman berikutnya
+	var tasks []models.Task
+	if err := query.
+		Order(column + " " + direction).
+		Order("id " + direction).
+		Limit(page.Limit + 1).
+		Find(&tasks).Error; err != nil {
+		return nil, nil, 0, err
+	}
+
+	var next *TaskCursor
+	if len(tasks) > page.Limit {
+		tasks = tasks[:page.Limit]
+		next = newTaskCursor(&tasks[len(tasks)-1], page.Sort, page.Desc)
+	}
+	return tasks, next, total, nil
+}
+
+// FindIDsByUserID implements TaskRepository.
+// Mengembalikan ID task yang cocok dengan filter, maksimum limit baris (diurutkan berdasarkan ID)
+func (t *taskRepository) FindIDsByUserID(userID uint, filter TaskFilter, limit int) ([]uint, error) {
+	var ids []uint
+	if err := t.applyTaskFilter(t.db.Model(&models.Task{}).Where("user_id = ?", userID), filter).
+		Order("id asc").
+		Limit(limit).
+		Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// FindAllByProjectID implements TaskRepository.
+// Dipanggil hanya untuk project yang aktif; task milik project di trash dianggap berada di inbox
+func (t *taskRepository) FindAllByProjectID(projectID uint) ([]models.Task, error) {
 	var tasks []models.Task
 	if err := t.db.
-		Preload("User").
-		Where("user_id = ?", userID).
+		Scopes(withDetails).
+		Where("project_id = ?", projectID).
 		Order("created_at desc").
 		Find(&tasks).Error; err != nil {
 		return nil, err
@@ -51,19 +165,284 @@ func (t *taskRepository) FindAllByUserID(userID uint) ([]models.Task, error) {
 	return tasks, nil
 }
 
+// MoveToProject implements TaskRepository.
+// projectID nil memindahkan task ke inbox (tanpa project)
+func (t *taskRepository) MoveToProject(task *models.Task, projectID *uint) error {
+	if err := t.db.Model(task).Update("project_id", projectID).Error; err != nil {
+		return err
+	}
+	task.ProjectID = projectID
+	return nil
+}
 
 // FindByID implements TaskRepository.
 func (t *taskRepository) FindByID(id uint) (*models.Task, error) {
 	var tasks models.Task
-	if err := t.db.Preload("User").First(&tasks, id).Error; err != nil {
+	if err := t.db.Preload("User").Scopes(withDetails).First(&tasks, id).Error; err != nil {
 		return nil, err
 	}
 	return &tasks, nil
 }
 
 // Update implements TaskRepository.
+// Field task dan daftar tag (task.Tags) disimpan dalam satu transaksi
 func (t *taskRepository) Update(task *models.Task) error {
-	return t.db.Save(task).Error
+	return t.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(task).Error; err != nil {
+			return err
+		}
+		return tx.Model(task).Association("Tags").Replace(task.Tags)
+	})
+}
+
+// FindChildren implements TaskRepository.
+func (t *taskRepository) FindChildren(parentID uint) ([]models.Task, error) {
+	var tasks []models.Task
+	if err := t.db.
+		Scopes(withDetails).
+		Where("parent_id = ?", parentID).
+		Order("created_at asc").
+		Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// FindSubtreeLevels implements TaskRepository.
+// Menelusuri subtask level demi level: levels[0] berisi ID subtask langsung,
+// levels[1] berisi ID subtask dari subtask tersebut, dan seterusnya
+func (t *taskRepository) FindSubtreeLevels(taskID uint) ([][]uint, error) {
+	var levels [][]uint
+	parents := []uint{taskID}
+	for len(parents) > 0 {
+		var children []uint
+		if err := t.db.Model(&models.Task{}).
+			Where("parent_id IN ?", parents).
+			Pluck("id", &children).Error; err != nil {
+			return nil, err
+		}
+		if len(children) > 0 {
+			levels = append(levels, children)
+		}
+		parents = children
+	}
+	return levels, nil
+}
+
+// CompleteMany implements TaskRepository.
+// Task yang sudah done/cancelled tidak diubah
+func (t *taskRepository) CompleteMany(ids []uint, completedAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return t.db.Model(&models.Task{}).
+		Where("id IN ? AND status NOT IN ?", ids, []string{models.TaskStatusDone, models.TaskStatusCancelled}).
+		Updates(map[string]interface{}{
+			"status":            models.TaskStatusDone,
+			"completed_at":      completedAt,
+			"status_changed_at": completedAt,
+		}).Error
+}
+
+// FindOccurrence implements TaskRepository.
+// Occurrence yang berada di trash tetap ditemukan agar tidak dibuat ulang
+func (t *taskRepository) FindOccurrence(seriesID uint, occurrenceIndex int) (*models.Task, error) {
+	var task models.Task
+	if err := t.db.Unscoped().
+		Where("(id = ? OR series_id = ?) AND occurrence_index = ?", seriesID, seriesID, occurrenceIndex).
+		First(&task).Error; err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// FindSeries implements TaskRepository.
+// Mengembalikan seluruh occurrence dalam series, diurutkan dari yang pertama
+func (t *taskRepository) FindSeries(seriesID uint) ([]models.Task, error) {
+	var tasks []models.Task
+	if err := t.db.
+		Scopes(withDetails).
+		Where("id = ? OR series_id = ?", seriesID, seriesID).
+		Order("occurrence_index asc").
+		Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// FindByIDs implements TaskRepository.
+// Urutan hasil mengikuti urutan ids, ID yang tidak ditemukan dilewati
+func (t *taskRepository) FindByIDs(ids []uint) ([]models.Task, error) {
+	if len(ids) == 0 {
+		return []models.Task{}, nil
+	}
+	var found []models.Task
+	if err := t.db.Scopes(withDetails).Where("id IN ?", ids).Find(&found).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]models.Task, len(found))
+	for _, task := range found {
+		byID[task.ID] = task
+	}
+	tasks := make([]models.Task, 0, len(found))
+	for _, id := range ids {
+		if task, ok := byID[id]; ok {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, nil
+}
+
+// FindAllForSearchIndex implements TaskRepository.
+// Hanya mengambil kolom yang dibutuhkan untuk membangun index pencarian in-memory
+func (t *taskRepository) FindAllForSearchIndex() ([]models.Task, error) {
+	var tasks []models.Task
+	if err := t.db.Select("id", "user_id", "title", "description").Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// FindTrashedByUserID implements TaskRepository.
+// Hanya task yang dihapus langsung oleh user; subtask yang ikut terhapus bersama parent-nya tidak ditampilkan
+func (t *taskRepository) FindTrashedByUserID(userID uint) ([]models.Task, error) {
+	var tasks []models.Task
+	if err := t.db.Unscoped().
+		Scopes(withDetails).
+		Where("user_id = ? AND deleted_at IS NOT NULL", userID).
+		Where("NOT EXISTS (SELECT 1 FROM tasks AS parent WHERE parent.id = tasks.parent_id AND parent.deleted_at = tasks.deleted_at)").
+		Order("deleted_at desc, id desc").
+		Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// FindTrashedByID implements TaskRepository.
+func (t *taskRepository) FindTrashedByID(id uint) (*models.Task, error) {
+	var task models.Task
+	if err := t.db.Unscoped().
+		Scopes(withDetails).
+		Where("deleted_at IS NOT NULL").
+		First(&task, id).Error; err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// Restore implements TaskRepository.
+// Mengembalikan task beserta subtask yang terhapus bersamanya, returns ID yang di-restore
+func (t *taskRepository) Restore(task *models.Task) ([]uint, error) {
+	var ids []uint
+	err := t.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		ids, err = trashedSubtree(tx, []uint{task.ID}, &task.DeletedAt.Time)
+		if err != nil {
+			return err
+		}
+		return tx.Unscoped().Model(&models.Task{}).
+			Where("id IN ?", ids).
+			Update("deleted_at", nil).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	task.DeletedAt = gorm.DeletedAt{}
+	return ids, nil
+}
+
+// Purge implements TaskRepository.
+// Menghapus permanen task beserta seluruh subtask-nya, returns ID yang dihapus
+func (t *taskRepository) Purge(task *models.Task) ([]uint, error) {
+	return t.purge([]uint{task.ID})
+}
+
+// PurgeTrashedByUserID implements TaskRepository.
+// Mengosongkan trash milik user
+func (t *taskRepository) PurgeTrashedByUserID(userID uint) ([]uint, error) {
+	var roots []uint
+	if err := t.db.Unscoped().Model(&models.Task{}).
+		Where("user_id = ? AND deleted_at IS NOT NULL", userID).
+		Pluck("id", &roots).Error; err != nil {
+		return nil, err
+	}
+	return t.purge(roots)
+}
+
+// PurgeDeletedBefore implements TaskRepository.
+// Menghapus permanen task yang berada di trash sejak sebelum cutoff (semua user)
+func (t *taskRepository) PurgeDeletedBefore(cutoff time.Time) ([]uint, error) {
+	var roots []uint
+	if err := t.db.Unscoped().Model(&models.Task{}).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+		Pluck("id", &roots).Error; err != nil {
+		return nil, err
+	}
+	return t.purge(roots)
+}
+
+// purge menghapus permanen task rootIDs beserta subtree-nya dalam satu transaksi
+// Baris task_tags dihapus terlebih dahulu, item checklist ikut terhapus (ON DELETE CASCADE)
+func (t *taskRepository) purge(rootIDs []uint) ([]uint, error) {
+	if len(rootIDs) == 0 {
+		return nil, nil
+	}
+	var ids []uint
+	err := t.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		ids, err = trashedSubtree(tx, rootIDs, nil)
+		if err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM task_tags WHERE task_id IN ?", ids).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("id IN ?", ids).Delete(&models.Task{}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// trashedSubtree mengumpulkan rootIDs beserta seluruh keturunannya, termasuk yang berada di trash
+// Jika deletedAt di-set, hanya keturunan yang dihapus pada waktu yang sama yang diikutkan
+func trashedSubtree(tx *gorm.DB, rootIDs []uint, deletedAt *time.Time) ([]uint, error) {
+	// rootIDs bisa saling bersarang (contoh: subtask dan parent-nya sama-sama di trash)
+	seen := make(map[uint]bool)
+	var ids []uint
+	level := rootIDs
+	for len(level) > 0 {
+		var parents []uint
+		for _, id := range level {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+				parents = append(parents, id)
+			}
+		}
+		if len(parents) == 0 {
+			break
+		}
+		query := tx.Unscoped().Model(&models.Task{}).Where("parent_id IN ?", parents)
+		if deletedAt != nil {
+			query = query.Where("deleted_at = ?", *deletedAt)
+		}
+		var children []uint
+		if err := query.Pluck("id", &children).Error; err != nil {
+			return nil, err
+		}
+		level = children
+	}
+	return ids, nil
+}
+
+// Transaction implements TaskRepository.
+// fn menerima TaskRepository yang terikat ke transaksi yang sama
+func (t *taskRepository) Transaction(fn func(txRepo TaskRepository) error) error {
+	return t.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&taskRepository{db: tx})
+	})
 }
 
 func NewTaskRepository(db *gorm.DB) TaskRepository {
@@ -0,0 +1,148 @@
+package repositories
+
+import (
+	"errors"
+	"rest-api/internal/models"
+	"strings"
+	"unicode"
+
+	"gorm.io/gorm"
+)
+
+// TaskSearchQuery adalah parameter pencarian full-text
+// Text mendukung:
+//   - kata biasa: semua kata harus ada (AND)
+//   - "frasa dalam tanda kutip": kata harus muncul berurutan
+//   - prefix*: cocok dengan kata yang diawali prefix
+type TaskSearchQuery struct {
+	UserID uint
+	Text   string
+	Limit  int
+	Offset int
+}
+
+// TaskSearchHit adalah satu hasil pencarian beserta skor relevansinya
+type TaskSearchHit struct {
+	TaskID uint
+	Score  float64
+}
+
+// TaskSearcher adalah abstraksi pencarian full-text untuk task
+// Implementasi: MySQL FULLTEXT (production) dan index in-memory (test/embedded)
+type TaskSearcher interface {
+	// Index menambahkan atau memperbarui task di index
+	Index(task *models.Task) error
+	// Remove menghapus task dari index
+	Remove(taskIDs ...uint) error
+	// Search mengembalikan hasil yang diurutkan berdasarkan relevansi dan total hasil
+	Search(query TaskSearchQuery) ([]TaskSearchHit, int64, error)
+}
+
+// ErrEmptySearchQuery dikembalikan jika query tidak berisi kata yang bisa dicari
+var ErrEmptySearchQuery = errors.New("empty search query")
+
+// searchClause adalah satu bagian query: kata tunggal, prefix, atau frasa
+type searchClause struct {
+	Terms  []string // Lebih dari satu untuk frasa
+	Prefix bool     // Hanya untuk kata tunggal
+}
+
+// parseSearchQuery memecah teks query menjadi clause
+func parseSearchQuery(text string) []searchClause {
+	var clauses []searchClause
+	for i, segment := range strings.Split(text, `"`) {
+		// Segmen ganjil berada di dalam tanda kutip (frasa)
+		if i%2 == 1 {
+			if terms := tokenize(segment); len(terms) > 0 {
+				clauses = append(clauses, searchClause{Terms: terms})
+			}
+			continue
+		}
+		for _, word := range strings.Fields(segment) {
+			prefix := strings.HasSuffix(word, "*")
+			for _, term := range tokenize(word) {
+				clauses = append(clauses, searchClause{Terms: []string{term}})
+			}
+			if prefix && len(clauses) > 0 {
+				clauses[len(clauses)-1].Prefix = true
+			}
+		}
+	}
+	return clauses
+}
+
+// tokenize memecah teks menjadi kata lowercase (huruf dan angka)
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// mysqlTaskSearcher memakai index FULLTEXT (title, description) di MySQL
+// Index dikelola oleh database sehingga Index/Remove tidak melakukan apa-apa
+type mysqlTaskSearcher struct {
+	db *gorm.DB
+}
+
+// Index implements TaskSearcher.
+func (s *mysqlTaskSearcher) Index(task *models.Task) error {
+	return nil
+}
+
+// Remove implements TaskSearcher.
+func (s *mysqlTaskSearcher) Remove(taskIDs ...uint) error {
+	return nil
+}
+
+// Search implements TaskSearcher.
+// Query diterjemahkan ke BOOLEAN MODE: setiap clause wajib (+), frasa dalam tanda kutip, prefix dengan *
+// Catatan: kata yang lebih pendek dari innodb_ft_min_token_size (default 3) atau stopword tidak ter-index
+func (s *mysqlTaskSearcher) Search(query TaskSearchQuery) ([]TaskSearchHit, int64, error) {
+	clauses := parseSearchQuery(query.Text)
+	if len(clauses) == 0 {
+		return nil, 0, ErrEmptySearchQuery
+	}
+	parts := make([]string, 0, len(clauses))
+	for _, clause := range clauses {
+		switch {
+		case len(clause.Terms) > 1:
+			parts = append(parts, `+"`+strings.Join(clause.Terms, " ")+`"`)
+		case clause.Prefix:
+			parts = append(parts, "+"+clause.Terms[0]+"*")
+		default:
+			parts = append(parts, "+"+clause.Terms[0])
+		}
+	}
+	against := strings.Join(parts, " ")
+	match := "MATCH(title, description) AGAINST (? IN BOOLEAN MODE)"
+
+	base := s.db.Model(&models.Task{}).Where("user_id = ? AND "+match, query.UserID, against)
+
+	var total int64
+	if err := base.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rows []struct {
+		ID    uint
+		Score float64
+	}
+	if err := base.Session(&gorm.Session{}).
+		Select("id, "+match+" AS score", against).
+		Order("score desc, id desc").
+		Limit(query.Limit).
+		Offset(query.Offset).
+		Scan(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+
+	hits := make([]TaskSearchHit, 0, len(rows))
+	for _, row := range rows {
+		hits = append(hits, TaskSearchHit{TaskID: row.ID, Score: row.Score})
+	}
+	return hits, total, nil
+}
+
+func NewMySQLTaskSearcher(db *gorm.DB) TaskSearcher {
+	return &mysqlTaskSearcher{db: db}
+}
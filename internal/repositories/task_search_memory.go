@@ -0,0 +1,250 @@
+package repositories
+
+import (
+	"math"
+	"rest-api/internal/models"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Parameter BM25 dan bobot field untuk index in-memory
+const (
+	bm25K1       = 1.2
+	bm25B        = 0.75
+	titleBoost   = 2.0
+	fieldGapSize = 10 // Jarak posisi antara title dan description agar frasa tidak melintasi field
+)
+
+// memoryDocument adalah representasi task di index in-memory
+type memoryDocument struct {
+	userID    uint
+	length    int
+	titleLen  int              // Posisi < titleLen berasal dari title
+	positions map[string][]int // term -> posisi kemunculan
+}
+
+// memoryTaskSearcher adalah inverted index pure-Go, dipakai untuk test dan deployment embedded
+// Aman dipakai bersamaan dari banyak goroutine
+type memoryTaskSearcher struct {
+	mu        sync.RWMutex
+	documents map[uint]*memoryDocument
+	postings  map[string]map[uint]struct{} // term -> set task ID
+	totalLen  int
+}
+
+// Index implements TaskSearcher.
+func (s *memoryTaskSearcher) Index(task *models.Task) error {
+	titleTerms := tokenize(task.Title)
+	descriptionTerms := tokenize(task.Description)
+
+	doc := &memoryDocument{
+		userID:    task.UserID,
+		length:    len(titleTerms) + len(descriptionTerms),
+		titleLen:  len(titleTerms),
+		positions: make(map[string][]int),
+	}
+	for i, term := range titleTerms {
+		doc.positions[term] = append(doc.positions[term], i)
+	}
+	for i, term := range descriptionTerms {
+		position := len(titleTerms) + fieldGapSize + i
+		doc.positions[term] = append(doc.positions[term], position)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.removeLocked(task.ID)
+	s.documents[task.ID] = doc
+	s.totalLen += doc.length
+	for term := range doc.positions {
+		if s.postings[term] == nil {
+			s.postings[term] = make(map[uint]struct{})
+		}
+		s.postings[term][task.ID] = struct{}{}
+	}
+	return nil
+}
+
+// Remove implements TaskSearcher.
+func (s *memoryTaskSearcher) Remove(taskIDs ...uint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range taskIDs {
+		s.removeLocked(id)
+	}
+	return nil
+}
+
+func (s *memoryTaskSearcher) removeLocked(taskID uint) {
+	doc, ok := s.documents[taskID]
+	if !ok {
+		return
+	}
+	for term := range doc.positions {
+		delete(s.postings[term], taskID)
+		if len(s.postings[term]) == 0 {
+			delete(s.postings, term)
+		}
+	}
+	s.totalLen -= doc.length
+	delete(s.documents, taskID)
+}
+
+// Search implements TaskSearcher.
+// Semua clause harus cocok; skor memakai BM25 dengan bobot lebih untuk kata di title
+func (s *memoryTaskSearcher) Search(query TaskSearchQuery) ([]TaskSearchHit, int64, error) {
+	clauses := parseSearchQuery(query.Text)
+	if len(clauses) == 0 {
+		return nil, 0, ErrEmptySearchQuery
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	scores := map[uint]float64{}
+	for i, clause := range clauses {
+		clauseScores := s.scoreClause(clause, query.UserID)
+		if i == 0 {
+			scores = clauseScores
+			continue
+		}
+		for id, score := range scores {
+			clauseScore, ok := clauseScores[id]
+			if !ok {
+				delete(scores, id)
+				continue
+			}
+			scores[id] = score + clauseScore
+		}
+	}
+
+	hits := make([]TaskSearchHit, 0, len(scores))
+	for id, score := range scores {
+		hits = append(hits, TaskSearchHit{TaskID: id, Score: score})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].TaskID > hits[j].TaskID
+	})
+
+	total := int64(len(hits))
+	if query.Offset >= len(hits) {
+		return []TaskSearchHit{}, total, nil
+	}
+	hits = hits[query.Offset:]
+	if query.Limit > 0 && len(hits) > query.Limit {
+		hits = hits[:query.Limit]
+	}
+	return hits, total, nil
+}
+
+// scoreClause menghitung skor setiap dokumen milik user yang cocok dengan clause
+func (s *memoryTaskSearcher) scoreClause(clause searchClause, userID uint) map[uint]float64 {
+	scores := map[uint]float64{}
+	terms := []string{clause.Terms[0]}
+	if clause.Prefix {
+		terms = s.expandPrefix(clause.Terms[0])
+	}
+
+	for _, term := range terms {
+		idf := s.idf(len(s.postings[term]))
+		for id := range s.postings[term] {
+			doc := s.documents[id]
+			if doc.userID != userID {
+				continue
+			}
+			var frequency float64
+			if len(clause.Terms) > 1 {
+				frequency = phraseFrequency(doc, clause.Terms)
+			} else {
+				frequency = termFrequency(doc, term)
+			}
+			if frequency == 0 {
+				continue
+			}
+			scores[id] += idf * s.saturate(frequency, doc.length)
+		}
+	}
+	return scores
+}
+
+// expandPrefix mengembalikan semua term di index yang diawali prefix
+func (s *memoryTaskSearcher) expandPrefix(prefix string) []string {
+	var terms []string
+	for term := range s.postings {
+		if strings.HasPrefix(term, prefix) {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+func (s *memoryTaskSearcher) idf(documentFrequency int) float64 {
+	n := float64(len(s.documents))
+	df := float64(documentFrequency)
+	return math.Log(1 + (n-df+0.5)/(df+0.5))
+}
+
+func (s *memoryTaskSearcher) saturate(frequency float64, length int) float64 {
+	averageLen := 1.0
+	if len(s.documents) > 0 {
+		averageLen = math.Max(float64(s.totalLen)/float64(len(s.documents)), 1)
+	}
+	norm := bm25K1 * (1 - bm25B + bm25B*float64(length)/averageLen)
+	return frequency * (bm25K1 + 1) / (frequency + norm)
+}
+
+// termFrequency menghitung kemunculan term, kemunculan di title diberi bobot titleBoost
+func termFrequency(doc *memoryDocument, term string) float64 {
+	var frequency float64
+	for _, position := range doc.positions[term] {
+		if position < doc.titleLen {
+			frequency += titleBoost
+		} else {
+			frequency++
+		}
+	}
+	return frequency
+}
+
+// phraseFrequency menghitung kemunculan frasa (term berurutan)
+func phraseFrequency(doc *memoryDocument, terms []string) float64 {
+	var frequency float64
+	for _, start := range doc.positions[terms[0]] {
+		matched := true
+		for offset, term := range terms[1:] {
+			if !containsPosition(doc.positions[term], start+offset+1) {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+		if start < doc.titleLen {
+			frequency += titleBoost
+		} else {
+			frequency++
+		}
+	}
+	return frequency
+}
+
+func containsPosition(positions []int, target int) bool {
+	for _, position := range positions {
+		if position == target {
+			return true
+		}
+	}
+	return false
+}
+
+func NewMemoryTaskSearcher() TaskSearcher {
+	return &memoryTaskSearcher{
+		documents: make(map[uint]*memoryDocument),
+		postings:  make(map[string]map[uint]struct{}),
+	}
+}
@@ -0,0 +1,198 @@
+package repositories
+
+import (
+	"errors"
+	"reflect"
+	"rest-api/internal/models"
+	"testing"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []searchClause
+	}{
+		{
+			name: "kata biasa menjadi clause terpisah dan lowercase",
+			text: "Deploy API",
+			want: []searchClause{{Terms: []string{"deploy"}}, {Terms: []string{"api"}}},
+		},
+		{
+			name: "frasa dalam tanda kutip",
+			text: `"login bug" urgent`,
+			want: []searchClause{{Terms: []string{"login", "bug"}}, {Terms: []string{"urgent"}}},
+		},
+		{
+			name: "prefix",
+			text: "deplo*",
+			want: []searchClause{{Terms: []string{"deplo"}, Prefix: true}},
+		},
+		{
+			name: "prefix hanya untuk kata terakhir dari kata bersambung",
+			text: "foo-ba*",
+			want: []searchClause{{Terms: []string{"foo"}}, {Terms: []string{"ba"}, Prefix: true}},
+		},
+		{
+			name: "tanda kutip tidak ditutup tetap dianggap frasa",
+			text: `"release notes`,
+			want: []searchClause{{Terms: []string{"release", "notes"}}},
+		},
+		{
+			name: "tanda baca saja tidak menghasilkan clause",
+			text: `"" * -- !`,
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseSearchQuery(tt.text)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseSearchQuery(%q) = %+v, want %+v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryTaskSearcher(t *testing.T) {
+	searcher := NewMemoryTaskSearcher()
+	tasks := []models.Task{
+		{ID: 1, UserID: 1, Title: "Fix login bug", Description: "users cannot sign in"},
+		{ID: 2, UserID: 1, Title: "Bug in login page", Description: "button misaligned"},
+		{ID: 3, UserID: 1, Title: "Write report", Description: "include the deployment checklist"},
+		{ID: 4, UserID: 1, Title: "Deploy backend", Description: "after review"},
+		{ID: 5, UserID: 2, Title: "Fix login bug", Description: "ship hotfix"},
+	}
+	for i := range tasks {
+		if err := searcher.Index(&tasks[i]); err != nil {
+			t.Fatalf("Index(%d): %v", tasks[i].ID, err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		query     TaskSearchQuery
+		wantIDs   []uint
+		wantTotal int64
+	}{
+		{
+			name:      "semua kata harus ada, dokumen lebih pendek lebih relevan",
+			query:     TaskSearchQuery{UserID: 1, Text: "login bug"},
+			wantIDs:   []uint{2, 1},
+			wantTotal: 2,
+		},
+		{
+			name:      "frasa harus berurutan",
+			query:     TaskSearchQuery{UserID: 1, Text: `"login bug"`},
+			wantIDs:   []uint{1},
+			wantTotal: 1,
+		},
+		{
+			name:      "frasa tidak melintasi title dan description",
+			query:     TaskSearchQuery{UserID: 1, Text: `"bug users"`},
+			wantIDs:   []uint{},
+			wantTotal: 0,
+		},
+		{
+			name:      "prefix cocok dengan beberapa kata, kata di title lebih relevan",
+			query:     TaskSearchQuery{UserID: 1, Text: "deplo*"},
+			wantIDs:   []uint{4, 3},
+			wantTotal: 2,
+		},
+		{
+			name:      "hanya task milik user",
+			query:     TaskSearchQuery{UserID: 2, Text: "login"},
+			wantIDs:   []uint{5},
+			wantTotal: 1,
+		},
+		{
+			name:      "task user lain tidak ikut dihitung",
+			query:     TaskSearchQuery{UserID: 1, Text: "hotfix"},
+			wantIDs:   []uint{},
+			wantTotal: 0,
+		},
+		{
+			name:      "limit dan offset",
+			query:     TaskSearchQuery{UserID: 1, Text: "login", Limit: 1, Offset: 1},
+			wantIDs:   []uint{1},
+			wantTotal: 2,
+		},
+		{
+			name:      "offset melewati hasil",
+			query:     TaskSearchQuery{UserID: 1, Text: "login", Offset: 5},
+			wantIDs:   []uint{},
+			wantTotal: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits, total, err := searcher.Search(tt.query)
+			if err != nil {
+				t.Fatalf("Search: %v", err)
+			}
+			if total != tt.wantTotal {
+				t.Errorf("total = %d, want %d", total, tt.wantTotal)
+			}
+			if got := hitIDs(hits); !reflect.DeepEqual(got, tt.wantIDs) {
+				t.Errorf("hits = %v, want %v", got, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestMemoryTaskSearcherRanksTitleAboveDescription(t *testing.T) {
+	searcher := NewMemoryTaskSearcher()
+	tasks := []models.Task{
+		{ID: 1, UserID: 1, Title: "Weekly sync", Description: "prepare invoice"},
+		{ID: 2, UserID: 1, Title: "Send invoice", Description: "to the client"},
+	}
+	for i := range tasks {
+		searcher.Index(&tasks[i])
+	}
+	hits, _, err := searcher.Search(TaskSearchQuery{UserID: 1, Text: "invoice"})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if got := hitIDs(hits); !reflect.DeepEqual(got, []uint{2, 1}) {
+		t.Fatalf("hits = %v, want [2 1]", got)
+	}
+	if hits[0].Score <= hits[1].Score {
+		t.Errorf("title score %v should be greater than description score %v", hits[0].Score, hits[1].Score)
+	}
+}
+
+func TestMemoryTaskSearcherReindexAndRemove(t *testing.T) {
+	searcher := NewMemoryTaskSearcher()
+	task := models.Task{ID: 1, UserID: 1, Title: "Draft proposal"}
+	searcher.Index(&task)
+
+	// Index ulang mengganti isi lama
+	task.Title = "Final proposal"
+	searcher.Index(&task)
+	if hits, _, _ := searcher.Search(TaskSearchQuery{UserID: 1, Text: "draft"}); len(hits) != 0 {
+		t.Errorf("old title still matches: %v", hitIDs(hits))
+	}
+	if hits, _, _ := searcher.Search(TaskSearchQuery{UserID: 1, Text: "final"}); len(hits) != 1 {
+		t.Errorf("new title does not match: %v", hitIDs(hits))
+	}
+
+	searcher.Remove(task.ID)
+	if hits, _, _ := searcher.Search(TaskSearchQuery{UserID: 1, Text: "proposal"}); len(hits) != 0 {
+		t.Errorf("removed task still matches: %v", hitIDs(hits))
+	}
+}
+
+func TestMemoryTaskSearcherEmptyQuery(t *testing.T) {
+	searcher := NewMemoryTaskSearcher()
+	if _, _, err := searcher.Search(TaskSearchQuery{UserID: 1, Text: ` "" `}); !errors.Is(err, ErrEmptySearchQuery) {
+		t.Errorf("err = %v, want ErrEmptySearchQuery", err)
+	}
+}
+
+func hitIDs(hits []TaskSearchHit) []uint {
+	ids := make([]uint, 0, len(hits))
+	for _, hit := range hits {
+		ids = append(ids, hit.TaskID)
+	}
+	return ids
+}
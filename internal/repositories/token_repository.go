@@ -0,0 +1,124 @@
+package repositories
+
+import (
+	"rest-api/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TokenRepository interface {
+	CreateRefreshToken(token *models.RefreshToken) error
+	FindRefreshTokenByHash(tokenHash string) (*models.RefreshToken, error)
+	MarkRefreshTokenUsed(token *models.RefreshToken, usedAt time.Time) (bool, error)
+	RevokeFamily(familyID string, revokedAt time.Time) error
+	RevokeAllByUserID(userID uint, revokedAt time.Time) error
+	RevokeOthersByUserID(userID uint, keepFamilyID string, revokedAt time.Time) error
+	IsAccessTokenRevoked(jti string) (bool, error)
+	DeleteExpired(now time.Time) error
+	Transaction(fn func(txRepo TokenRepository) error) error
+}
+
+type tokenRepository struct {
+	db *gorm.DB
+}
+
+// CreateRefreshToken implements TokenRepository.
+func (r *tokenRepository) CreateRefreshToken(token *models.RefreshToken) error {
+	return r.db.Create(token).Error
+}
+
+// FindRefreshTokenByHash implements TokenRepository.
+func (r *tokenRepository) FindRefreshTokenByHash(tokenHash string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	if err := r.db.Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// MarkRefreshTokenUsed implements TokenRepository.
+// Returns false jika token sudah pernah dipakai atau dicabut (termasuk oleh request lain yang bersamaan)
+func (r *tokenRepository) MarkRefreshTokenUsed(token *models.RefreshToken, usedAt time.Time) (bool, error) {
+	result := r.db.Model(&models.RefreshToken{}).
+		Where("id = ? AND used_at IS NULL AND revoked_at IS NULL", token.ID).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	token.UsedAt = &usedAt
+	return result.RowsAffected == 1, nil
+}
+
+// RevokeFamily implements TokenRepository.
+// Mencabut semua refresh token dalam family dan access token yang diterbitkan bersamanya
+func (r *tokenRepository) RevokeFamily(familyID string, revokedAt time.Time) error {
+	return r.revokeWhere(revokedAt, "family_id = ?", familyID)
+}
+
+// RevokeAllByUserID implements TokenRepository.
+func (r *tokenRepository) RevokeAllByUserID(userID uint, revokedAt time.Time) error {
+	return r.revokeWhere(revokedAt, "user_id = ?", userID)
+}
+
+// RevokeOthersByUserID implements TokenRepository.
+// Mencabut semua family refresh token user kecuali keepFamilyID
+func (r *tokenRepository) RevokeOthersByUserID(userID uint, keepFamilyID string, revokedAt time.Time) error {
+	return r.revokeWhere(revokedAt, "user_id = ? AND family_id <> ?", userID, keepFamilyID)
+}
+
+// revokeWhere mencabut refresh token yang cocok dengan kondisi query dan mencatat jti access token
+// yang belum expired agar langsung ditolak middleware Auth
+func (r *tokenRepository) revokeWhere(revokedAt time.Time, query string, args ...interface{}) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var tokens []models.RefreshToken
+		if err := tx.Where(query, args...).
+			Where("access_expires_at > ?", revokedAt).
+			Find(&tokens).Error; err != nil {
+			return err
+		}
+		if len(tokens) > 0 {
+			revoked := make([]models.RevokedToken, 0, len(tokens))
+			for _, token := range tokens {
+				revoked = append(revoked, models.RevokedToken{JTI: token.AccessJTI, ExpiresAt: token.AccessExpiresAt})
+			}
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&revoked).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&models.RefreshToken{}).
+			Where(query, args...).
+			Where("revoked_at IS NULL").
+			Update("revoked_at", revokedAt).Error
+	})
+}
+
+// IsAccessTokenRevoked implements TokenRepository.
+func (r *tokenRepository) IsAccessTokenRevoked(jti string) (bool, error) {
+	var count int64
+	if err := r.db.Model(&models.RevokedToken{}).Where("jti = ?", jti).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// DeleteExpired implements TokenRepository.
+// Menghapus refresh token dan catatan jti yang sudah expired
+func (r *tokenRepository) DeleteExpired(now time.Time) error {
+	if err := r.db.Where("expires_at < ?", now).Delete(&models.RefreshToken{}).Error; err != nil {
+		return err
+	}
+	return r.db.Where("expires_at < ?", now).Delete(&models.RevokedToken{}).Error
+}
+
+// Transaction implements TokenRepository.
+func (r *tokenRepository) Transaction(fn func(txRepo TokenRepository) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&tokenRepository{db: tx})
+	})
+}
+
+func NewTokenRepository(db *gorm.DB) TokenRepository {
+	return &tokenRepository{db: db}
+}
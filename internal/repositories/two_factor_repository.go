@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"rest-api/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type TwoFactorRepository interface {
+	SaveSecret(userID uint, encryptedSecret string) error
+	Enable(userID uint, enabledAt time.Time) error
+	Disable(userID uint) error
+	AdvanceLastStep(userID uint, step int64) (bool, error)
+	ReplaceRecoveryCodes(userID uint, codeHashes []string) error
+	UseRecoveryCode(userID uint, codeHash string, usedAt time.Time) (bool, error)
+	CountUnusedRecoveryCodes(userID uint) (int64, error)
+}
+
+type twoFactorRepository struct {
+	db *gorm.DB
+}
+
+// SaveSecret implements TwoFactorRepository.
+// Memulai (atau mengulang) enrollment; 2FA baru aktif setelah Enable
+func (r *twoFactorRepository) SaveSecret(userID uint, encryptedSecret string) error {
+	return r.db.Model(&models.User{}).Where("id = ?", userID).
+		Updates(map[string]interface{}{"totp_secret": encryptedSecret, "totp_last_step": 0, "two_factor_enabled_at": nil}).Error
+}
+
+// Enable implements TwoFactorRepository.
+func (r *twoFactorRepository) Enable(userID uint, enabledAt time.Time) error {
+	return r.db.Model(&models.User{}).Where("id = ?", userID).Update("two_factor_enabled_at", enabledAt).Error
+}
+
+// Disable implements TwoFactorRepository.
+// Menghapus secret dan semua recovery code
+func (r *twoFactorRepository) Disable(userID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.User{}).Where("id = ?", userID).
+			Updates(map[string]interface{}{"totp_secret": "", "totp_last_step": 0, "two_factor_enabled_at": nil}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error
+	})
+}
+
+// AdvanceLastStep implements TwoFactorRepository.
+// Returns false jika kode untuk step ini (atau yang lebih baru) sudah pernah diterima
+func (r *twoFactorRepository) AdvanceLastStep(userID uint, step int64) (bool, error) {
+	result := r.db.Model(&models.User{}).
+		Where("id = ? AND totp_last_step < ?", userID, step).
+		Update("totp_last_step", step)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// ReplaceRecoveryCodes implements TwoFactorRepository.
+// Recovery code lama (terpakai maupun belum) tidak berlaku lagi
+func (r *twoFactorRepository) ReplaceRecoveryCodes(userID uint, codeHashes []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		codes := make([]models.RecoveryCode, 0, len(codeHashes))
+		for _, hash := range codeHashes {
+			codes = append(codes, models.RecoveryCode{UserID: userID, CodeHash: hash})
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+// UseRecoveryCode implements TwoFactorRepository.
+// Returns false jika kode tidak ditemukan atau sudah dipakai
+func (r *twoFactorRepository) UseRecoveryCode(userID uint, codeHash string, usedAt time.Time) (bool, error) {
+	result := r.db.Model(&models.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Limit(1).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// CountUnusedRecoveryCodes implements TwoFactorRepository.
+func (r *twoFactorRepository) CountUnusedRecoveryCodes(userID uint) (int64, error) {
+	var count int64
+	if err := r.db.Model(&models.RecoveryCode{}).Where("user_id = ? AND used_at IS NULL", userID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func NewTwoFactorRepository(db *gorm.DB) TwoFactorRepository {
+	return &twoFactorRepository{db: db}
+}
@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"rest-api/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type UserTokenRepository interface {
+	Create(token *models.UserToken) error
+	FindByHash(purpose, tokenHash string) (*models.UserToken, error)
+	MarkUsed(token *models.UserToken, usedAt time.Time) (bool, error)
+	InvalidateByUserID(userID uint, purpose string, usedAt time.Time) error
+	IncrementAttempts(token *models.UserToken) error
+	DeleteExpired(now time.Time) error
+}
+
+type userTokenRepository struct {
+	db *gorm.DB
+}
+
+// Create implements UserTokenRepository.
+func (r *userTokenRepository) Create(token *models.UserToken) error {
+	return r.db.Create(token).Error
+}
+
+// FindByHash implements UserTokenRepository.
+func (r *userTokenRepository) FindByHash(purpose string, tokenHash string) (*models.UserToken, error) {
+	var token models.UserToken
+	if err := r.db.Where("purpose = ? AND token_hash = ?", purpose, tokenHash).First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// MarkUsed implements UserTokenRepository.
+// Returns false jika token sudah pernah dipakai (termasuk oleh request lain yang bersamaan)
+func (r *userTokenRepository) MarkUsed(token *models.UserToken, usedAt time.Time) (bool, error) {
+	result := r.db.Model(&models.UserToken{}).
+		Where("id = ? AND used_at IS NULL", token.ID).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	token.UsedAt = &usedAt
+	return result.RowsAffected == 1, nil
+}
+
+// InvalidateByUserID implements UserTokenRepository.
+// Membatalkan semua token user dengan purpose tersebut yang belum dipakai
+func (r *userTokenRepository) InvalidateByUserID(userID uint, purpose string, usedAt time.Time) error {
+	return r.db.Model(&models.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", usedAt).Error
+}
+
+// IncrementAttempts implements UserTokenRepository.
+func (r *userTokenRepository) IncrementAttempts(token *models.UserToken) error {
+	if err := r.db.Model(&models.UserToken{}).Where("id = ?", token.ID).
+		UpdateColumn("attempts", gorm.Expr("attempts + 1")).Error; err != nil {
+		return err
+	}
+	token.Attempts++
+	return nil
+}
+
+// DeleteExpired implements UserTokenRepository.
+func (r *userTokenRepository) DeleteExpired(now time.Time) error {
+	return r.db.Where("expires_at < ?", now).Delete(&models.UserToken{}).Error
+}
+
+func NewUserTokenRepository(db *gorm.DB) UserTokenRepository {
+	return &userTokenRepository{db: db}
+}
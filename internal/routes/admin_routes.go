@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"rest-api/config"
+	"rest-api/internal/controllers"
+	"rest-api/internal/middlewares"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupAdminRoutes mendaftarkan route administrasi akun
+// Hanya untuk login biasa (bukan personal access token) dengan role yang memiliki permission terkait
+func SetupAdminRoutes(app *fiber.App, cfg *config.Config, adminCtrl *controllers.AdminController) {
+	admin := app.Group("/api/admin")
+	admin.Get("/users", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeSession), middlewares.RequirePermission(middlewares.PermissionViewUsers), adminCtrl.ListUsers)
+	admin.Get("/users/:id", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeSession), middlewares.RequirePermission(middlewares.PermissionViewUsers), adminCtrl.GetUser)
+	admin.Get("/users/:id/task-counts", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeSession), middlewares.RequirePermission(middlewares.PermissionViewUsers), adminCtrl.GetTaskCounts)
+	admin.Put("/users/:id/role", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeSession), middlewares.RequirePermission(middlewares.PermissionManageUsers), adminCtrl.UpdateRole)
+	admin.Post("/users/:id/disable", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeSession), middlewares.RequirePermission(middlewares.PermissionManageUsers), adminCtrl.DisableUser)
+	admin.Post("/users/:id/enable", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeSession), middlewares.RequirePermission(middlewares.PermissionManageUsers), adminCtrl.EnableUser)
+	admin.Post("/users/:id/force-password-reset", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeSession), middlewares.RequirePermission(middlewares.PermissionManageUsers), adminCtrl.ForcePasswordReset)
+	admin.Delete("/users/:id", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeSession), middlewares.RequirePermission(middlewares.PermissionManageUsers), adminCtrl.DeleteUser)
+	admin.Get("/security-events", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeSession), middlewares.RequirePermission(middlewares.PermissionViewUsers), adminCtrl.ListSecurityEvents)
+}
@@ -3,6 +3,7 @@ package routes
 import (
 	"rest-api/config"
 	"rest-api/internal/controllers"
+	"rest-api/internal/middlewares"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -23,4 +24,41 @@ func SetupAuthRoutes(app *fiber.App, cfg *config.Config, authCtrl *controllers.A
 	// Response: { message, user }
 	// Note: User hanya bisa update profile sendiri
 	users.Post("/login/", authCtrl.Login)
+	// POST /api/auth/login/2fa
+	// Langkah kedua login untuk akun dengan 2FA aktif
+	// Request body: { challengeToken, code } (code = kode TOTP atau recovery code)
+	// Response: sama seperti login
+	users.Post("/login/2fa", authCtrl.LoginTwoFactor)
+	// POST /api/auth/oidc/start
+	// Mulai login lewat OpenID provider (OIDC_ISSUER); 404 jika tidak dikonfigurasi
+	// Response: { authorizationUrl }, state disimpan di cookie HTTP-only oidc_state
+	users.Post("/oidc/start", authCtrl.StartOIDCLogin)
+	// POST /api/auth/oidc/callback
+	// Dipanggil frontend dari halaman redirect URI dengan parameter dari provider
+	// Request body: { code, state }
+	// Response: sama seperti login (termasuk challenge 2FA)
+	users.Post("/oidc/callback", authCtrl.CompleteOIDCLogin)
+	// POST /api/auth/refresh
+	// Tukar refresh token (body { refreshToken } atau cookie refresh_token) dengan pasangan token baru
+	// Response: { message, token, expiresAt, refreshToken, refreshExpiresAt }
+	users.Post("/refresh", authCtrl.Refresh)
+	// POST /api/auth/logout
+	// Protected route, mencabut access token dan refresh token dari login yang sama
+	users.Post("/logout", middlewares.AuthAllowUnverified(cfg), middlewares.RequireScope(middlewares.ScopeSession), authCtrl.Logout)
+	// POST /api/auth/password/forgot
+	// Kirim link reset password ke email, response selalu sama baik email terdaftar atau tidak
+	// Request body: { email }
+	users.Post("/password/forgot", authCtrl.ForgotPassword)
+	// POST /api/auth/password/reset
+	// Ganti password memakai token dari email, token hanya berlaku sekali dan semua session diakhiri
+	// Request body: { token, password }
+	users.Post("/password/reset", authCtrl.ResetPassword)
+	// POST /api/auth/email/verify
+	// Verifikasi email memakai token dari email verifikasi (registrasi) atau konfirmasi email baru
+	// Request body: { token }
+	// Response: { message, user }
+	users.Post("/email/verify", authCtrl.VerifyEmail)
+	// POST /api/auth/email/resend
+	// Protected route, kirim ulang link verifikasi ke email saat ini (boleh untuk akun belum terverifikasi)
+	users.Post("/email/resend", middlewares.AuthAllowUnverified(cfg), middlewares.RequireScope(middlewares.ScopeSession), authCtrl.ResendVerification)
 }
@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"rest-api/config"
+	"rest-api/internal/controllers"
+	"rest-api/internal/middlewares"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupProjectRoutes(app *fiber.App, cfg *config.Config, projectCtrl *controllers.ProjectController, taskCtrl *controllers.TaskController) {
+	projects := app.Group("/api/projects")
+	projects.Get("/", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeProjectsRead), projectCtrl.GetProjects)
+	projects.Post("/", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeProjectsWrite), projectCtrl.CreateProject)
+	projects.Get("/trash", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeProjectsRead), projectCtrl.GetTrash)
+	projects.Post("/trash/:id/restore", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeProjectsWrite), projectCtrl.RestoreProject)
+	projects.Delete("/trash/:id", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeProjectsWrite), projectCtrl.PurgeProject)
+	projects.Get("/:id", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeProjectsRead), projectCtrl.GetProjectByID)
+	projects.Put("/:id", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeProjectsWrite), projectCtrl.UpdateProject)
+	projects.Delete("/:id", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeProjectsWrite), projectCtrl.DeleteProject)
+	projects.Post("/:id/archive", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeProjectsWrite), projectCtrl.ArchiveProject)
+	projects.Post("/:id/unarchive", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeProjectsWrite), projectCtrl.UnarchiveProject)
+	projects.Get("/:id/tasks", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeProjectsRead, middlewares.ScopeTasksRead), taskCtrl.GetTasksByProjectID)
+}
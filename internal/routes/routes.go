@@ -3,9 +3,14 @@
 package routes
 
 import (
+	"log"
 	"rest-api/config"
 	"rest-api/internal/controllers"
 	"rest-api/internal/database"
+	"rest-api/internal/mailer"
+	"rest-api/internal/middlewares"
+	"rest-api/internal/oidc"
+	"rest-api/internal/password"
 	"rest-api/internal/repositories"
 	"rest-api/internal/services"
 
@@ -20,17 +25,89 @@ import (
 //   - cfg: Configuration object yang berisi environment variables
 func SetupRoutes(app *fiber.App, cfg *config.Config) {
 	// Initialize User Repository, Service, dan Controller dengan dependency injection
+	// Mailer dipakai bersama untuk reset password dan verifikasi email
+	mail := mailer.New(cfg)
+	// Hasher dan kebijakan password dipakai bersama registrasi, login, reset dan ganti password
+	hasher := password.New(cfg)
+	passwordPolicy := password.NewPolicy(cfg)
+	userTokenRepo := repositories.NewUserTokenRepository(database.GetDB())
 	userRepo := repositories.NewUserRepository(database.GetDB())
-	userService := services.NewUserService(userRepo)
+	tokenRepo := repositories.NewTokenRepository(database.GetDB())
+	sessionRepo := repositories.NewSessionRepository(database.GetDB())
+	// Login gagal dan password saat ini yang salah (ganti password/email) dihitung bersama untuk lockout
+	loginAttemptRepo := repositories.NewLoginAttemptRepository(database.GetDB())
+	securityEventRepo := repositories.NewSecurityEventRepository(database.GetDB())
+	loginThrottle := services.NewLoginThrottle(loginAttemptRepo, securityEventRepo, cfg)
+	userService := services.NewUserService(userRepo, userTokenRepo, sessionRepo, tokenRepo, loginThrottle, hasher, passwordPolicy, mail, cfg)
 	userController := controllers.NewUserController(userService)
-	SetupUserRoutes(app, cfg, userController)
+	sessionService := services.NewSessionService(sessionRepo, tokenRepo)
+	sessionController := controllers.NewSessionController(sessionService)
+	twoFactorRepo := repositories.NewTwoFactorRepository(database.GetDB())
+	twoFactorService := services.NewTwoFactorService(userRepo, twoFactorRepo, hasher, cfg)
+	twoFactorController := controllers.NewTwoFactorController(twoFactorService)
+	personalAccessTokenRepo := repositories.NewPersonalAccessTokenRepository(database.GetDB())
+	personalAccessTokenService := services.NewPersonalAccessTokenService(personalAccessTokenRepo)
+	personalAccessTokenController := controllers.NewPersonalAccessTokenController(personalAccessTokenService)
+	SetupUserRoutes(app, cfg, userController, sessionController, twoFactorController, personalAccessTokenController)
+
+	// Login SSO lewat OpenID provider, nonaktif jika OIDC_ISSUER kosong
+	oidcRepo := repositories.NewOIDCRepository(database.GetDB())
+	oidcProvider := oidc.New(cfg)
+
 	authRepo := repositories.NewAuthRepository(database.GetDB())
-	authService := services.NewAuthService(authRepo, cfg)
+	authService := services.NewAuthService(authRepo, tokenRepo, sessionRepo, userTokenRepo, twoFactorRepo, loginThrottle, oidcRepo, oidcProvider, hasher, passwordPolicy, mail, cfg)
 	authController := controllers.NewAuthController(authService, cfg)
 	SetupAuthRoutes(app, cfg, authController)
 	// Initialize Task Repository, Service, dan Controller dengan dependency injection
 	taskRepo := repositories.NewTaskRepository(database.GetDB())
-	taskService := services.NewTaskService(taskRepo)
+	projectRepo := repositories.NewProjectRepository(database.GetDB())
+	tagRepo := repositories.NewTagRepository(database.GetDB())
+	taskSearcher := newTaskSearcher(cfg, taskRepo)
+	taskService := services.NewTaskService(taskRepo, projectRepo, tagRepo, taskSearcher, cfg)
 	taskController := controllers.NewTaskController(taskService)
-	SetupTaskRoutes(app, cfg, taskController)
+	checklistRepo := repositories.NewChecklistRepository(database.GetDB())
+	checklistService := services.NewChecklistService(checklistRepo, taskRepo)
+	checklistController := controllers.NewChecklistController(checklistService)
+	SetupTaskRoutes(app, cfg, taskController, checklistController)
+	// Initialize Project Service dan Controller, listing task per project memakai TaskController
+	projectService := services.NewProjectService(projectRepo)
+	projectController := controllers.NewProjectController(projectService)
+	SetupProjectRoutes(app, cfg, projectController, taskController)
+	// Initialize Tag Service dan Controller
+	tagService := services.NewTagService(tagRepo)
+	tagController := controllers.NewTagController(tagService)
+	SetupTagRoutes(app, cfg, tagController)
+	// Initialize Admin Repository, Service, dan Controller
+	adminRepo := repositories.NewAdminRepository(database.GetDB())
+	adminService := services.NewAdminService(adminRepo, sessionRepo, tokenRepo, userTokenRepo, personalAccessTokenRepo, securityEventRepo, taskSearcher, mail, cfg)
+	adminController := controllers.NewAdminController(adminService)
+	SetupAdminRoutes(app, cfg, adminController)
+	services.BootstrapAdmins(adminRepo, cfg)
+	// Public key access token untuk verifier lain, key yang sama dipakai middleware Auth
+	signingKeys := middlewares.SigningKeys(cfg)
+	jwksController := controllers.NewJWKSController(signingKeys)
+	SetupWellKnownRoutes(app, jwksController)
+	// Background job: hapus permanen isi trash yang melewati masa retensi
+	services.StartTrashPurger(taskRepo, projectRepo, taskSearcher, cfg)
+	// Background job: hapus refresh token, jti yang dicabut, token email dan session yang sudah tidak aktif
+	services.StartTokenCleanup(tokenRepo, sessionRepo, userTokenRepo, cfg)
+	// Background job: hapus catatan login gagal yang sudah kedaluwarsa
+	services.StartLoginAttemptCleanup(loginAttemptRepo, cfg)
+	// Background job: hapus state login OIDC yang tidak pernah diselesaikan
+	services.StartOIDCStateCleanup(oidcRepo)
+	// Background job: rotasi key signing JWT dan hapus key yang sudah expired
+	signingKeys.StartRotation()
+}
+
+// newTaskSearcher memilih backend pencarian task sesuai SEARCH_BACKEND
+// Backend memory dibangun ulang dari database setiap startup
+func newTaskSearcher(cfg *config.Config, taskRepo repositories.TaskRepository) repositories.TaskSearcher {
+	if cfg.SearchBackend != "memory" {
+		return repositories.NewMySQLTaskSearcher(database.GetDB())
+	}
+	searcher := repositories.NewMemoryTaskSearcher()
+	if err := services.BuildTaskSearchIndex(taskRepo, searcher); err != nil {
+		log.Printf("Warning: failed to build task search index: %v", err)
+	}
+	return searcher
 }
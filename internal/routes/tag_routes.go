@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"rest-api/config"
+	"rest-api/internal/controllers"
+	"rest-api/internal/middlewares"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupTagRoutes(app *fiber.App, cfg *config.Config, tagCtrl *controllers.TagController) {
+	tags := app.Group("/api/tags")
+	tags.Get("/", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeTagsRead), tagCtrl.GetTags)
+	tags.Post("/", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeTagsWrite), tagCtrl.CreateTag)
+	tags.Get("/:id", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeTagsRead), tagCtrl.GetTagByID)
+	tags.Put("/:id", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeTagsWrite), tagCtrl.UpdateTag)
+	tags.Delete("/:id", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeTagsWrite), tagCtrl.DeleteTag)
+	tags.Post("/:id/merge", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeTagsWrite), tagCtrl.MergeTag)
+}
@@ -8,11 +8,25 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func SetupTaskRoutes(app *fiber.App, cfg *config.Config, taskCtrl *controllers.TaskController) {
+func SetupTaskRoutes(app *fiber.App, cfg *config.Config, taskCtrl *controllers.TaskController, checklistCtrl *controllers.ChecklistController) {
 	tasks := app.Group("/api/tasks")
-	tasks.Get("/:id", middlewares.Auth(cfg), taskCtrl.GetTaskByID)
-	tasks.Get("/", middlewares.Auth(cfg), taskCtrl.GetTasksByUserID)
-	tasks.Post("/", middlewares.Auth(cfg), taskCtrl.CreateTask)
-	tasks.Put("/:id", middlewares.Auth(cfg), taskCtrl.UpdateTask)
-	tasks.Delete("/:id", middlewares.Auth(cfg), taskCtrl.DeleteTask)
+	// Setiap route mendeklarasikan scope yang dibutuhkan personal access token (login biasa memiliki semua scope)
+	// /search, /trash dan /bulk harus didaftarkan sebelum /:id
+	tasks.Post("/bulk", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeTasksWrite), taskCtrl.BulkTasks)
+	tasks.Get("/search", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeTasksRead), taskCtrl.SearchTasks)
+	tasks.Get("/trash", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeTasksRead), taskCtrl.GetTrash)
+	tasks.Delete("/trash", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeTasksWrite), taskCtrl.EmptyTrash)
+	tasks.Post("/trash/:id/restore", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeTasksWrite), taskCtrl.RestoreTask)
+	tasks.Delete("/trash/:id", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeTasksWrite), taskCtrl.PurgeTask)
+	tasks.Get("/:id", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeTasksRead), taskCtrl.GetTaskByID)
+	tasks.Get("/", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeTasksRead), taskCtrl.GetTasksByUserID)
+	tasks.Post("/", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeTasksWrite), taskCtrl.CreateTask)
+	tasks.Put("/:id", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeTasksWrite), taskCtrl.UpdateTask)
+	tasks.Put("/:id/move", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeTasksWrite), taskCtrl.MoveTask)
+	tasks.Get("/:id/subtasks", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeTasksRead), taskCtrl.GetSubtasks)
+	tasks.Get("/:id/series", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeTasksRead), taskCtrl.GetSeries)
+	tasks.Post("/:id/checklist", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeTasksWrite), checklistCtrl.AddItem)
+	tasks.Put("/:id/checklist/:itemId", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeTasksWrite), checklistCtrl.UpdateItem)
+	tasks.Delete("/:id/checklist/:itemId", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeTasksWrite), checklistCtrl.DeleteItem)
+	tasks.Delete("/:id", middlewares.Auth(cfg), middlewares.RequireScope(middlewares.ScopeTasksWrite), taskCtrl.DeleteTask)
 }
@@ -8,9 +8,23 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func SetupUserRoutes(app *fiber.App, cfg *config.Config, userCtrl *controllers.UserController) {
+func SetupUserRoutes(app *fiber.App, cfg *config.Config, userCtrl *controllers.UserController, sessionCtrl *controllers.SessionController, twoFactorCtrl *controllers.TwoFactorController, tokenCtrl *controllers.PersonalAccessTokenController) {
 	users := app.Group("/api/users")
-	users.Put("/:id", middlewares.Auth(cfg), userCtrl.UpdateUser)
-	users.Get("/", middlewares.Auth(cfg), userCtrl.GetProfile)
+	// Setiap route mendeklarasikan scope yang dibutuhkan personal access token (login biasa memiliki semua scope)
+	// Pengelolaan akun tetap bisa dipakai sebelum email diverifikasi (contoh: memperbaiki email yang salah ketik)
+	users.Put("/:id", middlewares.AuthAllowUnverified(cfg), middlewares.RequireScope(middlewares.ScopeUserWrite), userCtrl.UpdateUser)
+	users.Get("/", middlewares.AuthAllowUnverified(cfg), middlewares.RequireScope(middlewares.ScopeUserRead), userCtrl.GetProfile)
+	users.Get("/sessions", middlewares.AuthAllowUnverified(cfg), middlewares.RequireScope(middlewares.ScopeSession), sessionCtrl.GetSessions)
+	users.Delete("/sessions", middlewares.AuthAllowUnverified(cfg), middlewares.RequireScope(middlewares.ScopeSession), sessionCtrl.RevokeAllSessions)
+	users.Delete("/sessions/:id", middlewares.AuthAllowUnverified(cfg), middlewares.RequireScope(middlewares.ScopeSession), sessionCtrl.RevokeSession)
+	// Personal access token untuk script dan integrasi, hanya bisa dikelola dari login biasa
+	users.Get("/tokens", middlewares.AuthAllowUnverified(cfg), middlewares.RequireScope(middlewares.ScopeSession), tokenCtrl.GetTokens)
+	users.Post("/tokens", middlewares.AuthAllowUnverified(cfg), middlewares.RequireScope(middlewares.ScopeSession), tokenCtrl.CreateToken)
+	users.Delete("/tokens/:id", middlewares.AuthAllowUnverified(cfg), middlewares.RequireScope(middlewares.ScopeSession), tokenCtrl.DeleteToken)
+	// Two-factor authentication (TOTP): enroll -> confirm dengan kode -> recovery code ditampilkan sekali
+	users.Post("/2fa/enroll", middlewares.AuthAllowUnverified(cfg), middlewares.RequireScope(middlewares.ScopeSession), twoFactorCtrl.Enroll)
+	users.Post("/2fa/confirm", middlewares.AuthAllowUnverified(cfg), middlewares.RequireScope(middlewares.ScopeSession), twoFactorCtrl.Confirm)
+	users.Post("/2fa/disable", middlewares.AuthAllowUnverified(cfg), middlewares.RequireScope(middlewares.ScopeSession), twoFactorCtrl.Disable)
+	users.Post("/2fa/recovery-codes", middlewares.AuthAllowUnverified(cfg), middlewares.RequireScope(middlewares.ScopeSession), twoFactorCtrl.RegenerateRecoveryCodes)
 
 }
@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"rest-api/internal/controllers"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupWellKnownRoutes mendaftarkan endpoint publik /.well-known (tanpa autentikasi)
+func SetupWellKnownRoutes(app *fiber.App, jwksCtrl *controllers.JWKSController) {
+	wellKnown := app.Group("/.well-known")
+	wellKnown.Get("/jwks.json", jwksCtrl.GetJWKS)
+}
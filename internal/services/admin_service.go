@@ -0,0 +1,355 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"rest-api/config"
+	"rest-api/internal/dto/request"
+	"rest-api/internal/dto/response"
+	"rest-api/internal/mailer"
+	"rest-api/internal/middlewares"
+	"rest-api/internal/models"
+	"rest-api/internal/repositories"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	defaultAdminUserLimit = 20
+	maxAdminUserLimit     = 100
+)
+
+type AdminService interface {
+	ListUsers(query request.AdminUserListQuery) (*response.AdminUserListResponse, error)
+	GetUser(userID uint) (*response.AdminUserResponse, error)
+	GetTaskCounts(userID uint) (*response.AdminTaskCountsResponse, error)
+	UpdateRole(adminID, userID uint, role string) (*response.AdminUserResponse, error)
+	DisableUser(adminID, userID uint) (*response.AdminUserResponse, error)
+	EnableUser(adminID, userID uint) (*response.AdminUserResponse, error)
+	DeleteUser(adminID, userID uint) error
+	ForcePasswordReset(adminID, userID uint) error
+	ListSecurityEvents(query request.AdminSecurityEventListQuery) (*response.AdminSecurityEventListResponse, error)
+}
+
+type adminService struct {
+	adminRepo     repositories.AdminRepository
+	sessionRepo   repositories.SessionRepository
+	tokenRepo     repositories.TokenRepository
+	userTokenRepo repositories.UserTokenRepository
+	patRepo       repositories.PersonalAccessTokenRepository
+	eventRepo     repositories.SecurityEventRepository
+	searcher      repositories.TaskSearcher
+	mailer        mailer.Mailer
+	cfg           *config.Config
+}
+
+// ListUsers implements AdminService.
+func (s *adminService) ListUsers(query request.AdminUserListQuery) (*response.AdminUserListResponse, error) {
+	filter := repositories.AdminUserFilter{
+		Query:  strings.TrimSpace(query.Query),
+		Limit:  query.Limit,
+		Offset: query.Offset,
+	}
+	if query.Role != "" {
+		if !containsString(middlewares.Roles, query.Role) {
+			return nil, errors.New("invalid role")
+		}
+		filter.Role = query.Role
+	}
+	switch query.Status {
+	case "":
+	case "active":
+		disabled := false
+		filter.Disabled = &disabled
+	case "disabled":
+		disabled := true
+		filter.Disabled = &disabled
+	default:
+		return nil, errors.New("invalid status")
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultAdminUserLimit
+	}
+	if filter.Limit > maxAdminUserLimit {
+		filter.Limit = maxAdminUserLimit
+	}
+	if filter.Offset < 0 {
+		return nil, errors.New("invalid offset")
+	}
+
+	users, total, err := s.adminRepo.SearchUsers(filter)
+	if err != nil {
+		return nil, errors.New("failed to retrieve users")
+	}
+	userResponses := make([]response.AdminUserResponse, 0, len(users))
+	for i := range users {
+		userResponses = append(userResponses, toAdminUserResponse(&users[i]))
+	}
+	return &response.AdminUserListResponse{
+		Users:      userResponses,
+		TotalCount: total,
+		Limit:      filter.Limit,
+		Offset:     filter.Offset,
+	}, nil
+}
+
+// ListSecurityEvents implements AdminService.
+func (s *adminService) ListSecurityEvents(query request.AdminSecurityEventListQuery) (*response.AdminSecurityEventListResponse, error) {
+	filter := repositories.SecurityEventFilter{
+		Type:   query.Type,
+		UserID: query.UserID,
+		Limit:  query.Limit,
+		Offset: query.Offset,
+	}
+	switch filter.Type {
+	case "", models.SecurityEventAccountLocked, models.SecurityEventIPLocked:
+	default:
+		return nil, errors.New("invalid event type")
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultAdminUserLimit
+	}
+	if filter.Limit > maxAdminUserLimit {
+		filter.Limit = maxAdminUserLimit
+	}
+	if filter.Offset < 0 {
+		return nil, errors.New("invalid offset")
+	}
+
+	events, total, err := s.eventRepo.Find(filter)
+	if err != nil {
+		return nil, errors.New("failed to retrieve security events")
+	}
+	eventResponses := make([]response.SecurityEventResponse, 0, len(events))
+	for _, event := range events {
+		eventResponses = append(eventResponses, response.SecurityEventResponse{
+			ID:        event.ID,
+			Type:      event.Type,
+			UserID:    event.UserID,
+			IPAddress: event.IPAddress,
+			Detail:    event.Detail,
+			CreatedAt: event.CreatedAt,
+		})
+	}
+	return &response.AdminSecurityEventListResponse{
+		Events:     eventResponses,
+		TotalCount: total,
+		Limit:      filter.Limit,
+		Offset:     filter.Offset,
+	}, nil
+}
+
+// GetUser implements AdminService.
+func (s *adminService) GetUser(userID uint) (*response.AdminUserResponse, error) {
+	user, err := s.findUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	userResponse := toAdminUserResponse(user)
+	return &userResponse, nil
+}
+
+// GetTaskCounts implements AdminService.
+func (s *adminService) GetTaskCounts(userID uint) (*response.AdminTaskCountsResponse, error) {
+	if _, err := s.findUser(userID); err != nil {
+		return nil, err
+	}
+	counts, err := s.adminRepo.CountTasks(userID, time.Now().UTC())
+	if err != nil {
+		return nil, errors.New("failed to count tasks")
+	}
+	return &response.AdminTaskCountsResponse{
+		UserID:   userID,
+		Total:    counts.Total,
+		ByStatus: counts.ByStatus,
+		Overdue:  counts.Overdue,
+		Trashed:  counts.Trashed,
+	}, nil
+}
+
+// UpdateRole implements AdminService.
+func (s *adminService) UpdateRole(adminID uint, userID uint, role string) (*response.AdminUserResponse, error) {
+	if !containsString(middlewares.Roles, role) {
+		return nil, errors.New("invalid role")
+	}
+	user, err := s.findManagedUser(adminID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.adminRepo.SetRole(user.ID, role); err != nil {
+		return nil, errors.New("failed to update role")
+	}
+	user.Role = role
+	userResponse := toAdminUserResponse(user)
+	return &userResponse, nil
+}
+
+// DisableUser implements AdminService.
+// Semua session dan token user langsung dicabut
+func (s *adminService) DisableUser(adminID uint, userID uint) (*response.AdminUserResponse, error) {
+	user, err := s.findManagedUser(adminID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.DisabledAt != nil {
+		return nil, errors.New("user is already disabled")
+	}
+	now := time.Now().UTC()
+	if err := s.adminRepo.SetDisabledAt(user.ID, &now); err != nil {
+		return nil, errors.New("failed to disable user")
+	}
+	user.DisabledAt = &now
+	s.revokeCredentials(user.ID, now)
+
+	userResponse := toAdminUserResponse(user)
+	return &userResponse, nil
+}
+
+// EnableUser implements AdminService.
+func (s *adminService) EnableUser(adminID uint, userID uint) (*response.AdminUserResponse, error) {
+	user, err := s.findManagedUser(adminID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.DisabledAt == nil {
+		return nil, errors.New("user is not disabled")
+	}
+	if err := s.adminRepo.SetDisabledAt(user.ID, nil); err != nil {
+		return nil, errors.New("failed to enable user")
+	}
+	user.DisabledAt = nil
+
+	userResponse := toAdminUserResponse(user)
+	return &userResponse, nil
+}
+
+// DeleteUser implements AdminService.
+// Menghapus permanen akun beserta semua data miliknya
+func (s *adminService) DeleteUser(adminID uint, userID uint) error {
+	user, err := s.findManagedUser(adminID, userID)
+	if err != nil {
+		return err
+	}
+	taskIDs, err := s.adminRepo.DeleteUser(user.ID)
+	if err != nil {
+		return errors.New("failed to delete user")
+	}
+	if len(taskIDs) > 0 {
+		if err := s.searcher.Remove(taskIDs...); err != nil {
+			log.Printf("Warning: failed to remove tasks %v from search index: %v", taskIDs, err)
+		}
+	}
+	return nil
+}
+
+// ForcePasswordReset implements AdminService.
+// Login ditolak sampai user mengganti password lewat link yang dikirim ke email-nya
+func (s *adminService) ForcePasswordReset(adminID uint, userID uint) error {
+	user, err := s.findManagedUser(adminID, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.adminRepo.RequirePasswordReset(user.ID); err != nil {
+		return errors.New("failed to force password reset")
+	}
+	s.revokeCredentials(user.ID, time.Now().UTC())
+	if err := sendPasswordResetEmail(s.userTokenRepo, s.mailer, s.cfg, user, "An administrator requires you to choose a new password."); err != nil {
+		return errors.New("failed to send password reset email")
+	}
+	return nil
+}
+
+// revokeCredentials mengakhiri semua session, refresh token dan personal access token user
+func (s *adminService) revokeCredentials(userID uint, now time.Time) {
+	if err := s.sessionRepo.RevokeAllByUserID(userID, now); err != nil {
+		log.Printf("Warning: failed to revoke sessions for user %d: %v", userID, err)
+	}
+	if err := s.tokenRepo.RevokeAllByUserID(userID, now); err != nil {
+		log.Printf("Warning: failed to revoke tokens for user %d: %v", userID, err)
+	}
+	if err := s.patRepo.DeleteByUserID(userID); err != nil {
+		log.Printf("Warning: failed to delete access tokens for user %d: %v", userID, err)
+	}
+}
+
+func (s *adminService) findUser(userID uint) (*models.User, error) {
+	user, err := s.adminRepo.FindUserByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("user not found")
+		}
+		return nil, errors.New("failed to retrieve user")
+	}
+	return user, nil
+}
+
+// findManagedUser mencari user yang akan diubah admin; admin tidak bisa mengubah akunnya sendiri
+// agar tidak terkunci dari panel admin
+func (s *adminService) findManagedUser(adminID uint, userID uint) (*models.User, error) {
+	if adminID == userID {
+		return nil, errors.New("cannot modify your own account")
+	}
+	return s.findUser(userID)
+}
+
+func toAdminUserResponse(user *models.User) response.AdminUserResponse {
+	return response.AdminUserResponse{
+		UserResponse:          *toUserResponse(user),
+		Disabled:              user.DisabledAt != nil,
+		DisabledAt:            user.DisabledAt,
+		PasswordResetRequired: user.PasswordResetRequired,
+	}
+}
+
+// isAdminEmail mengecek apakah email ada di ADMIN_EMAILS (tidak case-sensitive)
+func isAdminEmail(cfg *config.Config, email string) bool {
+	for _, adminEmail := range adminEmails(cfg) {
+		if strings.EqualFold(adminEmail, email) {
+			return true
+		}
+	}
+	return false
+}
+
+func adminEmails(cfg *config.Config) []string {
+	var emails []string
+	for _, email := range strings.Split(cfg.AdminEmails, ",") {
+		if email = strings.TrimSpace(email); email != "" {
+			emails = append(emails, email)
+		}
+	}
+	return emails
+}
+
+// BootstrapAdmins memberi role admin ke user yang email-nya ada di ADMIN_EMAILS
+// Dipanggil saat startup agar admin pertama bisa dibuat tanpa akses database langsung
+func BootstrapAdmins(adminRepo repositories.AdminRepository, cfg *config.Config) {
+	emails := adminEmails(cfg)
+	if len(emails) == 0 {
+		return
+	}
+	promoted, err := adminRepo.PromoteAdmins(emails)
+	if err != nil {
+		log.Printf("Warning: failed to promote admins: %v", err)
+		return
+	}
+	if promoted > 0 {
+		log.Printf("👑 %d user(s) promoted to admin from ADMIN_EMAILS", promoted)
+	}
+}
+
+func NewAdminService(adminRepo repositories.AdminRepository, sessionRepo repositories.SessionRepository, tokenRepo repositories.TokenRepository, userTokenRepo repositories.UserTokenRepository, patRepo repositories.PersonalAccessTokenRepository, eventRepo repositories.SecurityEventRepository, searcher repositories.TaskSearcher, mailer mailer.Mailer, cfg *config.Config) AdminService {
+	return &adminService{
+		adminRepo:     adminRepo,
+		sessionRepo:   sessionRepo,
+		tokenRepo:     tokenRepo,
+		userTokenRepo: userTokenRepo,
+		patRepo:       patRepo,
+		eventRepo:     eventRepo,
+		searcher:      searcher,
+		mailer:        mailer,
+		cfg:           cfg,
+	}
+}
@@ -0,0 +1,276 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"rest-api/internal/middlewares"
+	"rest-api/internal/models"
+	"rest-api/internal/oidc"
+	"rest-api/internal/repositories"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// OIDCStateTTL adalah batas waktu dari redirect ke provider sampai callback
+	OIDCStateTTL              = 10 * time.Minute
+	oidcStateCleanupInterval  = time.Hour
+	maxProvisionUsernameTries = 5
+	maxUsernameLength         = 30
+)
+
+// OIDCAuthorization adalah hasil StartOIDCLogin
+// State juga disimpan controller di cookie agar callback hanya diterima dari browser yang memulai login
+type OIDCAuthorization struct {
+	AuthorizationURL string
+	State            string
+	ExpiresAt        time.Time
+}
+
+// StartOIDCLogin implements AuthService.
+// Membuat state, nonce, dan PKCE code verifier lalu mengembalikan URL authorization endpoint provider
+func (a *authService) StartOIDCLogin() (*OIDCAuthorization, error) {
+	if !a.oidcProvider.Enabled() {
+		return nil, errors.New("oidc login is not configured")
+	}
+	state, err := newRandomToken()
+	if err != nil {
+		return nil, errors.New("failed to start oidc login")
+	}
+	nonce, err := newRandomToken()
+	if err != nil {
+		return nil, errors.New("failed to start oidc login")
+	}
+	verifier, err := newRandomToken()
+	if err != nil {
+		return nil, errors.New("failed to start oidc login")
+	}
+
+	authorizationURL, err := a.oidcProvider.AuthCodeURL(state, nonce, verifier)
+	if err != nil {
+		log.Printf("Warning: oidc provider unavailable: %v", err)
+		return nil, errors.New("oidc provider unavailable")
+	}
+	expiresAt := time.Now().UTC().Add(OIDCStateTTL)
+	if err := a.oidcRepo.CreateState(&models.OIDCState{
+		StateHash:    hashToken(state),
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		ExpiresAt:    expiresAt,
+	}); err != nil {
+		return nil, errors.New("failed to start oidc login")
+	}
+	return &OIDCAuthorization{AuthorizationURL: authorizationURL, State: state, ExpiresAt: expiresAt}, nil
+}
+
+// CompleteOIDCLogin implements AuthService.
+// Menukar code dari callback, memvalidasi ID token, lalu login sebagai user yang terhubung dengan identity tersebut
+// browserState adalah state dari cookie browser; harus sama dengan state di callback
+func (a *authService) CompleteOIDCLogin(code, state, browserState string, client ClientInfo) (*LoginResult, error) {
+	if !a.oidcProvider.Enabled() {
+		return nil, errors.New("oidc login is not configured")
+	}
+	if code == "" || state == "" {
+		return nil, errors.New("code and state are required")
+	}
+	if subtle.ConstantTimeCompare([]byte(state), []byte(browserState)) != 1 {
+		return nil, errors.New("invalid or expired oidc state")
+	}
+	stored, err := a.oidcRepo.ConsumeState(hashToken(state))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("invalid or expired oidc state")
+		}
+		return nil, errors.New("failed to complete oidc login")
+	}
+	if time.Now().UTC().After(stored.ExpiresAt) {
+		return nil, errors.New("invalid or expired oidc state")
+	}
+
+	tokens, err := a.oidcProvider.Exchange(code, stored.CodeVerifier)
+	if err != nil {
+		log.Printf("Warning: oidc code exchange failed: %v", err)
+		return nil, errors.New("failed to verify identity")
+	}
+	claims, err := a.oidcProvider.VerifyIDToken(tokens.IDToken, stored.Nonce)
+	if err != nil {
+		log.Printf("Warning: oidc id token rejected: %v", err)
+		return nil, errors.New("failed to verify identity")
+	}
+
+	user, err := a.resolveOIDCUser(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.DisabledAt != nil {
+		return nil, errors.New("account disabled")
+	}
+	if user.PasswordResetRequired {
+		return nil, errors.New("password reset required")
+	}
+	if user.EmailVerifiedAt == nil && !middlewares.UnverifiedAllowed(a.cfg, middlewares.ActionLogin) {
+		return nil, errors.New("email not verified")
+	}
+	// Login lewat provider tidak melewati 2FA akun ini
+	if user.TwoFactorEnabledAt != nil {
+		challenge, err := a.startLoginChallenge(user)
+		if err != nil {
+			return nil, errors.New("failed to start two-factor login")
+		}
+		return &LoginResult{Challenge: challenge}, nil
+	}
+	return a.completeLogin(user, client)
+}
+
+// resolveOIDCUser mencari user untuk identity di ID token:
+//  1. identity (issuer + sub) yang sudah terhubung
+//  2. user dengan email sama, hanya jika email terverifikasi di provider dan di aplikasi; identity lalu dihubungkan
+//  3. akun baru (just-in-time provisioning) jika OIDC_AUTO_PROVISION aktif
+func (a *authService) resolveOIDCUser(claims *oidc.IDTokenClaims) (*models.User, error) {
+	issuer := a.cfg.OIDCIssuer
+	now := time.Now().UTC()
+	email := strings.TrimSpace(claims.Email)
+
+	identity, err := a.oidcRepo.FindIdentity(issuer, claims.Subject)
+	if err == nil {
+		user, err := a.authRepo.FindByID(identity.UserID)
+		if err != nil {
+			return nil, errors.New("failed to retrieve user")
+		}
+		if err := a.oidcRepo.TouchIdentity(identity.ID, email, now); err != nil {
+			log.Printf("Warning: failed to update oidc identity %d: %v", identity.ID, err)
+		}
+		return user, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("failed to retrieve user")
+	}
+
+	// Email yang belum diverifikasi provider tidak dipercaya untuk menghubungkan atau membuat akun
+	if email == "" || !bool(claims.EmailVerified) {
+		return nil, errors.New("verified email is required")
+	}
+	newIdentity := &models.UserIdentity{
+		Issuer:      issuer,
+		Subject:     claims.Subject,
+		Email:       email,
+		LastLoginAt: &now,
+	}
+
+	user, err := a.authRepo.FindByEmail(email)
+	if err == nil {
+		// Pemilik email di aplikasi belum terbukti; menghubungkan bisa menyerahkan akun ke orang yang mendaftar lebih dulu
+		if user.EmailVerifiedAt == nil {
+			return nil, errors.New("email already registered but not verified")
+		}
+		newIdentity.UserID = user.ID
+		if err := a.oidcRepo.CreateIdentity(newIdentity); err != nil {
+			return nil, errors.New("failed to link identity")
+		}
+		log.Printf("Security: linked oidc identity %s to user %d", claims.Subject, user.ID)
+		return user, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("failed to retrieve user")
+	}
+
+	if !a.oidcAutoProvision {
+		return nil, errors.New("account not provisioned")
+	}
+	return a.provisionOIDCUser(claims, email, newIdentity, now)
+}
+
+// provisionOIDCUser membuat akun baru untuk identity yang belum terhubung
+// Password diisi acak; user bisa memakai lupa password untuk login tanpa provider
+func (a *authService) provisionOIDCUser(claims *oidc.IDTokenClaims, email string, identity *models.UserIdentity, now time.Time) (*models.User, error) {
+	password, err := newRandomToken()
+	if err != nil {
+		return nil, errors.New("failed to create account")
+	}
+	hashedPassword, err := a.hasher.Hash(password)
+	if err != nil {
+		return nil, errors.New("failed to create account")
+	}
+	username, err := a.availableUsername(claims, email)
+	if err != nil {
+		return nil, errors.New("failed to create account")
+	}
+
+	user := &models.User{
+		Username:        username,
+		Email:           email,
+		Password:        hashedPassword,
+		EmailVerifiedAt: &now,
+		Role:            models.RoleUser,
+	}
+	// Provisioning hanya untuk email terverifikasi, dicek ulang karena menentukan role admin
+	if bool(claims.EmailVerified) && isAdminEmail(a.cfg, email) {
+		user.Role = models.RoleAdmin
+	}
+	if err := a.oidcRepo.CreateUserWithIdentity(user, identity); err != nil {
+		return nil, errors.New("failed to create account")
+	}
+	log.Printf("Security: provisioned user %d from oidc identity %s", user.ID, claims.Subject)
+	return user, nil
+}
+
+// availableUsername membuat username dari preferred_username atau bagian lokal email,
+// ditambah angka acak jika sudah dipakai
+func (a *authService) availableUsername(claims *oidc.IDTokenClaims, email string) (string, error) {
+	base := sanitizeUsername(claims.PreferredUsername)
+	if base == "" {
+		base = sanitizeUsername(strings.SplitN(email, "@", 2)[0])
+	}
+	if len(base) < 3 {
+		base = "user" + base
+	}
+
+	candidate := base
+	for i := 0; i < maxProvisionUsernameTries; i++ {
+		_, err := a.authRepo.FindByUsername(candidate)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return candidate, nil
+		}
+		if err != nil {
+			return "", err
+		}
+		suffix, err := rand.Int(rand.Reader, big.NewInt(10000))
+		if err != nil {
+			return "", err
+		}
+		candidate = fmt.Sprintf("%s%04d", truncate(base, maxUsernameLength-4), suffix.Int64())
+	}
+	return "", errors.New("no available username")
+}
+
+// sanitizeUsername hanya menyisakan huruf kecil, angka, titik, strip, dan underscore
+func sanitizeUsername(value string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(value) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '.' || r == '-' || r == '_' {
+			b.WriteRune(r)
+		}
+	}
+	return truncate(b.String(), maxUsernameLength)
+}
+
+// StartOIDCStateCleanup menjalankan goroutine yang secara berkala menghapus state login OIDC yang tidak pernah diselesaikan
+func StartOIDCStateCleanup(oidcRepo repositories.OIDCRepository) {
+	go func() {
+		ticker := time.NewTicker(oidcStateCleanupInterval)
+		defer ticker.Stop()
+		for {
+			if err := oidcRepo.DeleteExpiredStates(time.Now().UTC()); err != nil {
+				log.Printf("Warning: failed to delete expired oidc states: %v", err)
+			}
+			<-ticker.C
+		}
+	}()
+}
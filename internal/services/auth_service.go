@@ -2,65 +2,143 @@ package services
 
 import (
 	"errors"
+	"log"
 	"rest-api/config"
 	"rest-api/internal/dto/response"
+	"rest-api/internal/mailer"
 	"rest-api/internal/middlewares"
 	"rest-api/internal/models"
+	"rest-api/internal/oidc"
+	"rest-api/internal/password"
 	"rest-api/internal/repositories"
-	"time"
 
-	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
 type AuthService interface {
 	Register(username, email, password string) (*response.UserResponse, error)
-	Login(email, password string) (string, *response.UserResponse, error)
-	GenerateToken(userID uint) (string, error)
+	Login(email, password string, client ClientInfo) (*LoginResult, error)
+	LoginTwoFactor(challengeToken, code string, client ClientInfo) (*LoginResult, error)
+	StartOIDCLogin() (*OIDCAuthorization, error)
+	CompleteOIDCLogin(code, state, browserState string, client ClientInfo) (*LoginResult, error)
+	Refresh(refreshToken string) (*response.TokenResponse, error)
+	Logout(claims *middlewares.Claims) error
+	RequestPasswordReset(email string) error
+	ResetPassword(token, newPassword string) error
+	VerifyEmail(token string) (*response.UserResponse, error)
+	ResendVerification(userID uint) error
 }
 
-type authService struct {
-	authRepo repositories.AuthRepository
-	cfg      *config.Config
+// LoginResult adalah hasil login: token (login selesai) atau challenge 2FA (perlu langkah kedua)
+type LoginResult struct {
+	Tokens    *response.TokenResponse
+	User      *response.UserResponse
+	Challenge *response.TwoFactorChallengeResponse
 }
 
-// GenerateToken implements AuthService.
-func (a *authService) GenerateToken(userID uint) (string, error) {
-	return middlewares.GenerateToken(userID, a.cfg)
+type authService struct {
+	authRepo      repositories.AuthRepository
+	tokenRepo     repositories.TokenRepository
+	sessionRepo   repositories.SessionRepository
+	userTokenRepo repositories.UserTokenRepository
+	twoFactorRepo repositories.TwoFactorRepository
+	throttle      LoginThrottle
+	oidcRepo      repositories.OIDCRepository
+	oidcProvider  *oidc.Provider
+	hasher        password.Hasher
+	policy        *password.Policy
+	mailer        mailer.Mailer
+	cfg           *config.Config
+
+	oidcAutoProvision bool
+	// dummyHash dipakai untuk memverifikasi password saat email tidak terdaftar, dibuat dengan hasher yang sama
+	dummyHash string
 }
 
-
 // Login implements AuthService.
-func (a *authService) Login(email string, password string) (string, *response.UserResponse, error) {
+// Setiap login dicatat sebagai session baru (user agent, IP)
+// Akun dengan 2FA aktif mendapat challenge; token baru diterbitkan setelah LoginTwoFactor
+// Email tidak terdaftar dan password salah menghasilkan error yang sama; login gagal berulang dikenai backoff dan lockout
+func (a *authService) Login(email string, password string, client ClientInfo) (*LoginResult, error) {
 	if email == "" || password == "" {
-		return "", nil, errors.New("email and password are required")
+		return nil, errors.New("email and password are required")
+	}
+	if err := a.throttle.Check(email, client.IPAddress); err != nil {
+		return nil, err
 	}
 
 	user, err := a.authRepo.FindByEmail(email)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return "", nil, errors.New("email not found")
+		if err != gorm.ErrRecordNotFound {
+			return nil, errors.New("failed to retrieve user")
 		}
-		return "", nil, errors.New("failed to retrieve user")
+		// Tetap jalankan hash agar waktu response tidak membedakan email terdaftar
+		a.hasher.Verify(a.dummyHash, password)
+		a.throttle.RecordFailure(email, client.IPAddress, nil)
+		return nil, errors.New("invalid email or password")
 	}
 
-	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password));  err != nil {
-		return "", nil, errors.New("incorrect password")
+	ok, err := a.hasher.Verify(user.Password, password)
+	if err != nil {
+		log.Printf("Warning: failed to verify password hash for user %d: %v", user.ID, err)
+	}
+	if !ok {
+		a.throttle.RecordFailure(email, client.IPAddress, &user.ID)
+		return nil, errors.New("invalid email or password")
+	}
+	// Hitungan gagal baru di-reset di completeLogin, setelah langkah 2FA (jika ada) juga lolos
+	a.rehashPassword(user, password)
+
+	if user.DisabledAt != nil {
+		return nil, errors.New("account disabled")
+	}
+	if user.PasswordResetRequired {
+		return nil, errors.New("password reset required")
+	}
+	if user.EmailVerifiedAt == nil && !middlewares.UnverifiedAllowed(a.cfg, middlewares.ActionLogin) {
+		return nil, errors.New("email not verified")
+	}
+
+	if user.TwoFactorEnabledAt != nil {
+		challenge, err := a.startLoginChallenge(user)
+		if err != nil {
+			return nil, errors.New("failed to start two-factor login")
+		}
+		return &LoginResult{Challenge: challenge}, nil
 	}
+	return a.completeLogin(user, client)
+}
 
-	token, err := a.GenerateToken(user.ID)
+// rehashPassword mengganti hash dengan algoritma dan parameter terbaru setelah password terbukti benar
+// Gagal menyimpan tidak membatalkan login; hash akan dicoba di-upgrade lagi pada login berikutnya
+func (a *authService) rehashPassword(user *models.User, plain string) {
+	if !a.hasher.NeedsRehash(user.Password) {
+		return
+	}
+	hashed, err := a.hasher.Hash(plain)
 	if err != nil {
-		return "", nil, errors.New("failed to generate token")
+		log.Printf("Warning: failed to rehash password for user %d: %v", user.ID, err)
+		return
 	}
+	if err := a.authRepo.UpdatePasswordHash(user.ID, hashed); err != nil {
+		log.Printf("Warning: failed to store rehashed password for user %d: %v", user.ID, err)
+		return
+	}
+	user.Password = hashed
+}
 
-	userResponse := &response.UserResponse{
-		ID:        user.ID,
-		Username:  user.Username,
-		Email:     user.Email,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+// completeLogin memulai session dan family refresh token baru untuk user yang sudah terautentikasi
+func (a *authService) completeLogin(user *models.User, client ClientInfo) (*LoginResult, error) {
+	a.throttle.RecordSuccess(user.Email)
+	familyID := middlewares.NewTokenID()
+	if err := a.startSession(user.ID, familyID, client); err != nil {
+		return nil, errors.New("failed to create session")
 	}
-	return token, userResponse, nil
+	tokens, err := a.issueTokens(a.tokenRepo, user.ID, familyID)
+	if err != nil {
+		return nil, errors.New("failed to generate token")
+	}
+	return &LoginResult{Tokens: tokens, User: toUserResponse(user)}, nil
 }
 
 
@@ -69,6 +147,9 @@ func (a *authService) Register(username string, email string, password string)(*
 	if username == "" || email == "" || password == "" {
 		return nil, errors.New("all fields are required")
 	}
+	if err := a.policy.Validate(password, username, email); err != nil {
+		return nil, err
+	}
 
 	existingUser,err := a.authRepo.FindEmailOrUsername(email, username)
 	if err == nil && existingUser != nil {
@@ -77,7 +158,7 @@ func (a *authService) Register(username string, email string, password string)(*
 		return nil, errors.New("failed to check existing users")
 	}
 
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), 10)
+	hashedPassword, err := a.hasher.Hash(password)
 	if err != nil {
 		return nil, errors.New("failed to hash password")
 	}
@@ -85,29 +166,29 @@ func (a *authService) Register(username string, email string, password string)(*
 	user := &models.User{
 		Username: username,
 		Email:    email,
-		Password: string(hashedPassword),
+		Password: hashedPassword,
+		Role:     models.RoleUser,
 	}
+	// Role admin dari ADMIN_EMAILS baru diberikan setelah email terverifikasi (VerifyEmail)
 
 	if err := a.authRepo.Register(user); err != nil {
 		return nil, errors.New("failed to register user")
 	}
-
-	userResponse := &response.UserResponse{
-		ID:        user.ID,
-		Username:  user.Username,
-		Email:     user.Email,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+	// Gagal kirim tidak membatalkan registrasi, user bisa minta kirim ulang
+	if err := a.sendVerificationEmail(user); err != nil {
+		log.Printf("Warning: failed to send verification email to user %d: %v", user.ID, err)
 	}
+
+	userResponse := toUserResponse(user)
 	return userResponse, nil
 }
 
 
 
-func NewAuthService(authRepo repositories.AuthRepository, cfg *config.Config) AuthService {
-	return &authService{authRepo: authRepo, cfg: cfg}
-}
-
-func (s *authService) GetTokenExpiration() time.Duration {
-	return 7 * 24 * time.Hour
+func NewAuthService(authRepo repositories.AuthRepository, tokenRepo repositories.TokenRepository, sessionRepo repositories.SessionRepository, userTokenRepo repositories.UserTokenRepository, twoFactorRepo repositories.TwoFactorRepository, throttle LoginThrottle, oidcRepo repositories.OIDCRepository, oidcProvider *oidc.Provider, hasher password.Hasher, policy *password.Policy, mailer mailer.Mailer, cfg *config.Config) AuthService {
+	dummyHash, err := hasher.Hash("dummy-password")
+	if err != nil {
+		log.Printf("Warning: failed to create dummy password hash: %v", err)
+	}
+	return &authService{authRepo: authRepo, tokenRepo: tokenRepo, sessionRepo: sessionRepo, userTokenRepo: userTokenRepo, twoFactorRepo: twoFactorRepo, throttle: throttle, oidcRepo: oidcRepo, oidcProvider: oidcProvider, hasher: hasher, policy: policy, mailer: mailer, cfg: cfg, oidcAutoProvision: cfg.OIDCAutoProvision != "false", dummyHash: dummyHash}
 }
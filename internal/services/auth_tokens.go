@@ -0,0 +1,201 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"log"
+	"rest-api/config"
+	"rest-api/internal/dto/response"
+	"rest-api/internal/middlewares"
+	"rest-api/internal/models"
+	"rest-api/internal/repositories"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	defaultRefreshTokenTTL = 30 * 24 * time.Hour
+	tokenCleanupInterval   = time.Hour
+)
+
+// Refresh implements AuthService.
+// Refresh token hanya bisa dipakai sekali dan ditukar dengan pasangan token baru di family yang sama
+// Jika token yang sudah dirotasi dipakai lagi (kemungkinan bocor), seluruh family dicabut
+func (a *authService) Refresh(refreshToken string) (*response.TokenResponse, error) {
+	if refreshToken == "" {
+		return nil, errors.New("refresh token is required")
+	}
+	stored, err := a.tokenRepo.FindRefreshTokenByHash(hashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("invalid refresh token")
+		}
+		return nil, errors.New("failed to refresh token")
+	}
+	if stored.RevokedAt != nil {
+		return nil, errors.New("invalid refresh token")
+	}
+	now := time.Now().UTC()
+	if stored.UsedAt != nil {
+		return nil, a.revokeReusedFamily(stored, now)
+	}
+	if now.After(stored.ExpiresAt) {
+		return nil, errors.New("refresh token expired")
+	}
+	if _, err := a.authRepo.FindByID(stored.UserID); err != nil {
+		return nil, errors.New("invalid refresh token")
+	}
+	session, err := a.sessionRepo.FindActiveByFamilyID(stored.FamilyID)
+	if err != nil {
+		return nil, errors.New("invalid refresh token")
+	}
+
+	var tokens *response.TokenResponse
+	reused := false
+	err = a.tokenRepo.Transaction(func(txRepo repositories.TokenRepository) error {
+		marked, err := txRepo.MarkRefreshTokenUsed(stored, now)
+		if err != nil {
+			return err
+		}
+		// Request lain sudah memakai token ini lebih dulu
+		if !marked {
+			reused = true
+			return nil
+		}
+		tokens, err = a.issueTokens(txRepo, stored.UserID, stored.FamilyID)
+		return err
+	})
+	if err != nil {
+		return nil, errors.New("failed to refresh token")
+	}
+	if reused {
+		return nil, a.revokeReusedFamily(stored, now)
+	}
+	if err := a.sessionRepo.Touch(session, now); err != nil {
+		log.Printf("Warning: failed to update session %d: %v", session.ID, err)
+	}
+	return tokens, nil
+}
+
+// Logout implements AuthService.
+// Mengakhiri session dan mencabut family refresh token milik access token yang dipakai,
+// termasuk access token itu sendiri
+func (a *authService) Logout(claims *middlewares.Claims) error {
+	now := time.Now().UTC()
+	if err := a.sessionRepo.RevokeByFamilyID(claims.FamilyID, now); err != nil {
+		return errors.New("failed to logout")
+	}
+	if err := a.tokenRepo.RevokeFamily(claims.FamilyID, now); err != nil {
+		return errors.New("failed to logout")
+	}
+	return nil
+}
+
+// startSession mencatat login baru sebagai session
+func (a *authService) startSession(userID uint, familyID string, client ClientInfo) error {
+	userAgent := client.UserAgent
+	if len(userAgent) > 255 {
+		userAgent = userAgent[:255]
+	}
+	return a.sessionRepo.Create(&models.Session{
+		UserID:     userID,
+		FamilyID:   familyID,
+		UserAgent:  userAgent,
+		IPAddress:  client.IPAddress,
+		LastSeenAt: time.Now().UTC(),
+	})
+}
+
+// revokeReusedFamily mencabut seluruh family saat refresh token yang sudah dirotasi dipakai lagi
+func (a *authService) revokeReusedFamily(stored *models.RefreshToken, now time.Time) error {
+	log.Printf("⚠️ Refresh token reuse detected for user %d (family %s), revoking family", stored.UserID, stored.FamilyID)
+	if err := a.sessionRepo.RevokeByFamilyID(stored.FamilyID, now); err != nil {
+		return errors.New("failed to refresh token")
+	}
+	if err := a.tokenRepo.RevokeFamily(stored.FamilyID, now); err != nil {
+		return errors.New("failed to refresh token")
+	}
+	return errors.New("refresh token reuse detected")
+}
+
+// issueTokens membuat access token baru dan refresh token pasangannya di family yang diberikan
+func (a *authService) issueTokens(tokenRepo repositories.TokenRepository, userID uint, familyID string) (*response.TokenResponse, error) {
+	accessToken, claims, err := middlewares.GenerateToken(userID, familyID, a.cfg)
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, err := newRandomToken()
+	if err != nil {
+		return nil, err
+	}
+
+	refreshTTL, err := time.ParseDuration(a.cfg.JWTRefreshExpires)
+	if err != nil || refreshTTL <= 0 {
+		refreshTTL = defaultRefreshTokenTTL
+	}
+	stored := &models.RefreshToken{
+		UserID:          userID,
+		FamilyID:        familyID,
+		TokenHash:       hashToken(refreshToken),
+		AccessJTI:       claims.RegisteredClaims.ID,
+		AccessExpiresAt: claims.ExpiresAt.Time.UTC(),
+		ExpiresAt:       time.Now().UTC().Add(refreshTTL),
+	}
+	if err := tokenRepo.CreateRefreshToken(stored); err != nil {
+		return nil, err
+	}
+
+	return &response.TokenResponse{
+		Token:            accessToken,
+		ExpiresAt:        stored.AccessExpiresAt,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: stored.ExpiresAt,
+		CSRFToken:        middlewares.CSRFToken(a.cfg, familyID),
+	}, nil
+}
+
+// StartTokenCleanup menjalankan goroutine yang secara berkala menghapus
+// refresh token, catatan jti dan token email yang sudah expired, serta session yang sudah tidak bisa dipakai
+func StartTokenCleanup(tokenRepo repositories.TokenRepository, sessionRepo repositories.SessionRepository, userTokenRepo repositories.UserTokenRepository, cfg *config.Config) {
+	refreshTTL, err := time.ParseDuration(cfg.JWTRefreshExpires)
+	if err != nil || refreshTTL <= 0 {
+		refreshTTL = defaultRefreshTokenTTL
+	}
+	go func() {
+		ticker := time.NewTicker(tokenCleanupInterval)
+		defer ticker.Stop()
+		for {
+			now := time.Now().UTC()
+			if err := tokenRepo.DeleteExpired(now); err != nil {
+				log.Printf("Warning: failed to delete expired tokens: %v", err)
+			}
+			if err := userTokenRepo.DeleteExpired(now); err != nil {
+				log.Printf("Warning: failed to delete expired user tokens: %v", err)
+			}
+			// Session yang tidak aktif lebih lama dari umur refresh token tidak bisa di-refresh lagi
+			if err := sessionRepo.DeleteInactive(now.Add(-refreshTTL)); err != nil {
+				log.Printf("Warning: failed to delete inactive sessions: %v", err)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+// newRandomToken membuat token acak 256-bit (refresh token, token reset password)
+func newRandomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken mengembalikan SHA-256 (hex) dari token untuk disimpan di database
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
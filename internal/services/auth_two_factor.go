@@ -0,0 +1,83 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"rest-api/internal/dto/response"
+	"rest-api/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	loginChallengeTTL         = 5 * time.Minute
+	maxLoginChallengeAttempts = 5
+)
+
+// LoginTwoFactor implements AuthService.
+// Langkah kedua login: challenge token dari Login ditukar dengan token jika kode TOTP/recovery code benar
+// Challenge hangus setelah berhasil atau setelah maxLoginChallengeAttempts percobaan gagal
+// Kode yang salah juga dihitung LoginThrottle, sehingga challenge baru tidak bisa dipakai untuk menebak kode tanpa batas
+func (a *authService) LoginTwoFactor(challengeToken string, code string, client ClientInfo) (*LoginResult, error) {
+	if challengeToken == "" || code == "" {
+		return nil, errors.New("challenge token and code are required")
+	}
+	stored, err := a.userTokenRepo.FindByHash(models.UserTokenLoginChallenge, hashToken(challengeToken))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("invalid or expired login challenge")
+		}
+		return nil, errors.New("failed to verify two-factor code")
+	}
+	now := time.Now().UTC()
+	if stored.UsedAt != nil || now.After(stored.ExpiresAt) {
+		return nil, errors.New("invalid or expired login challenge")
+	}
+	user, err := a.authRepo.FindByID(stored.UserID)
+	if err != nil || user.TwoFactorEnabledAt == nil {
+		return nil, errors.New("invalid or expired login challenge")
+	}
+	if err := a.throttle.Check(user.Email, client.IPAddress); err != nil {
+		return nil, err
+	}
+
+	ok, err := verifySecondFactor(a.twoFactorRepo, twoFactorKey(a.cfg), user, code)
+	if err != nil {
+		return nil, errors.New("failed to verify two-factor code")
+	}
+	if !ok {
+		a.throttle.RecordFailure(user.Email, client.IPAddress, &user.ID)
+		if err := a.userTokenRepo.IncrementAttempts(stored); err != nil {
+			log.Printf("Warning: failed to record login challenge attempt for user %d: %v", user.ID, err)
+		}
+		if stored.Attempts >= maxLoginChallengeAttempts {
+			if _, err := a.userTokenRepo.MarkUsed(stored, now); err != nil {
+				log.Printf("Warning: failed to expire login challenge for user %d: %v", user.ID, err)
+			}
+			return nil, errors.New("too many invalid codes, please login again")
+		}
+		return nil, errors.New("invalid two-factor code")
+	}
+
+	marked, err := a.userTokenRepo.MarkUsed(stored, now)
+	if err != nil {
+		return nil, errors.New("failed to verify two-factor code")
+	}
+	if !marked {
+		return nil, errors.New("invalid or expired login challenge")
+	}
+	return a.completeLogin(user, client)
+}
+
+// startLoginChallenge membuat challenge token untuk langkah kedua login
+func (a *authService) startLoginChallenge(user *models.User) (*response.TwoFactorChallengeResponse, error) {
+	token, err := issueUserToken(a.userTokenRepo, user.ID, models.UserTokenLoginChallenge, user.Email, loginChallengeTTL)
+	if err != nil {
+		return nil, err
+	}
+	return &response.TwoFactorChallengeResponse{
+		ChallengeToken: token,
+		ExpiresAt:      time.Now().UTC().Add(loginChallengeTTL),
+	}, nil
+}
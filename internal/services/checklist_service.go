@@ -0,0 +1,147 @@
+package services
+
+import (
+	"errors"
+	"rest-api/internal/dto/request"
+	"rest-api/internal/dto/response"
+	"rest-api/internal/models"
+	"rest-api/internal/repositories"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type ChecklistService interface {
+	AddItem(userID, taskID uint, req request.ChecklistItemCreateRequest) (*response.ChecklistItemResponse, error)
+	UpdateItem(userID, taskID, itemID uint, req request.ChecklistItemUpdateRequest) (*response.ChecklistItemResponse, error)
+	DeleteItem(userID, taskID, itemID uint) error
+}
+
+type checklistService struct {
+	checklistRepo repositories.ChecklistRepository
+	taskRepo      repositories.TaskRepository
+}
+
+// AddItem implements ChecklistService.
+// Item baru ditambahkan di akhir checklist
+func (s *checklistService) AddItem(userID uint, taskID uint, req request.ChecklistItemCreateRequest) (*response.ChecklistItemResponse, error) {
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		return nil, errors.New("checklist item title is required")
+	}
+	if _, err := s.findOwnedTask(userID, taskID); err != nil {
+		return nil, err
+	}
+
+	position, err := s.checklistRepo.NextPosition(taskID)
+	if err != nil {
+		return nil, errors.New("failed to create checklist item")
+	}
+	item := &models.ChecklistItem{
+		TaskID:   taskID,
+		Title:    title,
+		Position: position,
+	}
+	if err := s.checklistRepo.Create(item); err != nil {
+		return nil, errors.New("failed to create checklist item")
+	}
+	return toChecklistItemResponse(item), nil
+}
+
+// UpdateItem implements ChecklistService.
+func (s *checklistService) UpdateItem(userID uint, taskID uint, itemID uint, req request.ChecklistItemUpdateRequest) (*response.ChecklistItemResponse, error) {
+	item, err := s.findItem(userID, taskID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Title != nil {
+		title := strings.TrimSpace(*req.Title)
+		if title == "" {
+			return nil, errors.New("checklist item title is required")
+		}
+		item.Title = title
+	}
+	if req.IsDone != nil {
+		item.IsDone = *req.IsDone
+	}
+	if req.Position != nil {
+		if *req.Position < 0 {
+			return nil, errors.New("invalid checklist item position")
+		}
+		item.Position = *req.Position
+	}
+
+	if err := s.checklistRepo.Update(item); err != nil {
+		return nil, errors.New("failed to update checklist item")
+	}
+	return toChecklistItemResponse(item), nil
+}
+
+// DeleteItem implements ChecklistService.
+func (s *checklistService) DeleteItem(userID uint, taskID uint, itemID uint) error {
+	item, err := s.findItem(userID, taskID, itemID)
+	if err != nil {
+		return err
+	}
+	if err := s.checklistRepo.Delete(item); err != nil {
+		return errors.New("failed to delete checklist item")
+	}
+	return nil
+}
+
+// findOwnedTask memastikan task ada dan milik user
+func (s *checklistService) findOwnedTask(userID, taskID uint) (*models.Task, error) {
+	task, err := s.taskRepo.FindByID(taskID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("task not found")
+		}
+		return nil, errors.New("failed to retrieve task")
+	}
+	if task.UserID != userID {
+		return nil, errors.New("unauthorized to update this task")
+	}
+	return task, nil
+}
+
+// findItem mengambil item checklist dan memastikan item berada di task milik user
+func (s *checklistService) findItem(userID, taskID, itemID uint) (*models.ChecklistItem, error) {
+	if _, err := s.findOwnedTask(userID, taskID); err != nil {
+		return nil, err
+	}
+	item, err := s.checklistRepo.FindByID(itemID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("checklist item not found")
+		}
+		return nil, errors.New("failed to retrieve checklist item")
+	}
+	if item.TaskID != taskID {
+		return nil, errors.New("checklist item not found")
+	}
+	return item, nil
+}
+
+func toChecklistItemResponse(item *models.ChecklistItem) *response.ChecklistItemResponse {
+	return &response.ChecklistItemResponse{
+		ID:        item.ID,
+		Title:     item.Title,
+		IsDone:    item.IsDone,
+		Position:  item.Position,
+		CreatedAt: item.CreatedAt,
+		UpdatedAt: item.UpdatedAt,
+	}
+}
+
+func toChecklistItemResponses(items []models.ChecklistItem) []response.ChecklistItemResponse {
+	itemResponses := make([]response.ChecklistItemResponse, 0, len(items))
+	for i := range items {
+		itemResponses = append(itemResponses, *toChecklistItemResponse(&items[i]))
+	}
+	return itemResponses
+}
+
+func NewChecklistService(checklistRepo repositories.ChecklistRepository, taskRepo repositories.TaskRepository) ChecklistService {
+	return &checklistService{checklistRepo: checklistRepo, taskRepo: taskRepo}
+}
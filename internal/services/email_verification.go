@@ -0,0 +1,138 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"rest-api/internal/dto/response"
+	"rest-api/internal/mailer"
+	"rest-api/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const defaultEmailVerificationTTL = 48 * time.Hour
+
+// VerifyEmail implements AuthService.
+// Menerima token verifikasi email setelah registrasi maupun token konfirmasi email baru
+func (a *authService) VerifyEmail(token string) (*response.UserResponse, error) {
+	if token == "" {
+		return nil, errors.New("token is required")
+	}
+	tokenHash := hashToken(token)
+	stored, err := a.userTokenRepo.FindByHash(models.UserTokenEmailVerify, tokenHash)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		stored, err = a.userTokenRepo.FindByHash(models.UserTokenEmailChange, tokenHash)
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("invalid or expired verification token")
+		}
+		return nil, errors.New("failed to verify email")
+	}
+	now := time.Now().UTC()
+	if stored.UsedAt != nil || now.After(stored.ExpiresAt) {
+		return nil, errors.New("invalid or expired verification token")
+	}
+	user, err := a.authRepo.FindByID(stored.UserID)
+	if err != nil {
+		return nil, errors.New("invalid or expired verification token")
+	}
+
+	changing := stored.Purpose == models.UserTokenEmailChange
+	// Token verifikasi hanya berlaku untuk alamat yang dipakai saat token dikirim
+	if !changing && stored.Email != user.Email {
+		return nil, errors.New("invalid or expired verification token")
+	}
+	// Email baru bisa saja sudah dipakai user lain sejak link dikirim
+	if changing {
+		existing, err := a.authRepo.FindByEmail(stored.Email)
+		if err == nil && existing.ID != user.ID {
+			return nil, errors.New("email already in use")
+		} else if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("failed to verify email")
+		}
+	}
+
+	marked, err := a.userTokenRepo.MarkUsed(stored, now)
+	if err != nil {
+		return nil, errors.New("failed to verify email")
+	}
+	if !marked {
+		return nil, errors.New("invalid or expired verification token")
+	}
+	oldEmail := user.Email
+	if err := a.authRepo.VerifyEmail(user.ID, stored.Email, now); err != nil {
+		return nil, errors.New("failed to verify email")
+	}
+	user.Email = stored.Email
+	user.EmailVerifiedAt = &now
+
+	// Role admin dari ADMIN_EMAILS hanya untuk pemilik email yang sudah terbukti
+	if user.Role != models.RoleAdmin && isAdminEmail(a.cfg, user.Email) {
+		if err := a.authRepo.SetRole(user.ID, models.RoleAdmin); err != nil {
+			log.Printf("Warning: failed to promote user %d to admin: %v", user.ID, err)
+		} else {
+			user.Role = models.RoleAdmin
+			log.Printf("Security: user %d promoted to admin from ADMIN_EMAILS", user.ID)
+		}
+	}
+
+	// Link lain yang masih beredar tidak berlaku lagi
+	if err := a.userTokenRepo.InvalidateByUserID(user.ID, models.UserTokenEmailVerify, now); err != nil {
+		log.Printf("Warning: failed to invalidate verification tokens for user %d: %v", user.ID, err)
+	}
+	if changing {
+		if err := a.userTokenRepo.InvalidateByUserID(user.ID, models.UserTokenEmailChange, now); err != nil {
+			log.Printf("Warning: failed to invalidate email change tokens for user %d: %v", user.ID, err)
+		}
+		// Link reset password yang terkirim ke email lama tidak boleh bisa dipakai lagi
+		if err := a.userTokenRepo.InvalidateByUserID(user.ID, models.UserTokenPasswordReset, now); err != nil {
+			log.Printf("Warning: failed to invalidate password reset tokens for user %d: %v", user.ID, err)
+		}
+		if oldEmail != user.Email {
+			sendMailAsync(a.mailer, mailer.Message{
+				To:      oldEmail,
+				Subject: "Your email address was changed",
+				Body: fmt.Sprintf("Hi %s,\n\nThe email address for your account was changed to %s.\n"+
+					"If this was not you, reset your password immediately.\n", user.Username, user.Email),
+			})
+		}
+	}
+	return toUserResponse(user), nil
+}
+
+// ResendVerification implements AuthService.
+func (a *authService) ResendVerification(userID uint) error {
+	user, err := a.authRepo.FindByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("user not found")
+		}
+		return errors.New("failed to retrieve user")
+	}
+	if user.EmailVerifiedAt != nil {
+		return errors.New("email already verified")
+	}
+	if err := a.sendVerificationEmail(user); err != nil {
+		return errors.New("failed to send verification email")
+	}
+	return nil
+}
+
+// sendVerificationEmail mengirim link verifikasi ke email user saat ini
+func (a *authService) sendVerificationEmail(user *models.User) error {
+	ttl := parseTTL(a.cfg.EmailVerificationExpires, defaultEmailVerificationTTL)
+	token, err := issueUserToken(a.userTokenRepo, user.ID, models.UserTokenEmailVerify, user.Email, ttl)
+	if err != nil {
+		return err
+	}
+	sendMailAsync(a.mailer, mailer.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hi %s,\n\nOpen the link below to verify your email address:\n\n%s\n\nThe link expires in %s.\n",
+			user.Username, tokenLink(a.cfg, "verify-email", token), ttl),
+	})
+	return nil
+}
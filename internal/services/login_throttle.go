@@ -0,0 +1,220 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"rest-api/config"
+	"rest-api/internal/models"
+	"rest-api/internal/repositories"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Default proteksi brute force login
+const (
+	defaultLoginMaxAttempts      = 10
+	defaultLoginMaxAttemptsPerIP = 100
+	defaultLoginLockoutDuration  = 15 * time.Minute
+	defaultLoginBackoffBase      = time.Second
+	defaultLoginAttemptWindow    = time.Hour
+	loginFreeAttempts            = 3 // Jumlah gagal sebelum backoff mulai berlaku
+	loginAttemptCleanupInterval  = time.Hour
+)
+
+// LoginThrottledError dikembalikan saat login ditolak karena backoff atau lockout
+// Pesannya sama untuk akun yang ada maupun tidak agar tidak membocorkan keberadaan akun
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginThrottledError) Error() string {
+	return "too many login attempts, please try again later"
+}
+
+// LoginThrottle melacak login gagal per akun dan per IP
+type LoginThrottle interface {
+	// Check mengembalikan *LoginThrottledError jika akun atau IP masih dalam backoff/lockout
+	Check(email, ip string) error
+	// RecordFailure mencatat login gagal; userID nil jika email tidak terdaftar
+	RecordFailure(email, ip string, userID *uint)
+	// RecordSuccess menghapus catatan gagal akun setelah login berhasil
+	RecordSuccess(email string)
+}
+
+type loginThrottle struct {
+	attemptRepo repositories.LoginAttemptRepository
+	eventRepo   repositories.SecurityEventRepository
+	maxAccount  int
+	maxIP       int
+	lockout     time.Duration
+	backoffBase time.Duration
+	window      time.Duration
+}
+
+// Check implements LoginThrottle.
+func (t *loginThrottle) Check(email, ip string) error {
+	attempts, err := t.attemptRepo.FindByKeys(accountKey(email), ipKey(ip))
+	if err != nil {
+		// Database bermasalah: jangan kunci semua user, login tetap diproses
+		log.Printf("Warning: failed to check login attempts: %v", err)
+		return nil
+	}
+
+	now := time.Now().UTC()
+	var retryAfter time.Duration
+	for _, attempt := range attempts {
+		if wait := t.waitFor(&attempt, now); wait > retryAfter {
+			retryAfter = wait
+		}
+	}
+	if retryAfter > 0 {
+		return &LoginThrottledError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// waitFor menghitung sisa waktu tunggu untuk satu key
+// Backoff hanya untuk key akun; IP bisa dipakai bersama banyak user (NAT) sehingga hanya dikenai lockout
+func (t *loginThrottle) waitFor(attempt *models.LoginAttempt, now time.Time) time.Duration {
+	if attempt.LockedUntil != nil && attempt.LockedUntil.After(now) {
+		return attempt.LockedUntil.Sub(now)
+	}
+	if !strings.HasPrefix(attempt.Key, "account:") || now.Sub(attempt.LastFailureAt) > t.window {
+		return 0
+	}
+	if wait := attempt.LastFailureAt.Add(t.backoff(attempt.Failures)).Sub(now); wait > 0 {
+		return wait
+	}
+	return 0
+}
+
+// backoff: tanpa jeda untuk beberapa gagal pertama, lalu base * 2^n dibatasi durasi lockout
+func (t *loginThrottle) backoff(failures int) time.Duration {
+	if failures < loginFreeAttempts || t.backoffBase <= 0 {
+		return 0
+	}
+	delay := t.backoffBase
+	for i := loginFreeAttempts; i < failures; i++ {
+		delay *= 2
+		if delay >= t.lockout {
+			return t.lockout
+		}
+	}
+	return delay
+}
+
+// RecordFailure implements LoginThrottle.
+func (t *loginThrottle) RecordFailure(email, ip string, userID *uint) {
+	now := time.Now().UTC()
+	t.recordKey(accountKey(email), t.maxAccount, now, func() *models.SecurityEvent {
+		return &models.SecurityEvent{
+			Type:      models.SecurityEventAccountLocked,
+			UserID:    userID,
+			IPAddress: ip,
+			Detail:    truncate(fmt.Sprintf("login locked for %s after %d failed attempts", normalizeEmailKey(email), t.maxAccount), 255),
+		}
+	})
+	t.recordKey(ipKey(ip), t.maxIP, now, func() *models.SecurityEvent {
+		return &models.SecurityEvent{
+			Type:      models.SecurityEventIPLocked,
+			IPAddress: ip,
+			Detail:    fmt.Sprintf("login locked for IP after %d failed attempts", t.maxIP),
+		}
+	})
+}
+
+// recordKey menambah hitungan gagal dan mengunci key saat mencapai batas
+// Security event dicatat sekali setiap kali lockout dimulai
+func (t *loginThrottle) recordKey(key string, max int, now time.Time, event func() *models.SecurityEvent) {
+	attempt, err := t.attemptRepo.RecordFailure(key, now, t.window)
+	if err != nil {
+		log.Printf("Warning: failed to record login failure: %v", err)
+		return
+	}
+	if max <= 0 || attempt.Failures < max {
+		return
+	}
+	if attempt.LockedUntil != nil && attempt.LockedUntil.After(now) {
+		return
+	}
+	if err := t.attemptRepo.Lock(key, now.Add(t.lockout)); err != nil {
+		log.Printf("Warning: failed to lock login: %v", err)
+		return
+	}
+	lockEvent := event()
+	log.Printf("Security: %s (%s)", lockEvent.Type, lockEvent.Detail)
+	if err := t.eventRepo.Create(lockEvent); err != nil {
+		log.Printf("Warning: failed to record security event: %v", err)
+	}
+}
+
+// RecordSuccess implements LoginThrottle.
+// Catatan gagal per IP tidak dihapus agar satu akun valid tidak bisa dipakai untuk me-reset hitungan IP
+func (t *loginThrottle) RecordSuccess(email string) {
+	if err := t.attemptRepo.Reset(accountKey(email)); err != nil {
+		log.Printf("Warning: failed to reset login attempts: %v", err)
+	}
+}
+
+func accountKey(email string) string {
+	return truncate("account:"+normalizeEmailKey(email), 191)
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
+
+func normalizeEmailKey(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func truncate(value string, max int) string {
+	if len(value) > max {
+		return value[:max]
+	}
+	return value
+}
+
+// parseLimit membaca batas jumlah dari konfigurasi, fallback jika tidak valid
+func parseLimit(name, value string, fallback int) int {
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 0 {
+		log.Printf("Warning: invalid %s %q, using default %d", name, value, fallback)
+		return fallback
+	}
+	return limit
+}
+
+// StartLoginAttemptCleanup menjalankan goroutine yang secara berkala menghapus catatan login gagal yang sudah kedaluwarsa
+func StartLoginAttemptCleanup(attemptRepo repositories.LoginAttemptRepository, cfg *config.Config) {
+	window := parseTTL(cfg.LoginAttemptWindow, defaultLoginAttemptWindow)
+	go func() {
+		ticker := time.NewTicker(loginAttemptCleanupInterval)
+		defer ticker.Stop()
+		for {
+			if err := attemptRepo.DeleteStale(time.Now().UTC().Add(-window)); err != nil {
+				log.Printf("Warning: failed to delete stale login attempts: %v", err)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+func NewLoginThrottle(attemptRepo repositories.LoginAttemptRepository, eventRepo repositories.SecurityEventRepository, cfg *config.Config) LoginThrottle {
+	lockout := parseTTL(cfg.LoginLockoutDuration, defaultLoginLockoutDuration)
+	window := parseTTL(cfg.LoginAttemptWindow, defaultLoginAttemptWindow)
+	// Window harus lebih panjang dari lockout agar hitungan tidak hilang selama terkunci
+	if window < lockout {
+		window = lockout
+	}
+	return &loginThrottle{
+		attemptRepo: attemptRepo,
+		eventRepo:   eventRepo,
+		maxAccount:  parseLimit("LOGIN_MAX_ATTEMPTS", cfg.LoginMaxAttempts, defaultLoginMaxAttempts),
+		maxIP:       parseLimit("LOGIN_MAX_ATTEMPTS_PER_IP", cfg.LoginMaxAttemptsPerIP, defaultLoginMaxAttemptsPerIP),
+		lockout:     lockout,
+		backoffBase: parseTTL(cfg.LoginBackoffBase, defaultLoginBackoffBase),
+		window:      window,
+	}
+}
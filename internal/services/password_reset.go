@@ -0,0 +1,121 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"rest-api/config"
+	"rest-api/internal/mailer"
+	"rest-api/internal/models"
+	"rest-api/internal/repositories"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const defaultPasswordResetTTL = time.Hour
+
+// RequestPasswordReset implements AuthService.
+// Response selalu sama baik email terdaftar atau tidak agar tidak bisa dipakai untuk menebak akun
+// Token lama yang belum dipakai dibatalkan sehingga hanya link terbaru yang berlaku
+func (a *authService) RequestPasswordReset(email string) error {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return errors.New("email is required")
+	}
+
+	user, err := a.authRepo.FindByEmail(email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return errors.New("failed to request password reset")
+	}
+
+	if err := sendPasswordResetEmail(a.userTokenRepo, a.mailer, a.cfg, user, "We received a request to reset your password."); err != nil {
+		return errors.New("failed to request password reset")
+	}
+	return nil
+}
+
+// ResetPassword implements AuthService.
+// Token hanya bisa dipakai sekali; setelah password diganti semua session user diakhiri
+func (a *authService) ResetPassword(token string, newPassword string) error {
+	if token == "" || newPassword == "" {
+		return errors.New("token and password are required")
+	}
+
+	stored, err := a.userTokenRepo.FindByHash(models.UserTokenPasswordReset, hashToken(token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("invalid or expired reset token")
+		}
+		return errors.New("failed to reset password")
+	}
+	now := time.Now().UTC()
+	if stored.UsedAt != nil || now.After(stored.ExpiresAt) {
+		return errors.New("invalid or expired reset token")
+	}
+	user, err := a.authRepo.FindByID(stored.UserID)
+	if err != nil {
+		return errors.New("invalid or expired reset token")
+	}
+	// Link reset hanya berlaku untuk alamat yang dipakai saat token dikirim, bukan email lama setelah diganti
+	if stored.Email != user.Email {
+		return errors.New("invalid or expired reset token")
+	}
+
+	if err := a.policy.Validate(newPassword, user.Username, user.Email); err != nil {
+		return err
+	}
+	hashedPassword, err := a.hasher.Hash(newPassword)
+	if err != nil {
+		return errors.New("failed to hash password")
+	}
+	// Tandai token terpakai lebih dulu agar request bersamaan tidak bisa memakai token yang sama
+	marked, err := a.userTokenRepo.MarkUsed(stored, now)
+	if err != nil {
+		return errors.New("failed to reset password")
+	}
+	if !marked {
+		return errors.New("invalid or expired reset token")
+	}
+	if err := a.authRepo.UpdatePassword(user.ID, hashedPassword); err != nil {
+		return errors.New("failed to reset password")
+	}
+
+	// Login yang mungkin dipegang penyerang tidak boleh bertahan setelah reset
+	if err := a.sessionRepo.RevokeAllByUserID(user.ID, now); err != nil {
+		log.Printf("Warning: failed to revoke sessions for user %d: %v", user.ID, err)
+	}
+	if err := a.tokenRepo.RevokeAllByUserID(user.ID, now); err != nil {
+		log.Printf("Warning: failed to revoke tokens for user %d: %v", user.ID, err)
+	}
+
+	sendMailAsync(a.mailer, mailer.Message{
+		To:      user.Email,
+		Subject: "Your password was changed",
+		Body: fmt.Sprintf("Hi %s,\n\nThe password for your account was just reset and all devices were signed out.\n"+
+			"If this was not you, request a new password reset immediately.\n", user.Username),
+	})
+	return nil
+}
+
+// sendPasswordResetEmail membuat token reset password dan mengirim link-nya ke email user
+// reason adalah kalimat pembuka email (permintaan user sendiri atau dipaksa admin)
+func sendPasswordResetEmail(userTokenRepo repositories.UserTokenRepository, m mailer.Mailer, cfg *config.Config, user *models.User, reason string) error {
+	ttl := parseTTL(cfg.PasswordResetExpires, defaultPasswordResetTTL)
+	token, err := issueUserToken(userTokenRepo, user.ID, models.UserTokenPasswordReset, user.Email, ttl)
+	if err != nil {
+		return err
+	}
+	sendMailAsync(m, mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\n%s Open the link below to choose a new one:\n\n%s\n\n"+
+			"The link expires in %s and can only be used once. If you did not request this, you can ignore this email.\n",
+			user.Username, reason, tokenLink(cfg, "reset-password", token), ttl),
+	})
+	return nil
+}
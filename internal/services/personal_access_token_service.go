@@ -0,0 +1,161 @@
+package services
+
+import (
+	"errors"
+	"rest-api/internal/dto/request"
+	"rest-api/internal/dto/response"
+	"rest-api/internal/middlewares"
+	"rest-api/internal/models"
+	"rest-api/internal/repositories"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	maxPersonalAccessTokens  = 50
+	accessTokenPrefixDisplay = 12 // Jumlah karakter awal token yang disimpan untuk ditampilkan
+)
+
+type PersonalAccessTokenService interface {
+	CreateToken(userID uint, req request.PersonalAccessTokenCreateRequest) (*response.PersonalAccessTokenResponse, error)
+	GetTokens(userID uint) ([]response.PersonalAccessTokenResponse, error)
+	DeleteToken(userID, tokenID uint) error
+}
+
+type personalAccessTokenService struct {
+	tokenRepo repositories.PersonalAccessTokenRepository
+}
+
+// CreateToken implements PersonalAccessTokenService.
+// Token asli hanya dikembalikan di response ini
+func (s *personalAccessTokenService) CreateToken(userID uint, req request.PersonalAccessTokenCreateRequest) (*response.PersonalAccessTokenResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.New("token name is required")
+	}
+	if len(name) > 100 {
+		return nil, errors.New("token name must be at most 100 characters")
+	}
+	scopes, err := normalizeScopes(req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	var expiresAt *time.Time
+	if req.ExpiresAt != nil {
+		if !req.ExpiresAt.After(time.Now()) {
+			return nil, errors.New("expiresAt must be in the future")
+		}
+		utc := req.ExpiresAt.UTC()
+		expiresAt = &utc
+	}
+
+	count, err := s.tokenRepo.CountByUserID(userID)
+	if err != nil {
+		return nil, errors.New("failed to create token")
+	}
+	if count >= maxPersonalAccessTokens {
+		return nil, errors.New("too many access tokens")
+	}
+
+	secret, err := newRandomToken()
+	if err != nil {
+		return nil, errors.New("failed to create token")
+	}
+	plain := middlewares.PersonalAccessTokenPrefix + secret
+	token := &models.PersonalAccessToken{
+		UserID:    userID,
+		Name:      name,
+		Prefix:    plain[:accessTokenPrefixDisplay],
+		TokenHash: hashToken(plain),
+		Scopes:    strings.Join(scopes, ","),
+		ExpiresAt: expiresAt,
+	}
+	if err := s.tokenRepo.Create(token); err != nil {
+		return nil, errors.New("failed to create token")
+	}
+
+	tokenResponse := toPersonalAccessTokenResponse(token)
+	tokenResponse.Token = plain
+	return &tokenResponse, nil
+}
+
+// GetTokens implements PersonalAccessTokenService.
+func (s *personalAccessTokenService) GetTokens(userID uint) ([]response.PersonalAccessTokenResponse, error) {
+	tokens, err := s.tokenRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, errors.New("failed to retrieve tokens")
+	}
+	tokenResponses := make([]response.PersonalAccessTokenResponse, 0, len(tokens))
+	for i := range tokens {
+		tokenResponses = append(tokenResponses, toPersonalAccessTokenResponse(&tokens[i]))
+	}
+	return tokenResponses, nil
+}
+
+// DeleteToken implements PersonalAccessTokenService.
+// Token langsung tidak bisa dipakai lagi
+func (s *personalAccessTokenService) DeleteToken(userID uint, tokenID uint) error {
+	token, err := s.tokenRepo.FindByID(tokenID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("token not found")
+		}
+		return errors.New("failed to retrieve token")
+	}
+	// Token milik user lain diperlakukan seperti tidak ada
+	if token.UserID != userID {
+		return errors.New("token not found")
+	}
+	if err := s.tokenRepo.Delete(token); err != nil {
+		return errors.New("failed to delete token")
+	}
+	return nil
+}
+
+// normalizeScopes memvalidasi scope dan membuang duplikat
+func normalizeScopes(scopes []string) ([]string, error) {
+	if len(scopes) == 0 {
+		return nil, errors.New("at least one scope is required")
+	}
+	normalized := make([]string, 0, len(scopes))
+	seen := make(map[string]bool)
+	for _, scope := range scopes {
+		scope = strings.TrimSpace(scope)
+		if !containsString(middlewares.TokenScopes, scope) {
+			return nil, errors.New("invalid scope: " + scope)
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			normalized = append(normalized, scope)
+		}
+	}
+	return normalized, nil
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
+
+func toPersonalAccessTokenResponse(token *models.PersonalAccessToken) response.PersonalAccessTokenResponse {
+	return response.PersonalAccessTokenResponse{
+		ID:         token.ID,
+		Name:       token.Name,
+		Prefix:     token.Prefix,
+		Scopes:     strings.Split(token.Scopes, ","),
+		ExpiresAt:  token.ExpiresAt,
+		LastUsedAt: token.LastUsedAt,
+		CreatedAt:  token.CreatedAt,
+		Expired:    token.ExpiresAt != nil && time.Now().After(*token.ExpiresAt),
+	}
+}
+
+func NewPersonalAccessTokenService(tokenRepo repositories.PersonalAccessTokenRepository) PersonalAccessTokenService {
+	return &personalAccessTokenService{tokenRepo: tokenRepo}
+}
@@ -0,0 +1,245 @@
+package services
+
+import (
+	"errors"
+	"regexp"
+	"rest-api/internal/dto/request"
+	"rest-api/internal/dto/response"
+	"rest-api/internal/models"
+	"rest-api/internal/repositories"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// hexColorPattern memvalidasi warna hex project/tag, contoh: #ff8800
+var hexColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+type ProjectService interface {
+	CreateProject(userID uint, req request.ProjectCreateRequest) (*response.ProjectResponse, error)
+	GetProjectsByUserID(userID uint, includeArchived bool) ([]response.ProjectResponse, error)
+	GetProjectByID(userID, projectID uint) (*response.ProjectResponse, error)
+	UpdateProject(userID, projectID uint, req request.ProjectUpdateRequest) (*response.ProjectResponse, error)
+	ArchiveProject(userID, projectID uint, archived bool) (*response.ProjectResponse, error)
+	DeleteProject(userID, projectID uint) error
+	GetTrash(userID uint) ([]response.ProjectResponse, error)
+	RestoreProject(userID, projectID uint) (*response.ProjectResponse, error)
+	PurgeProject(userID, projectID uint) error
+}
+
+type projectService struct {
+	projectRepo repositories.ProjectRepository
+}
+
+// CreateProject implements ProjectService.
+func (p *projectService) CreateProject(userID uint, req request.ProjectCreateRequest) (*response.ProjectResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.New("project name is required")
+	}
+	if req.Color != "" && !hexColorPattern.MatchString(req.Color) {
+		return nil, errors.New("invalid project color")
+	}
+	if err := p.checkNameAvailability(userID, name, 0); err != nil {
+		return nil, err
+	}
+
+	project := &models.Project{
+		UserID:      userID,
+		Name:        name,
+		Description: req.Description,
+		Color:       req.Color,
+	}
+	if err := p.projectRepo.Create(project); err != nil {
+		return nil, errors.New("failed to create project")
+	}
+	return toProjectResponse(project), nil
+}
+
+// GetProjectsByUserID implements ProjectService.
+func (p *projectService) GetProjectsByUserID(userID uint, includeArchived bool) ([]response.ProjectResponse, error) {
+	projects, err := p.projectRepo.FindAllByUserID(userID, includeArchived)
+	if err != nil {
+		return nil, errors.New("failed to retrieve projects")
+	}
+	projectResponses := make([]response.ProjectResponse, 0, len(projects))
+	for i := range projects {
+		projectResponses = append(projectResponses, *toProjectResponse(&projects[i]))
+	}
+	return projectResponses, nil
+}
+
+// GetProjectByID implements ProjectService.
+func (p *projectService) GetProjectByID(userID uint, projectID uint) (*response.ProjectResponse, error) {
+	project, err := p.findOwnedProject(userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return toProjectResponse(project), nil
+}
+
+// UpdateProject implements ProjectService.
+func (p *projectService) UpdateProject(userID uint, projectID uint, req request.ProjectUpdateRequest) (*response.ProjectResponse, error) {
+	project, err := p.findOwnedProject(userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, errors.New("project name is required")
+		}
+		if name != project.Name {
+			if err := p.checkNameAvailability(userID, name, project.ID); err != nil {
+				return nil, err
+			}
+		}
+		project.Name = name
+	}
+	if req.Description != nil {
+		project.Description = *req.Description
+	}
+	if req.Color != nil {
+		if *req.Color != "" && !hexColorPattern.MatchString(*req.Color) {
+			return nil, errors.New("invalid project color")
+		}
+		project.Color = *req.Color
+	}
+
+	if err := p.projectRepo.Update(project); err != nil {
+		return nil, errors.New("failed to update project")
+	}
+	return toProjectResponse(project), nil
+}
+
+// ArchiveProject implements ProjectService.
+// archived = false untuk mengembalikan project dari arsip
+func (p *projectService) ArchiveProject(userID uint, projectID uint, archived bool) (*response.ProjectResponse, error) {
+	project, err := p.findOwnedProject(userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.projectRepo.SetArchived(project, archived); err != nil {
+		return nil, errors.New("failed to archive project")
+	}
+	return toProjectResponse(project), nil
+}
+
+// DeleteProject implements ProjectService.
+func (p *projectService) DeleteProject(userID uint, projectID uint) error {
+	project, err := p.findOwnedProject(userID, projectID)
+	if err != nil {
+		return err
+	}
+	if err := p.projectRepo.Delete(project); err != nil {
+		return errors.New("failed to delete project")
+	}
+	return nil
+}
+
+// GetTrash implements ProjectService.
+func (p *projectService) GetTrash(userID uint) ([]response.ProjectResponse, error) {
+	projects, err := p.projectRepo.FindTrashedByUserID(userID)
+	if err != nil {
+		return nil, errors.New("failed to retrieve projects")
+	}
+	projectResponses := make([]response.ProjectResponse, 0, len(projects))
+	for i := range projects {
+		projectResponses = append(projectResponses, *toProjectResponse(&projects[i]))
+	}
+	return projectResponses, nil
+}
+
+// RestoreProject implements ProjectService.
+// Gagal jika nama project sudah dipakai project lain sejak dihapus
+func (p *projectService) RestoreProject(userID uint, projectID uint) (*response.ProjectResponse, error) {
+	project, err := p.findTrashedProject(userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.checkNameAvailability(userID, project.Name, project.ID); err != nil {
+		return nil, err
+	}
+	if err := p.projectRepo.Restore(project); err != nil {
+		return nil, errors.New("failed to restore project")
+	}
+	return toProjectResponse(project), nil
+}
+
+// PurgeProject implements ProjectService.
+func (p *projectService) PurgeProject(userID uint, projectID uint) error {
+	project, err := p.findTrashedProject(userID, projectID)
+	if err != nil {
+		return err
+	}
+	if err := p.projectRepo.Purge(project); err != nil {
+		return errors.New("failed to delete project")
+	}
+	return nil
+}
+
+// findTrashedProject mengambil project dari trash dan memastikan project milik user
+func (p *projectService) findTrashedProject(userID, projectID uint) (*models.Project, error) {
+	project, err := p.projectRepo.FindTrashedByID(projectID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("project not found in trash")
+		}
+		return nil, errors.New("failed to retrieve project")
+	}
+	if project.UserID != userID {
+		return nil, errors.New("unauthorized to access this project")
+	}
+	return project, nil
+}
+
+// findOwnedProject mengambil project dan memastikan project milik user
+func (p *projectService) findOwnedProject(userID, projectID uint) (*models.Project, error) {
+	project, err := p.projectRepo.FindByID(projectID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("project not found")
+		}
+		return nil, errors.New("failed to retrieve project")
+	}
+	if project.UserID != userID {
+		return nil, errors.New("unauthorized to access this project")
+	}
+	return project, nil
+}
+
+// checkNameAvailability memastikan nama project unik per user
+func (p *projectService) checkNameAvailability(userID uint, name string, excludeProjectID uint) error {
+	existing, err := p.projectRepo.FindByName(userID, name)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.New("failed to check project name")
+	}
+	if existing != nil && existing.ID != excludeProjectID {
+		return errors.New("project name already in use")
+	}
+	return nil
+}
+
+func toProjectResponse(project *models.Project) *response.ProjectResponse {
+	projectResponse := &response.ProjectResponse{
+		ID:          project.ID,
+		Name:        project.Name,
+		Description: project.Description,
+		Color:       project.Color,
+		IsArchived:  project.ArchivedAt != nil,
+		ArchivedAt:  project.ArchivedAt,
+		CreatedAt:   project.CreatedAt,
+		UpdatedAt:   project.UpdatedAt,
+		UserID:      project.UserID,
+	}
+	if project.DeletedAt.Valid {
+		deletedAt := project.DeletedAt.Time
+		projectResponse.DeletedAt = &deletedAt
+	}
+	return projectResponse
+}
+
+func NewProjectService(projectRepo repositories.ProjectRepository) ProjectService {
+	return &projectService{projectRepo: projectRepo}
+}
@@ -0,0 +1,273 @@
+package services
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frekuensi recurrence yang didukung (subset dari RFC 5545 RRULE)
+const (
+	FreqDaily   = "DAILY"
+	FreqWeekly  = "WEEKLY"
+	FreqMonthly = "MONTHLY"
+	FreqYearly  = "YEARLY"
+)
+
+// maxRecurrenceSearch membatasi jumlah langkah pencarian occurrence berikutnya
+// agar rule yang tidak pernah cocok (contoh: BYMONTHDAY=31 + INTERVAL=2 di bulan pendek) tidak berputar terus
+const maxRecurrenceSearch = 1000
+
+var weekdayCodes = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// RecurrenceRule adalah jadwal berulang gaya RRULE
+// Contoh: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH;UNTIL=20251231T000000Z" atau "FREQ=MONTHLY;BYMONTHDAY=1,15;COUNT=12"
+type RecurrenceRule struct {
+	Freq       string
+	Interval   int
+	ByDay      []time.Weekday // Hanya untuk DAILY dan WEEKLY
+	ByMonthDay []int          // Hanya untuk MONTHLY, 1-31
+	Until      *time.Time     // Occurrence setelah waktu ini tidak dibuat
+	Count      int            // Jumlah total occurrence dalam series, 0 = tanpa batas
+}
+
+// ParseRecurrenceRule membaca rule dari string RRULE (prefix "RRULE:" opsional)
+func ParseRecurrenceRule(value string) (*RecurrenceRule, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	if value == "" {
+		return nil, errors.New("invalid recurrence rule")
+	}
+
+	rule := &RecurrenceRule{Interval: 1}
+	for _, part := range strings.Split(value, ";") {
+		key, val, found := strings.Cut(strings.TrimSpace(part), "=")
+		if !found || val == "" {
+			return nil, errors.New("invalid recurrence rule")
+		}
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.Freq = strings.ToUpper(val)
+		case "INTERVAL":
+			interval, err := strconv.Atoi(val)
+			if err != nil || interval < 1 || interval > 365 {
+				return nil, errors.New("invalid recurrence rule")
+			}
+			rule.Interval = interval
+		case "BYDAY":
+			for _, code := range strings.Split(strings.ToUpper(val), ",") {
+				weekday, ok := weekdayCodes[code]
+				if !ok {
+					return nil, errors.New("invalid recurrence rule")
+				}
+				rule.ByDay = append(rule.ByDay, weekday)
+			}
+		case "BYMONTHDAY":
+			for _, day := range strings.Split(val, ",") {
+				monthDay, err := strconv.Atoi(day)
+				if err != nil || monthDay < 1 || monthDay > 31 {
+					return nil, errors.New("invalid recurrence rule")
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, monthDay)
+			}
+			sort.Ints(rule.ByMonthDay)
+		case "UNTIL":
+			until, err := parseRRuleTime(val)
+			if err != nil {
+				return nil, errors.New("invalid recurrence rule")
+			}
+			rule.Until = &until
+		case "COUNT":
+			count, err := strconv.Atoi(val)
+			if err != nil || count < 1 {
+				return nil, errors.New("invalid recurrence rule")
+			}
+			rule.Count = count
+		default:
+			return nil, errors.New("unsupported recurrence rule part: " + key)
+		}
+	}
+
+	switch rule.Freq {
+	case FreqDaily, FreqWeekly:
+		if len(rule.ByMonthDay) > 0 {
+			return nil, errors.New("invalid recurrence rule")
+		}
+	case FreqMonthly:
+		if len(rule.ByDay) > 0 {
+			return nil, errors.New("invalid recurrence rule")
+		}
+	case FreqYearly:
+		if len(rule.ByDay) > 0 || len(rule.ByMonthDay) > 0 {
+			return nil, errors.New("invalid recurrence rule")
+		}
+	default:
+		return nil, errors.New("invalid recurrence rule")
+	}
+	if rule.Until != nil && rule.Count > 0 {
+		return nil, errors.New("recurrence rule cannot have both UNTIL and COUNT")
+	}
+	return rule, nil
+}
+
+// String mengembalikan bentuk normal dari rule untuk disimpan di database
+func (r *RecurrenceRule) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		codes := make([]string, 0, len(r.ByDay))
+		for _, weekday := range r.ByDay {
+			for code, value := range weekdayCodes {
+				if value == weekday {
+					codes = append(codes, code)
+				}
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, 0, len(r.ByMonthDay))
+		for _, day := range r.ByMonthDay {
+			days = append(days, strconv.Itoa(day))
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Next menghitung occurrence berikutnya setelah anchor
+// Perhitungan dilakukan dalam timezone anchor sehingga jam lokal tetap sama saat pergantian DST
+// occurrenceIndex adalah urutan anchor di dalam series (dimulai dari 1), dipakai untuk COUNT
+// Mengembalikan false jika series sudah selesai (melewati UNTIL atau COUNT)
+func (r *RecurrenceRule) Next(anchor time.Time, occurrenceIndex int) (time.Time, bool) {
+	if r.Count > 0 && occurrenceIndex >= r.Count {
+		return time.Time{}, false
+	}
+
+	var next time.Time
+	var found bool
+	switch r.Freq {
+	case FreqDaily:
+		next, found = r.nextDaily(anchor)
+	case FreqWeekly:
+		next, found = r.nextWeekly(anchor)
+	case FreqMonthly:
+		next, found = r.nextMonthly(anchor)
+	case FreqYearly:
+		next, found = r.nextYearly(anchor)
+	}
+	if !found {
+		return time.Time{}, false
+	}
+	if r.Until != nil && next.After(*r.Until) {
+		return time.Time{}, false
+	}
+	return next, true
+}
+
+func (r *RecurrenceRule) nextDaily(anchor time.Time) (time.Time, bool) {
+	for step := 1; step <= maxRecurrenceSearch; step++ {
+		candidate := anchor.AddDate(0, 0, step*r.Interval)
+		if r.matchesByDay(candidate.Weekday()) {
+			return candidate, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func (r *RecurrenceRule) nextWeekly(anchor time.Time) (time.Time, bool) {
+	if len(r.ByDay) == 0 {
+		return anchor.AddDate(0, 0, 7*r.Interval), true
+	}
+	// Minggu dimulai hari Senin (WKST=MO); cek sisa hari di minggu anchor,
+	// lalu lompat per INTERVAL minggu
+	weekStart := anchor.AddDate(0, 0, -mondayOffset(anchor.Weekday()))
+	for week := 0; week <= maxRecurrenceSearch; week += r.Interval {
+		for day := 0; day < 7; day++ {
+			candidate := weekStart.AddDate(0, 0, week*7+day)
+			if candidate.After(anchor) && r.matchesByDay(candidate.Weekday()) {
+				return candidate, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+func (r *RecurrenceRule) nextMonthly(anchor time.Time) (time.Time, bool) {
+	days := r.ByMonthDay
+	if len(days) == 0 {
+		days = []int{anchor.Day()}
+	}
+	// Tanggal yang tidak ada di bulan tersebut (contoh: 31 Februari) dilewati, sesuai RFC 5545
+	for month := 0; month <= maxRecurrenceSearch; month += r.Interval {
+		first := time.Date(anchor.Year(), anchor.Month()+time.Month(month), 1,
+			anchor.Hour(), anchor.Minute(), anchor.Second(), 0, anchor.Location())
+		for _, day := range days {
+			candidate := first.AddDate(0, 0, day-1)
+			if candidate.Month() != first.Month() {
+				continue
+			}
+			if candidate.After(anchor) {
+				return candidate, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+func (r *RecurrenceRule) nextYearly(anchor time.Time) (time.Time, bool) {
+	for year := r.Interval; year <= maxRecurrenceSearch; year += r.Interval {
+		candidate := time.Date(anchor.Year()+year, anchor.Month(), anchor.Day(),
+			anchor.Hour(), anchor.Minute(), anchor.Second(), 0, anchor.Location())
+		// 29 Februari hanya muncul di tahun kabisat
+		if candidate.Month() == anchor.Month() {
+			return candidate, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func (r *RecurrenceRule) matchesByDay(weekday time.Weekday) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, day := range r.ByDay {
+		if day == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// mondayOffset mengembalikan jarak hari dari Senin (Senin = 0, Minggu = 6)
+func mondayOffset(weekday time.Weekday) int {
+	return (int(weekday) + 6) % 7
+}
+
+// parseRRuleTime membaca UNTIL dalam format RRULE (20251231T000000Z / 20251231) atau RFC 3339
+func parseRRuleTime(value string) (time.Time, error) {
+	layouts := []string{"20060102T150405Z", "20060102", time.RFC3339}
+	for _, layout := range layouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed.UTC(), nil
+		}
+	}
+	return time.Time{}, errors.New("invalid time")
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRecurrenceRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    string // Bentuk normal dari String(), kosong jika harus error
+		wantErr bool
+	}{
+		{name: "daily", value: "FREQ=DAILY", want: "FREQ=DAILY"},
+		{name: "prefix RRULE dan huruf kecil", value: "RRULE:freq=weekly;byday=mo,fr", want: "FREQ=WEEKLY;BYDAY=MO,FR"},
+		{name: "interval 1 tidak ditulis", value: "FREQ=DAILY;INTERVAL=1", want: "FREQ=DAILY"},
+		{name: "interval", value: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH", want: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH"},
+		{name: "bymonthday diurutkan", value: "FREQ=MONTHLY;BYMONTHDAY=15,1", want: "FREQ=MONTHLY;BYMONTHDAY=1,15"},
+		{name: "until tanggal saja", value: "FREQ=DAILY;UNTIL=20251231", want: "FREQ=DAILY;UNTIL=20251231T000000Z"},
+		{name: "until RFC 3339", value: "FREQ=DAILY;UNTIL=2025-12-31T10:00:00+07:00", want: "FREQ=DAILY;UNTIL=20251231T030000Z"},
+		{name: "count", value: "FREQ=YEARLY;COUNT=5", want: "FREQ=YEARLY;COUNT=5"},
+
+		{name: "kosong", value: "", wantErr: true},
+		{name: "tanpa FREQ", value: "INTERVAL=2", wantErr: true},
+		{name: "FREQ tidak didukung", value: "FREQ=HOURLY", wantErr: true},
+		{name: "part tanpa nilai", value: "FREQ=DAILY;COUNT=", wantErr: true},
+		{name: "part tidak didukung", value: "FREQ=MONTHLY;BYSETPOS=1", wantErr: true},
+		{name: "interval 0", value: "FREQ=DAILY;INTERVAL=0", wantErr: true},
+		{name: "interval terlalu besar", value: "FREQ=DAILY;INTERVAL=366", wantErr: true},
+		{name: "kode hari salah", value: "FREQ=WEEKLY;BYDAY=XX", wantErr: true},
+		{name: "bymonthday di luar 1-31", value: "FREQ=MONTHLY;BYMONTHDAY=32", wantErr: true},
+		{name: "byday untuk monthly", value: "FREQ=MONTHLY;BYDAY=MO", wantErr: true},
+		{name: "bymonthday untuk weekly", value: "FREQ=WEEKLY;BYMONTHDAY=1", wantErr: true},
+		{name: "byday untuk yearly", value: "FREQ=YEARLY;BYDAY=MO", wantErr: true},
+		{name: "until dan count bersamaan", value: "FREQ=DAILY;COUNT=2;UNTIL=20251231", wantErr: true},
+		{name: "count 0", value: "FREQ=DAILY;COUNT=0", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRecurrenceRule(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseRecurrenceRule(%q) = %q, want error", tt.value, rule.String())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseRecurrenceRule(%q): %v", tt.value, err)
+			}
+			if got := rule.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecurrenceRuleNext(t *testing.T) {
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 9, 0, 0, 0, time.UTC)
+	}
+	monday := at(2025, time.January, 6)
+
+	tests := []struct {
+		name            string
+		rule            string
+		anchor          time.Time
+		occurrenceIndex int
+		want            time.Time
+		wantOK          bool
+	}{
+		{name: "daily", rule: "FREQ=DAILY", anchor: monday, want: at(2025, time.January, 7), wantOK: true},
+		{name: "daily interval", rule: "FREQ=DAILY;INTERVAL=3", anchor: monday, want: at(2025, time.January, 9), wantOK: true},
+		{name: "daily byday", rule: "FREQ=DAILY;BYDAY=MO,WE,FR", anchor: monday, want: at(2025, time.January, 8), wantOK: true},
+		{name: "daily byday melewati akhir pekan", rule: "FREQ=DAILY;BYDAY=MO,WE,FR", anchor: at(2025, time.January, 10), want: at(2025, time.January, 13), wantOK: true},
+		{name: "weekly", rule: "FREQ=WEEKLY", anchor: monday, want: at(2025, time.January, 13), wantOK: true},
+		{name: "weekly byday minggu yang sama", rule: "FREQ=WEEKLY;BYDAY=MO,TH", anchor: monday, want: at(2025, time.January, 9), wantOK: true},
+		{name: "weekly interval lompat ke minggu berikutnya", rule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH", anchor: at(2025, time.January, 9), want: at(2025, time.January, 20), wantOK: true},
+		{name: "weekly byday minggu dimulai senin", rule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=SU", anchor: monday, want: at(2025, time.January, 12), wantOK: true},
+		{name: "monthly", rule: "FREQ=MONTHLY", anchor: at(2025, time.January, 15), want: at(2025, time.February, 15), wantOK: true},
+		{name: "monthly tanggal 31 melewati bulan pendek", rule: "FREQ=MONTHLY", anchor: at(2025, time.January, 31), want: at(2025, time.March, 31), wantOK: true},
+		{name: "monthly bymonthday bulan yang sama", rule: "FREQ=MONTHLY;BYMONTHDAY=1,15", anchor: monday, want: at(2025, time.January, 15), wantOK: true},
+		{name: "monthly bymonthday bulan berikutnya", rule: "FREQ=MONTHLY;BYMONTHDAY=1,15", anchor: at(2025, time.January, 15), want: at(2025, time.February, 1), wantOK: true},
+		{name: "yearly", rule: "FREQ=YEARLY", anchor: monday, want: at(2026, time.January, 6), wantOK: true},
+		{name: "yearly 29 februari", rule: "FREQ=YEARLY", anchor: at(2024, time.February, 29), want: at(2028, time.February, 29), wantOK: true},
+		{name: "count belum habis", rule: "FREQ=DAILY;COUNT=3", anchor: monday, occurrenceIndex: 2, want: at(2025, time.January, 7), wantOK: true},
+		{name: "count habis", rule: "FREQ=DAILY;COUNT=3", anchor: monday, occurrenceIndex: 3},
+		{name: "sebelum until", rule: "FREQ=DAILY;UNTIL=20250108T090000Z", anchor: at(2025, time.January, 7), want: at(2025, time.January, 8), wantOK: true},
+		{name: "melewati until", rule: "FREQ=DAILY;UNTIL=20250108T000000Z", anchor: at(2025, time.January, 7)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRecurrenceRule(tt.rule)
+			if err != nil {
+				t.Fatalf("ParseRecurrenceRule(%q): %v", tt.rule, err)
+			}
+			index := tt.occurrenceIndex
+			if index == 0 {
+				index = 1
+			}
+			got, ok := rule.Next(tt.anchor, index)
+			if ok != tt.wantOK {
+				t.Fatalf("Next() ok = %v, want %v (got %v)", ok, tt.wantOK, got)
+			}
+			if ok && !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecurrenceRuleNextKeepsLocalTimeAcrossDST(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("timezone data not available: %v", err)
+	}
+	rule, err := ParseRecurrenceRule("FREQ=DAILY")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// DST dimulai 9 Maret 2025 di New York
+	anchor := time.Date(2025, time.March, 8, 9, 0, 0, 0, loc)
+	got, ok := rule.Next(anchor, 1)
+	if !ok {
+		t.Fatal("Next() ok = false, want true")
+	}
+	if want := time.Date(2025, time.March, 9, 9, 0, 0, 0, loc); !got.Equal(want) {
+		t.Errorf("Next() = %v, want %v", got, want)
+	}
+	if got.Sub(anchor) != 23*time.Hour {
+		t.Errorf("Next() - anchor = %v, want 23h", got.Sub(anchor))
+	}
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"errors"
+	"rest-api/internal/dto/response"
+	"rest-api/internal/models"
+	"rest-api/internal/repositories"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ClientInfo berisi informasi perangkat yang dicatat saat login
+type ClientInfo struct {
+	UserAgent string
+	IPAddress string
+}
+
+type SessionService interface {
+	GetSessions(userID uint, currentFamilyID string) ([]response.SessionResponse, error)
+	RevokeSession(userID, sessionID uint) error
+	RevokeAllSessions(userID uint) error
+}
+
+type sessionService struct {
+	sessionRepo repositories.SessionRepository
+	tokenRepo   repositories.TokenRepository
+}
+
+// GetSessions implements SessionService.
+// Session yang sedang dipakai request ini ditandai current
+func (s *sessionService) GetSessions(userID uint, currentFamilyID string) ([]response.SessionResponse, error) {
+	sessions, err := s.sessionRepo.FindActiveByUserID(userID)
+	if err != nil {
+		return nil, errors.New("failed to retrieve sessions")
+	}
+	sessionResponses := make([]response.SessionResponse, 0, len(sessions))
+	for i := range sessions {
+		sessionResponse := toSessionResponse(&sessions[i])
+		sessionResponse.Current = sessions[i].FamilyID == currentFamilyID
+		sessionResponses = append(sessionResponses, *sessionResponse)
+	}
+	return sessionResponses, nil
+}
+
+// RevokeSession implements SessionService.
+// Semua token dari session tersebut langsung tidak bisa dipakai lagi
+func (s *sessionService) RevokeSession(userID uint, sessionID uint) error {
+	session, err := s.sessionRepo.FindByID(sessionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("session not found")
+		}
+		return errors.New("failed to retrieve session")
+	}
+	if session.UserID != userID || session.RevokedAt != nil {
+		return errors.New("session not found")
+	}
+	now := time.Now().UTC()
+	if err := s.sessionRepo.Revoke(session, now); err != nil {
+		return errors.New("failed to revoke session")
+	}
+	if err := s.tokenRepo.RevokeFamily(session.FamilyID, now); err != nil {
+		return errors.New("failed to revoke session")
+	}
+	return nil
+}
+
+// RevokeAllSessions implements SessionService.
+// Log out everywhere, termasuk session yang sedang dipakai
+func (s *sessionService) RevokeAllSessions(userID uint) error {
+	now := time.Now().UTC()
+	if err := s.sessionRepo.RevokeAllByUserID(userID, now); err != nil {
+		return errors.New("failed to revoke sessions")
+	}
+	if err := s.tokenRepo.RevokeAllByUserID(userID, now); err != nil {
+		return errors.New("failed to revoke sessions")
+	}
+	return nil
+}
+
+func toSessionResponse(session *models.Session) *response.SessionResponse {
+	return &response.SessionResponse{
+		ID:         session.ID,
+		UserAgent:  session.UserAgent,
+		IPAddress:  session.IPAddress,
+		CreatedAt:  session.CreatedAt,
+		LastSeenAt: session.LastSeenAt,
+	}
+}
+
+func NewSessionService(sessionRepo repositories.SessionRepository, tokenRepo repositories.TokenRepository) SessionService {
+	return &sessionService{sessionRepo: sessionRepo, tokenRepo: tokenRepo}
+}
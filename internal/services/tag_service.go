@@ -0,0 +1,186 @@
+package services
+
+import (
+	"errors"
+	"rest-api/internal/dto/request"
+	"rest-api/internal/dto/response"
+	"rest-api/internal/models"
+	"rest-api/internal/repositories"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type TagService interface {
+	CreateTag(userID uint, req request.TagCreateRequest) (*response.TagResponse, error)
+	GetTagsByUserID(userID uint) ([]response.TagResponse, error)
+	GetTagByID(userID, tagID uint) (*response.TagResponse, error)
+	UpdateTag(userID, tagID uint, req request.TagUpdateRequest) (*response.TagResponse, error)
+	MergeTag(userID, sourceTagID, targetTagID uint) (*response.TagResponse, error)
+	DeleteTag(userID, tagID uint) error
+}
+
+type tagService struct {
+	tagRepo repositories.TagRepository
+}
+
+// CreateTag implements TagService.
+func (s *tagService) CreateTag(userID uint, req request.TagCreateRequest) (*response.TagResponse, error) {
+	name := normalizeTagName(req.Name)
+	if name == "" {
+		return nil, errors.New("tag name is required")
+	}
+	if req.Color != "" && !hexColorPattern.MatchString(req.Color) {
+		return nil, errors.New("invalid tag color")
+	}
+	if err := s.checkNameAvailability(userID, name, 0); err != nil {
+		return nil, err
+	}
+
+	tag := &models.Tag{
+		UserID: userID,
+		Name:   name,
+		Color:  req.Color,
+	}
+	if err := s.tagRepo.Create(tag); err != nil {
+		return nil, errors.New("failed to create tag")
+	}
+	return toTagResponse(tag), nil
+}
+
+// GetTagsByUserID implements TagService.
+func (s *tagService) GetTagsByUserID(userID uint) ([]response.TagResponse, error) {
+	tags, err := s.tagRepo.FindAllByUserID(userID)
+	if err != nil {
+		return nil, errors.New("failed to retrieve tags")
+	}
+	return toTagResponses(tags), nil
+}
+
+// GetTagByID implements TagService.
+func (s *tagService) GetTagByID(userID uint, tagID uint) (*response.TagResponse, error) {
+	tag, err := s.findOwnedTag(userID, tagID)
+	if err != nil {
+		return nil, err
+	}
+	return toTagResponse(tag), nil
+}
+
+// UpdateTag implements TagService.
+// Rename ke nama yang sudah dipakai tag lain ditolak, gunakan MergeTag untuk menggabungkan
+func (s *tagService) UpdateTag(userID uint, tagID uint, req request.TagUpdateRequest) (*response.TagResponse, error) {
+	tag, err := s.findOwnedTag(userID, tagID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name := normalizeTagName(*req.Name)
+		if name == "" {
+			return nil, errors.New("tag name is required")
+		}
+		if name != tag.Name {
+			if err := s.checkNameAvailability(userID, name, tag.ID); err != nil {
+				return nil, err
+			}
+		}
+		tag.Name = name
+	}
+	if req.Color != nil {
+		if *req.Color != "" && !hexColorPattern.MatchString(*req.Color) {
+			return nil, errors.New("invalid tag color")
+		}
+		tag.Color = *req.Color
+	}
+
+	if err := s.tagRepo.Update(tag); err != nil {
+		return nil, errors.New("failed to update tag")
+	}
+	return toTagResponse(tag), nil
+}
+
+// MergeTag implements TagService.
+// Semua task dengan tag sumber dipindahkan ke tag tujuan, lalu tag sumber dihapus
+func (s *tagService) MergeTag(userID uint, sourceTagID uint, targetTagID uint) (*response.TagResponse, error) {
+	if sourceTagID == targetTagID {
+		return nil, errors.New("cannot merge a tag into itself")
+	}
+	source, err := s.findOwnedTag(userID, sourceTagID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.findOwnedTag(userID, targetTagID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.tagRepo.Merge(source, target); err != nil {
+		return nil, errors.New("failed to merge tags")
+	}
+	return toTagResponse(target), nil
+}
+
+// DeleteTag implements TagService.
+func (s *tagService) DeleteTag(userID uint, tagID uint) error {
+	tag, err := s.findOwnedTag(userID, tagID)
+	if err != nil {
+		return err
+	}
+	if err := s.tagRepo.Delete(tag); err != nil {
+		return errors.New("failed to delete tag")
+	}
+	return nil
+}
+
+// findOwnedTag mengambil tag dan memastikan tag milik user
+func (s *tagService) findOwnedTag(userID, tagID uint) (*models.Tag, error) {
+	tag, err := s.tagRepo.FindByID(tagID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("tag not found")
+		}
+		return nil, errors.New("failed to retrieve tag")
+	}
+	if tag.UserID != userID {
+		return nil, errors.New("unauthorized to access this tag")
+	}
+	return tag, nil
+}
+
+// checkNameAvailability memastikan nama tag unik per user
+func (s *tagService) checkNameAvailability(userID uint, name string, excludeTagID uint) error {
+	existing, err := s.tagRepo.FindByName(userID, name)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.New("failed to check tag name")
+	}
+	if existing != nil && existing.ID != excludeTagID {
+		return errors.New("tag name already in use")
+	}
+	return nil
+}
+
+// normalizeTagName merapikan nama tag: trim spasi dan lowercase ("Bug " -> "bug")
+func normalizeTagName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+func toTagResponse(tag *models.Tag) *response.TagResponse {
+	return &response.TagResponse{
+		ID:        tag.ID,
+		Name:      tag.Name,
+		Color:     tag.Color,
+		CreatedAt: tag.CreatedAt,
+		UpdatedAt: tag.UpdatedAt,
+	}
+}
+
+func toTagResponses(tags []models.Tag) []response.TagResponse {
+	tagResponses := make([]response.TagResponse, 0, len(tags))
+	for i := range tags {
+		tagResponses = append(tagResponses, *toTagResponse(&tags[i]))
+	}
+	return tagResponses
+}
+
+func NewTagService(tagRepo repositories.TagRepository) TagService {
+	return &tagService{tagRepo: tagRepo}
+}
@@ -0,0 +1,227 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"rest-api/internal/dto/request"
+	"rest-api/internal/dto/response"
+	"rest-api/internal/models"
+	"rest-api/internal/repositories"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+// maxBulkTasks membatasi jumlah task dalam satu bulk operation
+const maxBulkTasks = 500
+
+// BulkTasks implements TaskService.
+// Semua task diproses dalam satu transaksi; setiap task berjalan di savepoint sendiri
+// sehingga kegagalan satu task (tidak ditemukan, bukan milik user, transisi status ditolak)
+// hanya membatalkan perubahan task tersebut dan dilaporkan di hasil per item
+func (t *taskService) BulkTasks(userID uint, req request.TaskBulkRequest) (*response.TaskBulkResponse, error) {
+	apply, err := t.bulkAction(userID, req)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := t.bulkTargets(userID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &response.TaskBulkResponse{
+		Action:  req.Action,
+		Results: make([]response.TaskBulkItemResult, 0, len(ids)),
+	}
+	index := &deferredTaskSearcher{TaskSearcher: t.searcher}
+	err = t.taskRepo.Transaction(func(txRepo repositories.TaskRepository) error {
+		// Service yang terikat ke transaksi; perubahan index pencarian ditunda sampai commit
+		txService := *t
+		txService.taskRepo = txRepo
+		for _, id := range ids {
+			itemIndex := &deferredTaskSearcher{TaskSearcher: index}
+			itemErr := txRepo.Transaction(func(itemRepo repositories.TaskRepository) error {
+				itemService := txService
+				itemService.taskRepo = itemRepo
+				itemService.searcher = itemIndex
+				return apply(&itemService, id)
+			})
+			item := response.TaskBulkItemResult{ID: id, Success: itemErr == nil}
+			if itemErr != nil {
+				item.Error = itemErr.Error()
+				result.Failed++
+			} else {
+				// Perubahan index task yang gagal dibuang bersama savepoint-nya
+				itemIndex.flush()
+				result.Succeeded++
+			}
+			result.Results = append(result.Results, item)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.New("failed to apply bulk operation")
+	}
+	index.flush()
+	return result, nil
+}
+
+// bulkAction memvalidasi parameter aksi satu kali di awal
+// dan mengembalikan fungsi yang menerapkan aksi ke satu task
+func (t *taskService) bulkAction(userID uint, req request.TaskBulkRequest) (func(s *taskService, taskID uint) error, error) {
+	switch req.Action {
+	case request.BulkActionComplete:
+		done := models.TaskStatusDone
+		changes := request.TaskUpdateRequest{Status: &done, CompleteSubtasks: req.CompleteSubtasks}
+		return func(s *taskService, taskID uint) error {
+			_, err := s.UpdateTask(userID, taskID, changes)
+			return err
+		}, nil
+
+	case request.BulkActionUpdate:
+		if req.Changes == nil {
+			return nil, errors.New("changes are required for update action")
+		}
+		changes := *req.Changes
+		return func(s *taskService, taskID uint) error {
+			_, err := s.UpdateTask(userID, taskID, changes)
+			return err
+		}, nil
+
+	case request.BulkActionDelete:
+		return func(s *taskService, taskID uint) error {
+			return s.DeleteTask(userID, taskID)
+		}, nil
+
+	case request.BulkActionMove:
+		if req.ProjectID != nil {
+			if _, err := t.findWritableProject(userID, *req.ProjectID); err != nil {
+				return nil, err
+			}
+		}
+		return func(s *taskService, taskID uint) error {
+			_, err := s.MoveTask(userID, taskID, req.ProjectID)
+			return err
+		}, nil
+
+	case request.BulkActionAddTags, request.BulkActionRemoveTags:
+		if len(req.TagIDs) == 0 {
+			return nil, errors.New("tagIds are required for tag actions")
+		}
+		tags, err := t.findOwnedTags(userID, req.TagIDs)
+		if err != nil {
+			return nil, err
+		}
+		adding := req.Action == request.BulkActionAddTags
+		return func(s *taskService, taskID uint) error {
+			task, err := s.findOwnedTask(userID, taskID)
+			if err != nil {
+				return err
+			}
+			tagIDs := retagIDs(task.Tags, tags, adding)
+			_, err = s.UpdateTask(userID, taskID, request.TaskUpdateRequest{TagIDs: &tagIDs})
+			return err
+		}, nil
+	}
+	return nil, errors.New("invalid bulk action")
+}
+
+// bulkTargets menentukan ID task yang diproses dari ids atau filter
+func (t *taskService) bulkTargets(userID uint, req request.TaskBulkRequest) ([]uint, error) {
+	if len(req.IDs) > 0 && req.Filter != nil {
+		return nil, errors.New("provide either ids or filter, not both")
+	}
+	if req.Filter == nil {
+		ids := uniqueIDs(req.IDs)
+		if len(ids) == 0 {
+			return nil, errors.New("ids or filter is required")
+		}
+		if len(ids) > maxBulkTasks {
+			return nil, errors.New("bulk operation is limited to " + strconv.Itoa(maxBulkTasks) + " tasks")
+		}
+		return ids, nil
+	}
+
+	filter, err := t.buildTaskFilter(*req.Filter)
+	if err != nil {
+		return nil, err
+	}
+	// Ambil satu lebih dari batas untuk mendeteksi filter yang terlalu luas
+	ids, err := t.taskRepo.FindIDsByUserID(userID, filter, maxBulkTasks+1)
+	if err != nil {
+		return nil, errors.New("failed to retrieve tasks")
+	}
+	if len(ids) > maxBulkTasks {
+		return nil, errors.New("bulk operation is limited to " + strconv.Itoa(maxBulkTasks) + " tasks")
+	}
+	return ids, nil
+}
+
+// findOwnedTask mengambil task dan memastikan task milik user
+func (t *taskService) findOwnedTask(userID, taskID uint) (*models.Task, error) {
+	task, err := t.taskRepo.FindByID(taskID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("task not found")
+		}
+		return nil, errors.New("failed to retrieve task")
+	}
+	if task.UserID != userID {
+		return nil, errors.New("unauthorized to update this task")
+	}
+	return task, nil
+}
+
+// retagIDs menghitung daftar ID tag baru setelah tags ditambahkan atau dilepas
+func retagIDs(current, tags []models.Tag, adding bool) []uint {
+	changed := make(map[uint]bool, len(tags))
+	for _, tag := range tags {
+		changed[tag.ID] = true
+	}
+	ids := make([]uint, 0, len(current)+len(tags))
+	for _, tag := range current {
+		if !changed[tag.ID] {
+			ids = append(ids, tag.ID)
+		}
+	}
+	if adding {
+		for _, tag := range tags {
+			ids = append(ids, tag.ID)
+		}
+	}
+	return ids
+}
+
+// deferredTaskSearcher menampung perubahan index pencarian selama transaksi bulk
+// dan baru meneruskannya ke searcher di bawahnya saat flush dipanggil (setelah commit)
+type deferredTaskSearcher struct {
+	repositories.TaskSearcher
+	indexed []*models.Task
+	removed []uint
+}
+
+// Index implements repositories.TaskSearcher.
+func (d *deferredTaskSearcher) Index(task *models.Task) error {
+	d.indexed = append(d.indexed, task)
+	return nil
+}
+
+// Remove implements repositories.TaskSearcher.
+func (d *deferredTaskSearcher) Remove(taskIDs ...uint) error {
+	d.removed = append(d.removed, taskIDs...)
+	return nil
+}
+
+// flush meneruskan perubahan yang ditampung ke searcher di bawahnya
+func (d *deferredTaskSearcher) flush() {
+	for _, task := range d.indexed {
+		if err := d.TaskSearcher.Index(task); err != nil {
+			log.Printf("Warning: failed to index task %d: %v", task.ID, err)
+		}
+	}
+	if len(d.removed) > 0 {
+		if err := d.TaskSearcher.Remove(d.removed...); err != nil {
+			log.Printf("Warning: failed to remove tasks %v from search index: %v", d.removed, err)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"errors"
+	"rest-api/internal/dto/response"
+	"rest-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// maxTaskDepth adalah jumlah level maksimum task (task utama = level 1)
+// 3 berarti task -> subtask -> sub-subtask
+const maxTaskDepth = 3
+
+// GetSubtasks implements TaskService.
+// Mengembalikan subtask langsung dari sebuah task, list kosong jika tidak ada
+func (t *taskService) GetSubtasks(userID uint, taskID uint) ([]response.TaskResponse, error) {
+	task, err := t.taskRepo.FindByID(taskID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("task not found")
+		}
+		return nil, errors.New("failed to retrieve task")
+	}
+	if task.UserID != userID {
+		return nil, errors.New("unauthorized to access this task")
+	}
+
+	children, err := t.taskRepo.FindChildren(task.ID)
+	if err != nil {
+		return nil, errors.New("failed to retrieve tasks")
+	}
+	taskResponses := make([]response.TaskResponse, 0, len(children))
+	for i := range children {
+		taskResponses = append(taskResponses, *t.toTaskResponse(&children[i]))
+	}
+	return taskResponses, nil
+}
+
+// findParentTask mengambil calon parent milik user
+// Parent milik user lain dianggap tidak ada agar tidak membocorkan keberadaan task
+func (t *taskService) findParentTask(userID, parentID uint) (*models.Task, error) {
+	parent, err := t.taskRepo.FindByID(parentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("parent task not found")
+		}
+		return nil, errors.New("failed to retrieve task")
+	}
+	if parent.UserID != userID {
+		return nil, errors.New("parent task not found")
+	}
+	return parent, nil
+}
+
+// taskDepth menghitung level task dengan menelusuri parent ke atas (task utama = 1)
+func (t *taskService) taskDepth(task *models.Task) (int, error) {
+	depth := 1
+	parentID := task.ParentID
+	for parentID != nil {
+		depth++
+		if depth > maxTaskDepth+1 {
+			break
+		}
+		parent, err := t.taskRepo.FindByID(*parentID)
+		if err != nil {
+			return 0, errors.New("failed to retrieve task")
+		}
+		parentID = parent.ParentID
+	}
+	return depth, nil
+}
+
+// attachToParent memvalidasi dan memasang task sebagai subtask dari parentID
+// Mengecek parent bukan task itu sendiri atau subtask-nya (siklus), dan kedalaman subtree tetap dalam batas
+func (t *taskService) attachToParent(userID uint, task *models.Task, parentID uint) (*models.Task, error) {
+	if task.ID != 0 && task.ID == parentID {
+		return nil, errors.New("task cannot be its own parent")
+	}
+	parent, err := t.findParentTask(userID, parentID)
+	if err != nil {
+		return nil, err
+	}
+	parentDepth, err := t.taskDepth(parent)
+	if err != nil {
+		return nil, err
+	}
+
+	subtreeHeight := 1
+	if task.ID != 0 {
+		levels, err := t.taskRepo.FindSubtreeLevels(task.ID)
+		if err != nil {
+			return nil, errors.New("failed to retrieve task")
+		}
+		for _, level := range levels {
+			for _, id := range level {
+				if id == parent.ID {
+					return nil, errors.New("cannot move a task under its own subtask")
+				}
+			}
+		}
+		subtreeHeight += len(levels)
+	}
+	if parentDepth+subtreeHeight > maxTaskDepth {
+		return nil, errors.New("maximum subtask depth exceeded")
+	}
+
+	task.ParentID = &parent.ID
+	return parent, nil
+}
+
+// subtasksToComplete menentukan subtask yang harus ikut diselesaikan saat task diubah ke done
+// Jika masih ada subtask langsung yang belum selesai:
+//   - completeSubtasks = true: mengembalikan ID seluruh subtask (semua level)
+//   - completeSubtasks = false: perubahan ditolak
+func (t *taskService) subtasksToComplete(task *models.Task, completeSubtasks bool) ([]uint, error) {
+	hasOpenChildren := false
+	for _, child := range task.Children {
+		if !isClosedTaskStatus(child.Status) {
+			hasOpenChildren = true
+			break
+		}
+	}
+	if !hasOpenChildren {
+		return nil, nil
+	}
+	if !completeSubtasks {
+		return nil, errors.New("task has incomplete subtasks")
+	}
+
+	levels, err := t.taskRepo.FindSubtreeLevels(task.ID)
+	if err != nil {
+		return nil, errors.New("failed to retrieve task")
+	}
+	var ids []uint
+	for _, level := range levels {
+		ids = append(ids, level...)
+	}
+	return ids, nil
+}
+
+// subtaskProgress menghitung progress subtask langsung, subtask cancelled tidak dihitung
+func subtaskProgress(children []models.Task) response.ProgressResponse {
+	progress := response.ProgressResponse{}
+	for _, child := range children {
+		switch child.Status {
+		case models.TaskStatusCancelled:
+			continue
+		case models.TaskStatusDone:
+			progress.Done++
+		}
+		progress.Total++
+	}
+	return progress
+}
+
+// checklistProgress menghitung jumlah item checklist yang sudah dicentang
+func checklistProgress(items []models.ChecklistItem) response.ProgressResponse {
+	progress := response.ProgressResponse{Total: len(items)}
+	for _, item := range items {
+		if item.IsDone {
+			progress.Done++
+		}
+	}
+	return progress
+}
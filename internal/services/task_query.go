@@ -0,0 +1,275 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"rest-api/internal/dto/request"
+	"rest-api/internal/dto/response"
+	"rest-api/internal/models"
+	"rest-api/internal/repositories"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultTaskPageSize = 20
+	maxTaskPageSize     = 100
+)
+
+// GetTasksByUserID implements TaskService.
+// Mengembalikan satu halaman task sesuai filter, sort dan cursor di query
+// Hasil kosong tetap dikembalikan sebagai list kosong
+func (t *taskService) GetTasksByUserID(userID uint, query request.TaskListQuery) (*response.TaskListResponse, error) {
+	filter, err := t.buildTaskFilter(query)
+	if err != nil {
+		return nil, err
+	}
+	page, err := buildTaskPage(query)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, next, total, err := t.taskRepo.FindAllByUserID(userID, filter, page)
+	if err != nil {
+		if errors.Is(err, repositories.ErrInvalidCursor) {
+			return nil, errors.New("invalid cursor")
+		}
+		return nil, errors.New("failed to retrieve tasks")
+	}
+
+	taskResponses := make([]response.TaskResponse, 0, len(tasks))
+	for i := range tasks {
+		taskResponses = append(taskResponses, *t.toTaskResponse(&tasks[i]))
+	}
+	result := &response.TaskListResponse{
+		Tasks: taskResponses,
+		Page: response.PageResponse{
+			TotalCount: total,
+			Limit:      page.Limit,
+		},
+	}
+	if next != nil {
+		cursor := encodeTaskCursor(next)
+		result.Page.NextCursor = &cursor
+	}
+	return result, nil
+}
+
+// buildTaskFilter mengubah query string menjadi repositories.TaskFilter
+func (t *taskService) buildTaskFilter(query request.TaskListQuery) (repositories.TaskFilter, error) {
+	filter := repositories.TaskFilter{}
+
+	loc := time.UTC
+	if query.TimeZone != "" {
+		parsed, err := time.LoadLocation(query.TimeZone)
+		if err != nil {
+			return filter, errors.New("invalid time zone")
+		}
+		loc = parsed
+	}
+
+	if query.Due != "" {
+		if !isValidDueState(query.Due) {
+			return filter, errors.New("invalid due filter")
+		}
+		now := t.now().In(loc)
+		filter.DueState = query.Due
+		filter.Now = now.UTC()
+		filter.DayStart = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc).UTC()
+		filter.DayEnd = time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, loc).UTC()
+	}
+
+	if query.Tags != "" {
+		tagIDs, err := parseIDList(query.Tags)
+		if err != nil {
+			return filter, errors.New("invalid tag filter")
+		}
+		filter.TagIDs = tagIDs
+	}
+	switch query.TagMode {
+	case "", "any":
+	case "all":
+		filter.MatchAllTags = true
+	default:
+		return filter, errors.New("invalid tag filter")
+	}
+
+	if query.ProjectID != "" {
+		projectID, err := strconv.ParseUint(query.ProjectID, 10, 64)
+		if err != nil {
+			return filter, errors.New("invalid project filter")
+		}
+		id := uint(projectID)
+		filter.ProjectID = &id
+	}
+
+	if query.Status != "" {
+		for _, status := range strings.Split(query.Status, ",") {
+			status = strings.TrimSpace(status)
+			if !isValidTaskStatus(status) {
+				return filter, errors.New("invalid status filter")
+			}
+			filter.Statuses = append(filter.Statuses, status)
+		}
+	}
+	switch query.Completed {
+	case "":
+	case "true":
+		filter.Statuses = intersectStatuses(filter.Statuses, []string{models.TaskStatusDone, models.TaskStatusCancelled})
+	case "false":
+		filter.Statuses = intersectStatuses(filter.Statuses, []string{models.TaskStatusTodo, models.TaskStatusInProgress, models.TaskStatusBlocked})
+	default:
+		return filter, errors.New("invalid status filter")
+	}
+
+	filter.Search = strings.TrimSpace(query.Search)
+
+	var err error
+	if filter.DueFrom, err = parseQueryTime(query.DueFrom, loc, false); err != nil {
+		return filter, err
+	}
+	if filter.DueTo, err = parseQueryTime(query.DueTo, loc, true); err != nil {
+		return filter, err
+	}
+	if filter.CreatedFrom, err = parseQueryTime(query.CreatedFrom, loc, false); err != nil {
+		return filter, err
+	}
+	if filter.CreatedTo, err = parseQueryTime(query.CreatedTo, loc, true); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
+
+// buildTaskPage membaca sort, order, limit dan cursor dari query string
+func buildTaskPage(query request.TaskListQuery) (repositories.TaskPage, error) {
+	page := repositories.TaskPage{Sort: query.Sort, Limit: query.Limit}
+	if page.Sort == "" {
+		page.Sort = repositories.TaskSortCreated
+	}
+
+	switch page.Sort {
+	case repositories.TaskSortCreated, repositories.TaskSortUpdated, repositories.TaskSortPriority:
+		page.Desc = true
+	case repositories.TaskSortDue, repositories.TaskSortTitle:
+		page.Desc = false
+	default:
+		return page, errors.New("invalid sort field")
+	}
+	switch query.Order {
+	case "":
+	case "asc":
+		page.Desc = false
+	case "desc":
+		page.Desc = true
+	default:
+		return page, errors.New("invalid sort order")
+	}
+
+	if page.Limit <= 0 {
+		page.Limit = defaultTaskPageSize
+	}
+	if page.Limit > maxTaskPageSize {
+		page.Limit = maxTaskPageSize
+	}
+
+	if query.Cursor != "" {
+		cursor, err := decodeTaskCursor(query.Cursor)
+		if err != nil {
+			return page, errors.New("invalid cursor")
+		}
+		page.After = cursor
+	}
+	return page, nil
+}
+
+// encodeTaskCursor mengubah cursor menjadi string opaque (base64 URL-safe)
+func encodeTaskCursor(cursor *repositories.TaskCursor) string {
+	payload, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+func decodeTaskCursor(value string) (*repositories.TaskCursor, error) {
+	payload, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	var cursor repositories.TaskCursor
+	if err := json.Unmarshal(payload, &cursor); err != nil {
+		return nil, err
+	}
+	return &cursor, nil
+}
+
+// parseQueryTime membaca waktu dari query string (RFC 3339 atau YYYY-MM-DD)
+// Untuk tanggal saja dan endOfRange = true, hasilnya awal hari berikutnya agar batas atas inklusif
+func parseQueryTime(value string, loc *time.Location, endOfRange bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		utc := parsed.UTC()
+		return &utc, nil
+	}
+	parsed, err := time.ParseInLocation("2006-01-02", value, loc)
+	if err != nil {
+		return nil, errors.New("invalid date filter")
+	}
+	if endOfRange {
+		parsed = parsed.AddDate(0, 0, 1)
+	}
+	utc := parsed.UTC()
+	return &utc, nil
+}
+
+// intersectStatuses menggabungkan filter status eksplisit dengan filter completed
+// Jika tidak ada status eksplisit, allowed dipakai apa adanya
+func intersectStatuses(statuses, allowed []string) []string {
+	if len(statuses) == 0 {
+		return allowed
+	}
+	var result []string
+	for _, status := range statuses {
+		for _, candidate := range allowed {
+			if status == candidate {
+				result = append(result, status)
+			}
+		}
+	}
+	if len(result) == 0 {
+		// Kombinasi yang tidak mungkin cocok (contoh: status=todo&completed=true)
+		return []string{""}
+	}
+	return result
+}
+
+// parseIDList membaca daftar ID yang dipisah koma, contoh: "1,4,7"
+func parseIDList(value string) ([]uint, error) {
+	var ids []uint
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := strconv.ParseUint(part, 10, 64)
+		if err != nil || id == 0 {
+			return nil, errors.New("invalid id: " + part)
+		}
+		ids = append(ids, uint(id))
+	}
+	return uniqueIDs(ids), nil
+}
+
+// uniqueIDs menghapus ID duplikat dengan tetap menjaga urutan
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	unique := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
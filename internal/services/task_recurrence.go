@@ -0,0 +1,123 @@
+package services
+
+import (
+	"errors"
+	"rest-api/internal/dto/response"
+	"rest-api/internal/models"
+	"rest-api/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+// GetSeries implements TaskService.
+// Mengembalikan seluruh occurrence dari series task berulang, termasuk yang sudah selesai
+func (t *taskService) GetSeries(userID uint, taskID uint) ([]response.TaskResponse, error) {
+	task, err := t.taskRepo.FindByID(taskID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("task not found")
+		}
+		return nil, errors.New("failed to retrieve task")
+	}
+	if task.UserID != userID {
+		return nil, errors.New("unauthorized to access this task")
+	}
+
+	tasks, err := t.taskRepo.FindSeries(seriesRootID(task))
+	if err != nil {
+		return nil, errors.New("failed to retrieve tasks")
+	}
+	taskResponses := make([]response.TaskResponse, 0, len(tasks))
+	for i := range tasks {
+		taskResponses = append(taskResponses, *t.toTaskResponse(&tasks[i]))
+	}
+	return taskResponses, nil
+}
+
+// spawnNextOccurrence membuat occurrence berikutnya dari task berulang yang baru diselesaikan
+// Jadwal dihitung dari due date (atau start date, atau waktu selesai jika task tanpa tanggal)
+// Tidak melakukan apa-apa jika task tidak berulang, series sudah selesai (UNTIL/COUNT),
+// atau occurrence berikutnya sudah pernah dibuat (task dibuka lalu diselesaikan lagi)
+// Mengembalikan occurrence yang dibuat, atau nil jika tidak ada
+func (t *taskService) spawnNextOccurrence(txRepo repositories.TaskRepository, task *models.Task) (*models.Task, error) {
+	if task.RecurrenceRule == "" {
+		return nil, nil
+	}
+	rule, err := ParseRecurrenceRule(task.RecurrenceRule)
+	if err != nil {
+		return nil, nil
+	}
+
+	seriesID := seriesRootID(task)
+	if _, err := txRepo.FindOccurrence(seriesID, task.OccurrenceIndex+1); err == nil {
+		return nil, nil
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	loc := taskLocation(task)
+	anchor := *task.CompletedAt
+	if task.DueAt != nil {
+		anchor = *task.DueAt
+	} else if task.StartAt != nil {
+		anchor = *task.StartAt
+	}
+	anchor = anchor.In(loc)
+	next, ok := rule.Next(anchor, task.OccurrenceIndex)
+	if !ok {
+		return nil, nil
+	}
+
+	now := t.now().UTC()
+	occurrence := &models.Task{
+		UserID:          task.UserID,
+		ProjectID:       task.ProjectID,
+		ParentID:        task.ParentID,
+		Title:           task.Title,
+		Description:     task.Description,
+		Status:          models.TaskStatusTodo,
+		Priority:        task.Priority,
+		TimeZone:        task.TimeZone,
+		StatusChangedAt: &now,
+		RecurrenceRule:  task.RecurrenceRule,
+		SeriesID:        &seriesID,
+		OccurrenceIndex: task.OccurrenceIndex + 1,
+		Tags:            task.Tags,
+	}
+	// Jarak start date ke due date dipertahankan di occurrence berikutnya
+	switch {
+	case task.DueAt != nil:
+		dueAt := next.UTC()
+		occurrence.DueAt = &dueAt
+		if task.StartAt != nil {
+			startAt := dueAt.Add(-task.DueAt.Sub(*task.StartAt))
+			occurrence.StartAt = &startAt
+		}
+	case task.StartAt != nil:
+		startAt := next.UTC()
+		occurrence.StartAt = &startAt
+	default:
+		dueAt := next.UTC()
+		occurrence.DueAt = &dueAt
+	}
+	// Checklist disalin dalam keadaan belum dicentang
+	for _, item := range task.ChecklistItems {
+		occurrence.ChecklistItems = append(occurrence.ChecklistItems, models.ChecklistItem{
+			Title:    item.Title,
+			Position: item.Position,
+		})
+	}
+
+	if err := txRepo.Create(occurrence); err != nil {
+		return nil, err
+	}
+	return occurrence, nil
+}
+
+// seriesRootID mengembalikan ID occurrence pertama dari series
+func seriesRootID(task *models.Task) uint {
+	if task.SeriesID != nil {
+		return *task.SeriesID
+	}
+	return task.ID
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"rest-api/internal/dto/request"
+	"rest-api/internal/dto/response"
+	"rest-api/internal/models"
+	"rest-api/internal/repositories"
+)
+
+// Batas jumlah hasil per halaman pencarian
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+// SearchTasks implements TaskService.
+// Hasil diurutkan berdasarkan relevansi, dipaginasi dengan limit/offset
+func (t *taskService) SearchTasks(userID uint, query request.TaskSearchQuery) (*response.TaskSearchResponse, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+	if query.Offset < 0 {
+		return nil, errors.New("invalid offset")
+	}
+
+	hits, total, err := t.searcher.Search(repositories.TaskSearchQuery{
+		UserID: userID,
+		Text:   query.Query,
+		Limit:  limit,
+		Offset: query.Offset,
+	})
+	if err != nil {
+		if errors.Is(err, repositories.ErrEmptySearchQuery) {
+			return nil, errors.New("search query is required")
+		}
+		return nil, errors.New("failed to search tasks")
+	}
+
+	ids := make([]uint, 0, len(hits))
+	for _, hit := range hits {
+		ids = append(ids, hit.TaskID)
+	}
+	tasks, err := t.taskRepo.FindByIDs(ids)
+	if err != nil {
+		return nil, errors.New("failed to retrieve tasks")
+	}
+	byID := make(map[uint]*models.Task, len(tasks))
+	for i := range tasks {
+		byID[tasks[i].ID] = &tasks[i]
+	}
+
+	results := make([]response.TaskSearchResult, 0, len(hits))
+	for _, hit := range hits {
+		// Task yang sudah dihapus tapi masih ada di index dilewati
+		task, ok := byID[hit.TaskID]
+		if !ok || task.UserID != userID {
+			continue
+		}
+		results = append(results, response.TaskSearchResult{
+			Task:  *t.toTaskResponse(task),
+			Score: hit.Score,
+		})
+	}
+	return &response.TaskSearchResponse{
+		Results:    results,
+		TotalCount: total,
+		Limit:      limit,
+		Offset:     query.Offset,
+	}, nil
+}
+
+// indexTask memperbarui index pencarian setelah task disimpan
+// Kegagalan hanya dicatat di log agar tidak menggagalkan request
+func (t *taskService) indexTask(task *models.Task) {
+	if err := t.searcher.Index(task); err != nil {
+		log.Printf("Warning: failed to index task %d: %v", task.ID, err)
+	}
+}
+
+// unindexTasks menghapus task dari index pencarian
+func (t *taskService) unindexTasks(ids ...uint) {
+	if err := t.searcher.Remove(ids...); err != nil {
+		log.Printf("Warning: failed to remove tasks %v from search index: %v", ids, err)
+	}
+}
+
+// BuildTaskSearchIndex mengisi index pencarian dari seluruh task di database
+// Dipakai saat startup untuk backend in-memory
+func BuildTaskSearchIndex(taskRepo repositories.TaskRepository, searcher repositories.TaskSearcher) error {
+	tasks, err := taskRepo.FindAllForSearchIndex()
+	if err != nil {
+		return err
+	}
+	for i := range tasks {
+		if err := searcher.Index(&tasks[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
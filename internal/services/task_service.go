@@ -2,38 +2,122 @@ package services
 
 import (
 	"errors"
+	"log"
+	"rest-api/config"
+	"rest-api/internal/dto/request"
+	"rest-api/internal/dto/response"
 	"rest-api/internal/models"
 	"rest-api/internal/repositories"
+	"time"
 
 	"gorm.io/gorm"
 )
 
 type TaskService interface {
-	CreateTask(userID uint, title, description string) (*models.Task, error)
-	GetTasksByUserID(userID uint) ([]models.Task, error)
-	GetTasksByID(id uint) (*models.Task, error)
-	UpdateTask(userID, blogID uint, title, description *string, isCompleted *bool) (*models.Task, error)
+	CreateTask(userID uint, req request.TaskCreateRequest) (*response.TaskResponse, error)
+	GetTasksByUserID(userID uint, query request.TaskListQuery) (*response.TaskListResponse, error)
+	GetTasksByID(id uint) (*response.TaskResponse, error)
+	UpdateTask(userID, taskID uint, req request.TaskUpdateRequest) (*response.TaskResponse, error)
 	DeleteTask(userID, taskID uint) error
+	GetTasksByProjectID(userID, projectID uint) ([]response.TaskResponse, error)
+	MoveTask(userID, taskID uint, projectID *uint) (*response.TaskResponse, error)
+	GetSubtasks(userID, taskID uint) ([]response.TaskResponse, error)
+	GetSeries(userID, taskID uint) ([]response.TaskResponse, error)
+	SearchTasks(userID uint, query request.TaskSearchQuery) (*response.TaskSearchResponse, error)
+	GetTrash(userID uint) ([]response.TaskResponse, error)
+	RestoreTask(userID, taskID uint) (*response.TaskResponse, error)
+	PurgeTask(userID, taskID uint) error
+	EmptyTrash(userID uint) (int, error)
+	BulkTasks(userID uint, req request.TaskBulkRequest) (*response.TaskBulkResponse, error)
 }
 
 type taskService struct {
-	taskRepo repositories.TaskRepository
+	taskRepo    repositories.TaskRepository
+	projectRepo repositories.ProjectRepository
+	tagRepo     repositories.TagRepository
+	searcher    repositories.TaskSearcher
+	workflow    TaskWorkflow
+	now         func() time.Time
 }
 
 // CreateTask implements TaskService.
-func (t *taskService) CreateTask(userID uint, title string, description string) (*models.Task, error) {
-	if title == "" && description == "" {
+func (t *taskService) CreateTask(userID uint, req request.TaskCreateRequest) (*response.TaskResponse, error) {
+	if req.Title == "" && req.Description == "" {
 		return nil, errors.New("title or description must be provided")
-	}	
+	}
+	timeZone := req.TimeZone
+	if timeZone == "" {
+		timeZone = "UTC"
+	}
+	if _, err := time.LoadLocation(timeZone); err != nil {
+		return nil, errors.New("invalid time zone")
+	}
+	status := req.Status
+	if status == "" {
+		status = models.TaskStatusTodo
+	}
+	if !isValidTaskStatus(status) {
+		return nil, errors.New("invalid status")
+	}
+	priority := models.TaskPriorityMedium
+	if req.Priority != "" {
+		value, ok := parseTaskPriority(req.Priority)
+		if !ok {
+			return nil, errors.New("invalid priority")
+		}
+		priority = value
+	}
+	if req.ProjectID != nil {
+		if _, err := t.findWritableProject(userID, *req.ProjectID); err != nil {
+			return nil, err
+		}
+	}
+	tags, err := t.findOwnedTags(userID, req.TagIDs)
+	if err != nil {
+		return nil, err
+	}
+	now := t.now().UTC()
 	task := &models.Task{
-		UserID:      userID,
-		Title:       title,
-		Description: description,
+		UserID:          userID,
+		ProjectID:       req.ProjectID,
+		Title:           req.Title,
+		Description:     req.Description,
+		Status:          status,
+		Priority:        priority,
+		StartAt:         toUTC(req.StartAt),
+		DueAt:           toUTC(req.DueAt),
+		TimeZone:        timeZone,
+		StatusChangedAt: &now,
+		Tags:            tags,
+	}
+	if status == models.TaskStatusDone {
+		task.CompletedAt = &now
+	}
+	if req.Recurrence != "" {
+		rule, err := ParseRecurrenceRule(req.Recurrence)
+		if err != nil {
+			return nil, errors.New("invalid recurrence rule")
+		}
+		task.RecurrenceRule = rule.String()
+	}
+	if req.ParentID != nil {
+		parent, err := t.attachToParent(userID, task, *req.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		// Subtask tanpa project eksplisit mengikuti project parent-nya
+		if task.ProjectID == nil {
+			task.ProjectID = parent.ProjectID
+		}
 	}
-	if err := t.taskRepo.Create(task);  err != nil {
+	if err := validateTaskSchedule(task); err != nil {
+		return nil, err
+	}
+	if err := t.taskRepo.Create(task); err != nil {
 		return nil, errors.New("failed to create task")
 	}
-	return task, nil
+	t.indexTask(task)
+	return t.toTaskResponse(task), nil
 }
 
 // DeleteTask implements TaskService.
@@ -48,14 +132,22 @@ func (t *taskService) DeleteTask(userID uint, taskID uint) error {
 	if task.UserID != userID {
 		return errors.New("unauthorized to delete this task")
 	}
+	// Subtask ikut terhapus sehingga ID-nya dikumpulkan untuk dihapus dari index pencarian
+	removedIDs := []uint{task.ID}
+	if levels, err := t.taskRepo.FindSubtreeLevels(task.ID); err == nil {
+		for _, level := range levels {
+			removedIDs = append(removedIDs, level...)
+		}
+	}
 	if err := t.taskRepo.Delete(task); err != nil {
 		return errors.New("failed to delete task")
 	}
+	t.unindexTasks(removedIDs...)
 	return nil
 }
 
 // GetTasksByID implements TaskService.
-func (t *taskService) GetTasksByID(id uint) (*models.Task, error) {
+func (t *taskService) GetTasksByID(id uint) (*response.TaskResponse, error) {
 	task, err := t.taskRepo.FindByID(id)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -63,26 +155,62 @@ func (t *taskService) GetTasksByID(id uint) (*models.Task, error) {
 		}
 		return nil, errors.New("failed to retrieve task")
 	}
-	return task, nil
+	return t.toTaskResponse(task), nil
 }
 
-// GetTasksByUserID implements TaskService.
-func (t *taskService) GetTasksByUserID(userID uint) ([]models.Task, error) {
-	tasks, err := t.taskRepo.FindAllByUserID(userID)
+// GetTasksByProjectID implements TaskService.
+// Mengembalikan list kosong jika project belum punya task
+func (t *taskService) GetTasksByProjectID(userID uint, projectID uint) ([]response.TaskResponse, error) {
+	project, err := t.projectRepo.FindByID(projectID)
 	if err != nil {
-		return nil, errors.New("failed to retrieve tasks")
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("project not found")
+		}
+		return nil, errors.New("failed to retrieve project")
 	}
-
-	if len(tasks) == 0 {
-		return nil, errors.New("no tasks found for this user")
+	if project.UserID != userID {
+		return nil, errors.New("unauthorized to access this project")
 	}
 
-	return tasks, nil
+	tasks, err := t.taskRepo.FindAllByProjectID(project.ID)
+	if err != nil {
+		return nil, errors.New("failed to retrieve tasks")
+	}
+	taskResponses := make([]response.TaskResponse, 0, len(tasks))
+	for i := range tasks {
+		taskResponses = append(taskResponses, *t.toTaskResponse(&tasks[i]))
+	}
+	return taskResponses, nil
 }
 
+// MoveTask implements TaskService.
+// projectID nil memindahkan task ke inbox
+func (t *taskService) MoveTask(userID uint, taskID uint, projectID *uint) (*response.TaskResponse, error) {
+	task, err := t.taskRepo.FindByID(taskID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("task not found")
+		}
+		return nil, errors.New("failed to retrieve task")
+	}
+	if task.UserID != userID {
+		return nil, errors.New("unauthorized to update this task")
+	}
+	var project *models.Project
+	if projectID != nil {
+		if project, err = t.findWritableProject(userID, *projectID); err != nil {
+			return nil, err
+		}
+	}
+	if err := t.taskRepo.MoveToProject(task, projectID); err != nil {
+		return nil, errors.New("failed to move task")
+	}
+	task.Project = project
+	return t.toTaskResponse(task), nil
+}
 
 // UpdateTask implements TaskService.
-func (t *taskService) UpdateTask(userID uint, taskID uint, title *string, description *string, isCompleted *bool) (*models.Task, error) {
+func (t *taskService) UpdateTask(userID uint, taskID uint, req request.TaskUpdateRequest) (*response.TaskResponse, error) {
 	// 1️⃣ Ambil task berdasarkan ID
 	task, err := t.taskRepo.FindByID(taskID)
 	if err != nil {
@@ -98,25 +226,286 @@ func (t *taskService) UpdateTask(userID uint, taskID uint, title *string, descri
 	}
 
 	// 3️⃣ Update field yang dikirim (gunakan pointer agar bisa optional)
-	if title != nil {
-		task.Title = *title
+	if req.Title != nil {
+		task.Title = *req.Title
 	}
-	if description != nil {
-		task.Description = *description
+	if req.Description != nil {
+		task.Description = *req.Description
 	}
-	if isCompleted != nil {
-		task.IsCompleted = *isCompleted
+	if req.Priority != nil {
+		priority, ok := parseTaskPriority(*req.Priority)
+		if !ok {
+			return nil, errors.New("invalid priority")
+		}
+		task.Priority = priority
+	}
+	completing := false
+	if req.Status != nil && *req.Status != task.Status {
+		if err := t.changeStatus(task, *req.Status); err != nil {
+			return nil, err
+		}
+		completing = task.Status == models.TaskStatusDone
+	}
+	if req.ClearParent {
+		task.ParentID = nil
+	} else if req.ParentID != nil && (task.ParentID == nil || *task.ParentID != *req.ParentID) {
+		if _, err := t.attachToParent(userID, task, *req.ParentID); err != nil {
+			return nil, err
+		}
+	}
+	if req.TimeZone != nil {
+		if _, err := time.LoadLocation(*req.TimeZone); err != nil || *req.TimeZone == "" {
+			return nil, errors.New("invalid time zone")
+		}
+		task.TimeZone = *req.TimeZone
+	}
+	if req.TagIDs != nil {
+		tags, err := t.findOwnedTags(userID, *req.TagIDs)
+		if err != nil {
+			return nil, err
+		}
+		task.Tags = tags
+	}
+	if req.Recurrence != nil {
+		if *req.Recurrence == "" {
+			task.RecurrenceRule = ""
+		} else {
+			rule, err := ParseRecurrenceRule(*req.Recurrence)
+			if err != nil {
+				return nil, errors.New("invalid recurrence rule")
+			}
+			task.RecurrenceRule = rule.String()
+		}
+	}
+	if req.ClearStartAt {
+		task.StartAt = nil
+	} else if req.StartAt != nil {
+		task.StartAt = toUTC(req.StartAt)
+	}
+	if req.ClearDueAt {
+		task.DueAt = nil
+	} else if req.DueAt != nil {
+		task.DueAt = toUTC(req.DueAt)
+	}
+	if err := validateTaskSchedule(task); err != nil {
+		return nil, err
 	}
 
 	// 4️⃣ Simpan perubahan ke database
-	if err := t.taskRepo.Update(task); err != nil {
+	// Saat task diselesaikan, subtask ikut diproses dan occurrence berikutnya dibuat untuk task berulang
+	if completing {
+		if err := t.completeTask(task, req.CompleteSubtasks); err != nil {
+			if err.Error() == "task has incomplete subtasks" {
+				return nil, err
+			}
+			return nil, errors.New("failed to update task")
+		}
+		// Ambil ulang agar progress subtask sesuai dengan status terbaru
+		if reloaded, err := t.taskRepo.FindByID(task.ID); err == nil {
+			task = reloaded
+		}
+	} else if err := t.taskRepo.Update(task); err != nil {
 		return nil, errors.New("failed to update task")
 	}
+	t.indexTask(task)
+
+	return t.toTaskResponse(task), nil
+}
+
+// toTaskResponse mengubah model Task menjadi TaskResponse
+// StartAt dan DueAt ditampilkan di timezone milik task, dan DueState dihitung saat ini
+func (t *taskService) toTaskResponse(task *models.Task) *response.TaskResponse {
+	loc := taskLocation(task)
+	taskResponse := &response.TaskResponse{
+		ID:              task.ID,
+		Title:           task.Title,
+		Description:     task.Description,
+		Status:          task.Status,
+		Priority:        taskPriorityName(task.Priority),
+		TimeZone:        task.TimeZone,
+		CompletedAt:     task.CompletedAt,
+		StatusChangedAt: task.StatusChangedAt,
+		CreatedAt:       task.CreatedAt,
+		UpdatedAt:       task.UpdatedAt,
+		UserID:          task.UserID,
+		ProjectID:       taskProjectID(task),
+		Tags:            toTagResponses(task.Tags),
+		ParentID:        task.ParentID,
+		RecurrenceRule:  task.RecurrenceRule,
+		SeriesID:        task.SeriesID,
+		OccurrenceIndex: task.OccurrenceIndex,
+
+		Checklist:         toChecklistItemResponses(task.ChecklistItems),
+		ChecklistProgress: checklistProgress(task.ChecklistItems),
+		SubtaskProgress:   subtaskProgress(task.Children),
+	}
+	if task.StartAt != nil {
+		startAt := task.StartAt.In(loc)
+		taskResponse.StartAt = &startAt
+	}
+	if task.DueAt != nil {
+		dueAt := task.DueAt.In(loc)
+		taskResponse.DueAt = &dueAt
+	}
+	if task.DeletedAt.Valid {
+		deletedAt := task.DeletedAt.Time
+		taskResponse.DeletedAt = &deletedAt
+	}
+	if !isClosedTaskStatus(task.Status) {
+		taskResponse.DueState = computeDueState(task.DueAt, t.now().In(loc))
+	}
+	return taskResponse
+}
+
+// taskProjectID mengembalikan project task yang ditampilkan ke user
+// Task yang project-nya ada di trash tetap menyimpan project_id (agar kembali saat project di-restore)
+// tetapi ditampilkan sebagai task inbox; task.Project kosong karena preload tidak memuat project di trash
+func taskProjectID(task *models.Task) *uint {
+	if task.ProjectID == nil || task.Project == nil {
+		return nil
+	}
+	return task.ProjectID
+}
 
-	return task, nil
+// findWritableProject memastikan project milik user dan tidak sedang diarsipkan
+// sehingga task boleh ditambahkan ke dalamnya
+func (t *taskService) findWritableProject(userID, projectID uint) (*models.Project, error) {
+	project, err := t.projectRepo.FindByID(projectID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("project not found")
+		}
+		return nil, errors.New("failed to retrieve project")
+	}
+	if project.UserID != userID {
+		return nil, errors.New("unauthorized to access this project")
+	}
+	if project.ArchivedAt != nil {
+		return nil, errors.New("project is archived")
+	}
+	return project, nil
 }
 
+// findOwnedTags mengambil tag berdasarkan ID dan memastikan semuanya milik user
+func (t *taskService) findOwnedTags(userID uint, tagIDs []uint) ([]models.Tag, error) {
+	tagIDs = uniqueIDs(tagIDs)
+	tags, err := t.tagRepo.FindByIDs(userID, tagIDs)
+	if err != nil {
+		return nil, errors.New("failed to retrieve tags")
+	}
+	if len(tags) != len(tagIDs) {
+		return nil, errors.New("tag not found")
+	}
+	return tags, nil
+}
 
-func NewTaskService(taskRepo repositories.TaskRepository) TaskService {
-	return &taskService{taskRepo: taskRepo}
+// completeTask menyimpan task yang baru diubah ke done dalam satu transaksi bersama:
+// penyelesaian subtask (lihat subtasksToComplete) dan pembuatan occurrence berikutnya untuk task berulang
+func (t *taskService) completeTask(task *models.Task, completeSubtasks bool) error {
+	subtaskIDs, err := t.subtasksToComplete(task, completeSubtasks)
+	if err != nil {
+		return err
+	}
+	var occurrence *models.Task
+	err = t.taskRepo.Transaction(func(txRepo repositories.TaskRepository) error {
+		if err := txRepo.Update(task); err != nil {
+			return err
+		}
+		if err := txRepo.CompleteMany(subtaskIDs, *task.CompletedAt); err != nil {
+			return err
+		}
+		occurrence, err = t.spawnNextOccurrence(txRepo, task)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	// Index diperbarui setelah transaksi berhasil
+	if occurrence != nil {
+		t.indexTask(occurrence)
+	}
+	return nil
+}
+
+// changeStatus memindahkan task ke status baru sesuai workflow
+// dan mencatat waktu perubahan status serta waktu selesai
+func (t *taskService) changeStatus(task *models.Task, status string) error {
+	if !isValidTaskStatus(status) {
+		return errors.New("invalid status")
+	}
+	if !t.workflow.CanTransition(task.Status, status) {
+		return errors.New("status transition not allowed")
+	}
+	now := t.now().UTC()
+	task.Status = status
+	task.StatusChangedAt = &now
+	if status == models.TaskStatusDone {
+		task.CompletedAt = &now
+	} else {
+		task.CompletedAt = nil
+	}
+	return nil
+}
+
+// computeDueState menentukan due state berdasarkan due date dan waktu sekarang
+// now harus sudah berada di timezone task agar batas "hari ini" sesuai
+func computeDueState(dueAt *time.Time, now time.Time) string {
+	if dueAt == nil {
+		return models.DueStateNone
+	}
+	if dueAt.Before(now) {
+		return models.DueStateOverdue
+	}
+	startOfTomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+	if dueAt.Before(startOfTomorrow) {
+		return models.DueStateToday
+	}
+	return models.DueStateUpcoming
+}
+
+func isValidDueState(dueState string) bool {
+	switch dueState {
+	case models.DueStateNone, models.DueStateOverdue, models.DueStateToday, models.DueStateUpcoming:
+		return true
+	}
+	return false
+}
+
+// validateTaskSchedule memastikan start date tidak lebih dari due date
+func validateTaskSchedule(task *models.Task) error {
+	if task.StartAt != nil && task.DueAt != nil && task.StartAt.After(*task.DueAt) {
+		return errors.New("start date must be before due date")
+	}
+	return nil
+}
+
+// taskLocation mengembalikan *time.Location dari timezone task, fallback ke UTC
+func taskLocation(task *models.Task) *time.Location {
+	loc, err := time.LoadLocation(task.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// toUTC menormalisasi waktu ke UTC sebelum disimpan ke database
+func toUTC(value *time.Time) *time.Time {
+	if value == nil {
+		return nil
+	}
+	utc := value.UTC()
+	return &utc
+}
+
+func NewTaskService(taskRepo repositories.TaskRepository, projectRepo repositories.ProjectRepository, tagRepo repositories.TagRepository, searcher repositories.TaskSearcher, cfg *config.Config) TaskService {
+	workflow := DefaultTaskWorkflow
+	if cfg.TaskStatusTransitions != "" {
+		parsed, err := ParseTaskWorkflow(cfg.TaskStatusTransitions)
+		if err != nil {
+			log.Printf("Warning: %v, using default task workflow", err)
+		} else {
+			workflow = parsed
+		}
+	}
+	return &taskService{taskRepo: taskRepo, projectRepo: projectRepo, tagRepo: tagRepo, searcher: searcher, workflow: workflow, now: time.Now}
 }
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"errors"
+	"rest-api/internal/dto/response"
+	"rest-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// GetTrash implements TaskService.
+// Subtask yang terhapus bersama parent-nya tidak ditampilkan terpisah
+func (t *taskService) GetTrash(userID uint) ([]response.TaskResponse, error) {
+	tasks, err := t.taskRepo.FindTrashedByUserID(userID)
+	if err != nil {
+		return nil, errors.New("failed to retrieve tasks")
+	}
+	taskResponses := make([]response.TaskResponse, 0, len(tasks))
+	for i := range tasks {
+		taskResponses = append(taskResponses, *t.toTaskResponse(&tasks[i]))
+	}
+	return taskResponses, nil
+}
+
+// RestoreTask implements TaskService.
+// Subtask yang terhapus bersamaan ikut di-restore; subtask gagal di-restore selama parent-nya masih di trash
+func (t *taskService) RestoreTask(userID uint, taskID uint) (*response.TaskResponse, error) {
+	task, err := t.findTrashedTask(userID, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if task.ParentID != nil {
+		if _, err := t.taskRepo.FindByID(*task.ParentID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("parent task is in trash")
+			}
+			return nil, errors.New("failed to retrieve task")
+		}
+	}
+
+	restoredIDs, err := t.taskRepo.Restore(task)
+	if err != nil {
+		return nil, errors.New("failed to restore task")
+	}
+	restored, err := t.taskRepo.FindByIDs(restoredIDs)
+	if err != nil {
+		return nil, errors.New("failed to retrieve task")
+	}
+	for i := range restored {
+		t.indexTask(&restored[i])
+	}
+
+	reloaded, err := t.taskRepo.FindByID(task.ID)
+	if err != nil {
+		return nil, errors.New("failed to retrieve task")
+	}
+	return t.toTaskResponse(reloaded), nil
+}
+
+// PurgeTask implements TaskService.
+// Menghapus permanen task dari trash beserta seluruh subtask-nya
+func (t *taskService) PurgeTask(userID uint, taskID uint) error {
+	task, err := t.findTrashedTask(userID, taskID)
+	if err != nil {
+		return err
+	}
+	purgedIDs, err := t.taskRepo.Purge(task)
+	if err != nil {
+		return errors.New("failed to delete task")
+	}
+	t.unindexTasks(purgedIDs...)
+	return nil
+}
+
+// EmptyTrash implements TaskService.
+// Returns jumlah task yang dihapus permanen
+func (t *taskService) EmptyTrash(userID uint) (int, error) {
+	purgedIDs, err := t.taskRepo.PurgeTrashedByUserID(userID)
+	if err != nil {
+		return 0, errors.New("failed to empty trash")
+	}
+	t.unindexTasks(purgedIDs...)
+	return len(purgedIDs), nil
+}
+
+// findTrashedTask mengambil task dari trash dan memastikan task milik user
+func (t *taskService) findTrashedTask(userID, taskID uint) (*models.Task, error) {
+	task, err := t.taskRepo.FindTrashedByID(taskID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("task not found in trash")
+		}
+		return nil, errors.New("failed to retrieve task")
+	}
+	if task.UserID != userID {
+		return nil, errors.New("unauthorized to access this task")
+	}
+	return task, nil
+}
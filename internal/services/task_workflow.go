@@ -0,0 +1,97 @@
+package services
+
+import (
+	"errors"
+	"rest-api/internal/models"
+	"strings"
+)
+
+// TaskWorkflow memetakan setiap status ke daftar status tujuan yang diizinkan
+type TaskWorkflow map[string][]string
+
+// DefaultTaskWorkflow adalah workflow yang dipakai jika TASK_STATUS_TRANSITIONS tidak di-set
+var DefaultTaskWorkflow = TaskWorkflow{
+	models.TaskStatusTodo:       {models.TaskStatusInProgress, models.TaskStatusBlocked, models.TaskStatusDone, models.TaskStatusCancelled},
+	models.TaskStatusInProgress: {models.TaskStatusTodo, models.TaskStatusBlocked, models.TaskStatusDone, models.TaskStatusCancelled},
+	models.TaskStatusBlocked:    {models.TaskStatusTodo, models.TaskStatusInProgress, models.TaskStatusCancelled},
+	models.TaskStatusDone:       {models.TaskStatusTodo},
+	models.TaskStatusCancelled:  {models.TaskStatusTodo},
+}
+
+// taskPriorities memetakan nama priority di API ke nilai yang disimpan di database
+var taskPriorities = map[string]int{
+	"low":    models.TaskPriorityLow,
+	"medium": models.TaskPriorityMedium,
+	"high":   models.TaskPriorityHigh,
+	"urgent": models.TaskPriorityUrgent,
+}
+
+// CanTransition mengecek apakah perubahan status from -> to diizinkan
+func (w TaskWorkflow) CanTransition(from, to string) bool {
+	for _, allowed := range w[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseTaskWorkflow membaca workflow dari string konfigurasi
+// Format: "<status>:<tujuan>|<tujuan>;<status>:<tujuan>"
+// Contoh: "todo:in_progress|done;in_progress:done|todo;done:todo"
+// Status yang tidak disebutkan tidak bisa berpindah ke status lain
+func ParseTaskWorkflow(spec string) (TaskWorkflow, error) {
+	workflow := TaskWorkflow{}
+	for _, rule := range strings.Split(spec, ";") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		from, targets, found := strings.Cut(rule, ":")
+		from = strings.TrimSpace(from)
+		if !found || !isValidTaskStatus(from) {
+			return nil, errors.New("invalid task workflow rule: " + rule)
+		}
+		for _, to := range strings.Split(targets, "|") {
+			to = strings.TrimSpace(to)
+			if !isValidTaskStatus(to) || to == from {
+				return nil, errors.New("invalid task workflow rule: " + rule)
+			}
+			workflow[from] = append(workflow[from], to)
+		}
+	}
+	if len(workflow) == 0 {
+		return nil, errors.New("task workflow is empty")
+	}
+	return workflow, nil
+}
+
+func isValidTaskStatus(status string) bool {
+	switch status {
+	case models.TaskStatusTodo, models.TaskStatusInProgress, models.TaskStatusBlocked,
+		models.TaskStatusDone, models.TaskStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// isClosedTaskStatus mengembalikan true untuk status akhir (done/cancelled)
+func isClosedTaskStatus(status string) bool {
+	return status == models.TaskStatusDone || status == models.TaskStatusCancelled
+}
+
+// parseTaskPriority mengubah nama priority menjadi nilai integer
+func parseTaskPriority(priority string) (int, bool) {
+	value, ok := taskPriorities[priority]
+	return value, ok
+}
+
+// taskPriorityName mengubah nilai priority integer menjadi nama untuk response
+func taskPriorityName(priority int) string {
+	for name, value := range taskPriorities {
+		if value == priority {
+			return name
+		}
+	}
+	return "medium"
+}
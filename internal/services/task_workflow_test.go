@@ -0,0 +1,83 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTaskWorkflow(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    TaskWorkflow
+		wantErr bool
+	}{
+		{
+			name: "satu rule",
+			spec: "todo:done",
+			want: TaskWorkflow{"todo": {"done"}},
+		},
+		{
+			name: "beberapa rule dan tujuan dengan spasi",
+			spec: " todo: in_progress | done ; in_progress:done|todo;done:todo; ",
+			want: TaskWorkflow{
+				"todo":        {"in_progress", "done"},
+				"in_progress": {"done", "todo"},
+				"done":        {"todo"},
+			},
+		},
+		{
+			name: "status yang sama digabung",
+			spec: "todo:done;todo:cancelled",
+			want: TaskWorkflow{"todo": {"done", "cancelled"}},
+		},
+		{name: "kosong", spec: "", wantErr: true},
+		{name: "hanya pemisah", spec: " ; ;", wantErr: true},
+		{name: "tanpa titik dua", spec: "todo", wantErr: true},
+		{name: "status asal tidak dikenal", spec: "open:done", wantErr: true},
+		{name: "status tujuan tidak dikenal", spec: "todo:finished", wantErr: true},
+		{name: "tujuan kosong", spec: "todo:", wantErr: true},
+		{name: "tujuan sama dengan asal", spec: "todo:todo", wantErr: true},
+		{name: "satu rule salah membatalkan semua", spec: "todo:done;done:archived", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTaskWorkflow(tt.spec)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseTaskWorkflow(%q) = %v, want error", tt.spec, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseTaskWorkflow(%q): %v", tt.spec, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseTaskWorkflow(%q) = %v, want %v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTaskWorkflowCanTransition(t *testing.T) {
+	workflow, err := ParseTaskWorkflow("todo:in_progress|done;done:todo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		from, to string
+		want     bool
+	}{
+		{"todo", "in_progress", true},
+		{"todo", "done", true},
+		{"done", "todo", true},
+		{"done", "in_progress", false},
+		{"in_progress", "done", false}, // Status yang tidak disebutkan tidak bisa berpindah
+		{"todo", "cancelled", false},
+	}
+	for _, tt := range tests {
+		if got := workflow.CanTransition(tt.from, tt.to); got != tt.want {
+			t.Errorf("CanTransition(%q, %q) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"rest-api/internal/models"
+	"rest-api/internal/repositories"
+	"rest-api/internal/secretbox"
+	"testing"
+	"time"
+)
+
+// rfc6238Secret adalah secret SHA1 dari test vector RFC 6238 ("12345678901234567890") dalam base32
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCode(t *testing.T) {
+	// Test vector RFC 6238 memakai 8 digit; kode 6 digit adalah 6 digit terakhirnya
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+	for _, tt := range tests {
+		got, err := totpCode(rfc6238Secret, tt.unix/totpPeriod)
+		if err != nil {
+			t.Fatalf("totpCode(%d): %v", tt.unix, err)
+		}
+		if got != tt.want {
+			t.Errorf("totpCode(%d) = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	current := now.Unix() / totpPeriod
+	code := func(step int64) string {
+		c, err := totpCode(rfc6238Secret, step)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+
+	tests := []struct {
+		name     string
+		secret   string
+		code     string
+		wantStep int64
+		wantOK   bool
+	}{
+		{name: "step saat ini", secret: rfc6238Secret, code: code(current), wantStep: current, wantOK: true},
+		{name: "secret huruf kecil", secret: "gezdgnbvgy3tqojqgezdgnbvgy3tqojq", code: code(current), wantStep: current, wantOK: true},
+		{name: "satu step sebelumnya", secret: rfc6238Secret, code: code(current - 1), wantStep: current - 1, wantOK: true},
+		{name: "satu step sesudahnya", secret: rfc6238Secret, code: code(current + 1), wantStep: current + 1, wantOK: true},
+		{name: "dua step sebelumnya", secret: rfc6238Secret, code: code(current - 2)},
+		{name: "dua step sesudahnya", secret: rfc6238Secret, code: code(current + 2)},
+		{name: "kode salah", secret: rfc6238Secret, code: "000000"},
+		{name: "kode terlalu pendek", secret: rfc6238Secret, code: code(current)[:5]},
+		{name: "kode 8 digit", secret: rfc6238Secret, code: "14050471"},
+		{name: "secret bukan base32", secret: "not base32!", code: "123456"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := validateTOTP(tt.secret, tt.code, now)
+			if ok != tt.wantOK {
+				t.Fatalf("validateTOTP ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && step != tt.wantStep {
+				t.Errorf("validateTOTP step = %d, want %d", step, tt.wantStep)
+			}
+		})
+	}
+}
+
+// fakeTwoFactorRepository menyimpan step terakhir di memory, sama seperti kolom totp_last_step
+type fakeTwoFactorRepository struct {
+	repositories.TwoFactorRepository
+	lastStep int64
+}
+
+func (r *fakeTwoFactorRepository) AdvanceLastStep(userID uint, step int64) (bool, error) {
+	if step <= r.lastStep {
+		return false, nil
+	}
+	r.lastStep = step
+	return true, nil
+}
+
+func TestVerifyTOTPCode(t *testing.T) {
+	key := secretbox.DeriveKey("test two factor key")
+	sealed, err := secretbox.Seal(key, rfc6238Secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := &models.User{ID: 1, TOTPSecret: sealed}
+	code, err := totpCode(rfc6238Secret, time.Now().Unix()/totpPeriod)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spaced := " " + code[:3] + " " + code[3:] + " "
+
+	repo := &fakeTwoFactorRepository{}
+	if ok, err := verifyTOTPCode(repo, key, user, spaced); err != nil || !ok {
+		t.Fatalf("verifyTOTPCode(valid code with spaces) = %v, %v; want true", ok, err)
+	}
+	// Kode yang sama tidak boleh dipakai dua kali
+	if ok, err := verifyTOTPCode(repo, key, user, code); err != nil || ok {
+		t.Errorf("verifyTOTPCode(reused code) = %v, %v; want false", ok, err)
+	}
+
+	if ok, err := verifyTOTPCode(&fakeTwoFactorRepository{}, key, &models.User{ID: 2}, code); err != nil || ok {
+		t.Errorf("verifyTOTPCode(user without 2FA) = %v, %v; want false", ok, err)
+	}
+	// Secret yang dienkripsi dengan kunci lain tidak bisa dibuka
+	if _, err := verifyTOTPCode(&fakeTwoFactorRepository{}, secretbox.DeriveKey("other key"), user, code); err == nil {
+		t.Error("verifyTOTPCode(wrong key) succeeded, want error")
+	}
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"log"
+	"rest-api/config"
+	"rest-api/internal/repositories"
+	"time"
+)
+
+// Nilai default pembersihan trash
+const (
+	defaultTrashRetention = 30 * 24 * time.Hour
+	trashPurgeInterval    = time.Hour
+)
+
+// StartTrashPurger menjalankan goroutine yang secara berkala menghapus permanen
+// task dan project yang sudah berada di trash lebih lama dari TRASH_RETENTION
+// TRASH_RETENTION=0 menonaktifkan pembersihan otomatis
+func StartTrashPurger(taskRepo repositories.TaskRepository, projectRepo repositories.ProjectRepository, searcher repositories.TaskSearcher, cfg *config.Config) {
+	retention := defaultTrashRetention
+	if cfg.TrashRetention != "" {
+		parsed, err := time.ParseDuration(cfg.TrashRetention)
+		if err != nil || parsed < 0 {
+			log.Printf("Warning: invalid TRASH_RETENTION %q, using default %s", cfg.TrashRetention, defaultTrashRetention)
+		} else {
+			retention = parsed
+		}
+	}
+	if retention == 0 {
+		log.Println("Trash purge disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(trashPurgeInterval)
+		defer ticker.Stop()
+		for {
+			purgeExpiredTrash(taskRepo, projectRepo, searcher, time.Now().UTC().Add(-retention))
+			<-ticker.C
+		}
+	}()
+}
+
+// purgeExpiredTrash menghapus permanen isi trash yang dihapus sebelum cutoff
+func purgeExpiredTrash(taskRepo repositories.TaskRepository, projectRepo repositories.ProjectRepository, searcher repositories.TaskSearcher, cutoff time.Time) {
+	purgedIDs, err := taskRepo.PurgeDeletedBefore(cutoff)
+	if err != nil {
+		log.Printf("Warning: failed to purge trashed tasks: %v", err)
+	} else if len(purgedIDs) > 0 {
+		if err := searcher.Remove(purgedIDs...); err != nil {
+			log.Printf("Warning: failed to remove purged tasks from search index: %v", err)
+		}
+		log.Printf("🗑️ Purged %d task(s) from trash", len(purgedIDs))
+	}
+
+	purgedProjects, err := projectRepo.PurgeDeletedBefore(cutoff)
+	if err != nil {
+		log.Printf("Warning: failed to purge trashed projects: %v", err)
+	} else if purgedProjects > 0 {
+		log.Printf("🗑️ Purged %d project(s) from trash", purgedProjects)
+	}
+}
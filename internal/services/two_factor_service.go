@@ -0,0 +1,227 @@
+package services
+
+import (
+	"crypto/rand"
+	"errors"
+	"rest-api/config"
+	"rest-api/internal/dto/response"
+	"rest-api/internal/models"
+	"rest-api/internal/password"
+	"rest-api/internal/repositories"
+	"rest-api/internal/secretbox"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	recoveryCodeCount  = 10
+	recoveryCodeLength = 10 // Karakter base32 (50-bit), ditampilkan sebagai xxxxx-xxxxx
+)
+
+type TwoFactorService interface {
+	Enroll(userID uint) (*response.TwoFactorEnrollResponse, error)
+	Confirm(userID uint, code string) ([]string, error)
+	Disable(userID uint, password, code string) error
+	RegenerateRecoveryCodes(userID uint, code string) ([]string, error)
+}
+
+type twoFactorService struct {
+	userRepo      repositories.UserRepository
+	twoFactorRepo repositories.TwoFactorRepository
+	hasher        password.Hasher
+	cfg           *config.Config
+}
+
+// Enroll implements TwoFactorService.
+// Membuat secret baru; 2FA belum aktif sampai dikonfirmasi dengan kode dari authenticator
+func (s *twoFactorService) Enroll(userID uint) (*response.TwoFactorEnrollResponse, error) {
+	user, err := s.findUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TwoFactorEnabledAt != nil {
+		return nil, errors.New("two-factor authentication is already enabled")
+	}
+
+	secret, err := newTOTPSecret()
+	if err != nil {
+		return nil, errors.New("failed to start two-factor enrollment")
+	}
+	encrypted, err := secretbox.Seal(twoFactorKey(s.cfg), secret)
+	if err != nil {
+		return nil, errors.New("failed to start two-factor enrollment")
+	}
+	if err := s.twoFactorRepo.SaveSecret(user.ID, encrypted); err != nil {
+		return nil, errors.New("failed to start two-factor enrollment")
+	}
+
+	return &response.TwoFactorEnrollResponse{
+		Secret:     secret,
+		OTPAuthURI: totpURI(s.cfg.TwoFactorIssuer, user.Email, secret),
+	}, nil
+}
+
+// Confirm implements TwoFactorService.
+// Mengaktifkan 2FA dan mengembalikan recovery code (hanya ditampilkan sekali)
+func (s *twoFactorService) Confirm(userID uint, code string) ([]string, error) {
+	user, err := s.findUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TwoFactorEnabledAt != nil {
+		return nil, errors.New("two-factor authentication is already enabled")
+	}
+	if user.TOTPSecret == "" {
+		return nil, errors.New("two-factor enrollment has not been started")
+	}
+	// Konfirmasi hanya menerima kode TOTP karena recovery code belum ada
+	ok, err := verifyTOTPCode(s.twoFactorRepo, twoFactorKey(s.cfg), user, code)
+	if err != nil {
+		return nil, errors.New("failed to confirm two-factor authentication")
+	}
+	if !ok {
+		return nil, errors.New("invalid two-factor code")
+	}
+
+	codes, err := s.replaceRecoveryCodes(user.ID)
+	if err != nil {
+		return nil, errors.New("failed to confirm two-factor authentication")
+	}
+	if err := s.twoFactorRepo.Enable(user.ID, time.Now().UTC()); err != nil {
+		return nil, errors.New("failed to confirm two-factor authentication")
+	}
+	return codes, nil
+}
+
+// Disable implements TwoFactorService.
+func (s *twoFactorService) Disable(userID uint, password string, code string) error {
+	user, err := s.findUser(userID)
+	if err != nil {
+		return err
+	}
+	if user.TwoFactorEnabledAt == nil {
+		return errors.New("two-factor authentication is not enabled")
+	}
+	if ok, _ := s.hasher.Verify(user.Password, password); !ok {
+		return errors.New("incorrect password")
+	}
+	ok, err := verifySecondFactor(s.twoFactorRepo, twoFactorKey(s.cfg), user, code)
+	if err != nil {
+		return errors.New("failed to disable two-factor authentication")
+	}
+	if !ok {
+		return errors.New("invalid two-factor code")
+	}
+	if err := s.twoFactorRepo.Disable(user.ID); err != nil {
+		return errors.New("failed to disable two-factor authentication")
+	}
+	return nil
+}
+
+// RegenerateRecoveryCodes implements TwoFactorService.
+// Semua recovery code lama tidak berlaku lagi
+func (s *twoFactorService) RegenerateRecoveryCodes(userID uint, code string) ([]string, error) {
+	user, err := s.findUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TwoFactorEnabledAt == nil {
+		return nil, errors.New("two-factor authentication is not enabled")
+	}
+	ok, err := verifySecondFactor(s.twoFactorRepo, twoFactorKey(s.cfg), user, code)
+	if err != nil {
+		return nil, errors.New("failed to regenerate recovery codes")
+	}
+	if !ok {
+		return nil, errors.New("invalid two-factor code")
+	}
+	codes, err := s.replaceRecoveryCodes(user.ID)
+	if err != nil {
+		return nil, errors.New("failed to regenerate recovery codes")
+	}
+	return codes, nil
+}
+
+func (s *twoFactorService) findUser(userID uint) (*models.User, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("user not found")
+		}
+		return nil, errors.New("failed to retrieve user")
+	}
+	return user, nil
+}
+
+// replaceRecoveryCodes membuat recovery code baru dan menyimpan hash-nya
+func (s *twoFactorService) replaceRecoveryCodes(userID uint) ([]string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		code, err := newRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, hashToken(normalizeRecoveryCode(code)))
+	}
+	if err := s.twoFactorRepo.ReplaceRecoveryCodes(userID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// verifySecondFactor menerima kode TOTP atau recovery code (recovery code langsung hangus)
+func verifySecondFactor(repo repositories.TwoFactorRepository, key []byte, user *models.User, code string) (bool, error) {
+	if ok, err := verifyTOTPCode(repo, key, user, code); ok || err != nil {
+		return ok, err
+	}
+	normalized := normalizeRecoveryCode(code)
+	if len(normalized) != recoveryCodeLength {
+		return false, nil
+	}
+	return repo.UseRecoveryCode(user.ID, hashToken(normalized), time.Now().UTC())
+}
+
+// verifyTOTPCode mengecek kode TOTP dan menolak kode dari step yang sudah pernah dipakai
+func verifyTOTPCode(repo repositories.TwoFactorRepository, key []byte, user *models.User, code string) (bool, error) {
+	if user.TOTPSecret == "" {
+		return false, nil
+	}
+	secret, err := secretbox.Open(key, user.TOTPSecret)
+	if err != nil {
+		return false, err
+	}
+	step, ok := validateTOTP(secret, strings.ReplaceAll(strings.TrimSpace(code), " ", ""), time.Now())
+	if !ok {
+		return false, nil
+	}
+	return repo.AdvanceLastStep(user.ID, step)
+}
+
+// newRecoveryCode membuat recovery code acak dengan format xxxxx-xxxxx
+func newRecoveryCode() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	code := strings.ToLower(totpEncoding.EncodeToString(b))[:recoveryCodeLength]
+	return code[:5] + "-" + code[5:], nil
+}
+
+// normalizeRecoveryCode mengabaikan huruf besar, spasi dan tanda hubung saat recovery code diketik user
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
+
+// twoFactorKey mengembalikan kunci enkripsi secret TOTP
+func twoFactorKey(cfg *config.Config) []byte {
+	return secretbox.DeriveKey(cfg.TwoFactorKey())
+}
+
+func NewTwoFactorService(userRepo repositories.UserRepository, twoFactorRepo repositories.TwoFactorRepository, hasher password.Hasher, cfg *config.Config) TwoFactorService {
+	return &twoFactorService{userRepo: userRepo, twoFactorRepo: twoFactorRepo, hasher: hasher, cfg: cfg}
+}
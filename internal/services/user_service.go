@@ -2,23 +2,37 @@ package services
 
 import (
 	"errors"
+	"fmt"
+	"log"
+	"rest-api/config"
 	"rest-api/internal/dto/response"
+	"rest-api/internal/mailer"
+	"rest-api/internal/models"
+	"rest-api/internal/password"
 	"rest-api/internal/repositories"
+	"time"
 
-	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
 type UserService interface {
 	GetUserByID(id uint) (*response.UserResponse, error)
-	UpdateUser(currentUserID, targetUserID uint, username, email, password *string) (*response.UserResponse, error)
+	UpdateUser(currentUserID, targetUserID uint, username, email, password, currentPassword *string, currentFamilyID string, client ClientInfo) (*response.UserResponse, error)
 	CheckUsernameAvailability(username string, excludeUserID uint) error
 	CheckEmailAvailability(email string, excludeUserID uint) error
 	GetProfile(userID uint) (*response.UserResponse, error)
 }
 
 type userService struct {
-	userRepo repositories.UserRepository
+	userRepo      repositories.UserRepository
+	userTokenRepo repositories.UserTokenRepository
+	sessionRepo   repositories.SessionRepository
+	tokenRepo     repositories.TokenRepository
+	throttle      LoginThrottle
+	hasher        password.Hasher
+	policy        *password.Policy
+	mailer        mailer.Mailer
+	cfg           *config.Config
 }
 
 // GetProfile implements UserService.
@@ -30,13 +44,7 @@ func (s *userService) GetProfile(userID uint) (*response.UserResponse, error) {
 		}
 		return nil, errors.New("gagal mengambil profil")
 	}
-	userResponse := &response.UserResponse{
-		ID:        user.ID,
-		Username:  user.Username,
-		Email:     user.Email,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
-	}
+	userResponse := toUserResponse(user)
 	return userResponse, nil
 }
 
@@ -74,18 +82,15 @@ func (s *userService) GetUserByID(id uint) (*response.UserResponse, error) {
 		return nil, errors.New("failed to retrieve user")
 	}
 
-	userResponse := &response.UserResponse{
-		ID:        user.ID,
-		Username:  user.Username,
-		Email:     user.Email,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
-	}
+	userResponse := toUserResponse(user)
 	return userResponse, nil
 }
 
 // UpdateUser implements UserService.
-func (s *userService) UpdateUser(currentUserID uint, targetUserID uint, username *string, email *string, password *string) (*response.UserResponse, error) {
+// Mengganti password atau email butuh password saat ini, agar token atau session yang dicuri tidak cukup untuk mengambil alih akun
+// Password saat ini yang salah dihitung LoginThrottle seperti login gagal, agar session curian tidak bisa dipakai menebak password
+// Setelah password diganti, semua session lain diakhiri; session currentFamilyID (yang dipakai request ini) tetap login
+func (s *userService) UpdateUser(currentUserID uint, targetUserID uint, username *string, email *string, password *string, currentPassword *string, currentFamilyID string, client ClientInfo) (*response.UserResponse, error) {
 	user, err := s.userRepo.FindByID(targetUserID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -97,11 +102,32 @@ func (s *userService) UpdateUser(currentUserID uint, targetUserID uint, username
 		return nil, errors.New("unauthorized to update this user")
 	}
 
-	if email != nil && *email != user.Email {
+	changingEmail := email != nil && *email != user.Email
+	if password != nil || changingEmail {
+		if currentPassword == nil || *currentPassword == "" {
+			return nil, errors.New("current password is required")
+		}
+		if err := s.throttle.Check(user.Email, client.IPAddress); err != nil {
+			return nil, err
+		}
+		ok, err := s.hasher.Verify(user.Password, *currentPassword)
+		if err != nil {
+			return nil, errors.New("failed to verify current password")
+		}
+		if !ok {
+			s.throttle.RecordFailure(user.Email, client.IPAddress, &user.ID)
+			return nil, errors.New("current password is incorrect")
+		}
+		s.throttle.RecordSuccess(user.Email)
+	}
+
+	// Email baru baru berlaku setelah dikonfirmasi lewat link yang dikirim ke alamat tersebut
+	pendingEmail := ""
+	if changingEmail {
 		if err := s.CheckEmailAvailability(*email, currentUserID); err != nil {
 			return nil, err
 		}
-		user.Email = *email
+		pendingEmail = *email
 	}
 	if username != nil && *username != user.Username {
 		if err := s.CheckUsernameAvailability(*username, currentUserID); err != nil {
@@ -111,27 +137,72 @@ func (s *userService) UpdateUser(currentUserID uint, targetUserID uint, username
 	}
 
 	if password != nil {
-		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(*password), 10)
+		// Dicek terhadap username baru dan email lama maupun email yang menunggu konfirmasi
+		if err := s.policy.Validate(*password, user.Username, user.Email, pendingEmail); err != nil {
+			return nil, err
+		}
+		hashedPassword, err := s.hasher.Hash(*password)
 		if err != nil {
 			return nil, errors.New("failed to hash password")
 		}
-		user.Password = string(hashedPassword)
+		user.Password = hashedPassword
 	}
 
 	if err := s.userRepo.Update(user); err != nil {
 		return nil, errors.New("failed to update user")
 	}
-
-	userResponse := &response.UserResponse{
-		ID:        user.ID,
-		Username:  user.Username,
-		Email:     user.Email,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+	if password != nil {
+		// Login yang mungkin dipegang penyerang tidak boleh bertahan setelah password diganti
+		now := time.Now().UTC()
+		if err := s.sessionRepo.RevokeOthersByUserID(user.ID, currentFamilyID, now); err != nil {
+			log.Printf("Warning: failed to revoke sessions for user %d: %v", user.ID, err)
+		}
+		if err := s.tokenRepo.RevokeOthersByUserID(user.ID, currentFamilyID, now); err != nil {
+			log.Printf("Warning: failed to revoke tokens for user %d: %v", user.ID, err)
+		}
+	}
+	if pendingEmail != "" {
+		if err := s.requestEmailChange(user, pendingEmail); err != nil {
+			return nil, errors.New("failed to request email change")
+		}
 	}
+
+	userResponse := toUserResponse(user)
+	userResponse.PendingEmail = pendingEmail
 	return userResponse, nil
 }
 
-func NewUserService(userRepo repositories.UserRepository) UserService {
-	return &userService{userRepo: userRepo}
+// requestEmailChange mengirim link konfirmasi ke alamat email baru
+func (s *userService) requestEmailChange(user *models.User, newEmail string) error {
+	ttl := parseTTL(s.cfg.EmailVerificationExpires, defaultEmailVerificationTTL)
+	token, err := issueUserToken(s.userTokenRepo, user.ID, models.UserTokenEmailChange, newEmail, ttl)
+	if err != nil {
+		return err
+	}
+	sendMailAsync(s.mailer, mailer.Message{
+		To:      newEmail,
+		Subject: "Confirm your new email address",
+		Body: fmt.Sprintf("Hi %s,\n\nOpen the link below to use this address for your account:\n\n%s\n\n"+
+			"The link expires in %s. Until then your account keeps using %s.\n",
+			user.Username, tokenLink(s.cfg, "verify-email", token), ttl, user.Email),
+	})
+	return nil
+}
+
+// toUserResponse mengubah model User menjadi response (tanpa password)
+func toUserResponse(user *models.User) *response.UserResponse {
+	return &response.UserResponse{
+		ID:               user.ID,
+		Username:         user.Username,
+		Email:            user.Email,
+		EmailVerified:    user.EmailVerifiedAt != nil,
+		TwoFactorEnabled: user.TwoFactorEnabledAt != nil,
+		Role:             user.Role,
+		CreatedAt:        user.CreatedAt,
+		UpdatedAt:        user.UpdatedAt,
+	}
+}
+
+func NewUserService(userRepo repositories.UserRepository, userTokenRepo repositories.UserTokenRepository, sessionRepo repositories.SessionRepository, tokenRepo repositories.TokenRepository, throttle LoginThrottle, hasher password.Hasher, policy *password.Policy, mailer mailer.Mailer, cfg *config.Config) UserService {
+	return &userService{userRepo: userRepo, userTokenRepo: userTokenRepo, sessionRepo: sessionRepo, tokenRepo: tokenRepo, throttle: throttle, hasher: hasher, policy: policy, mailer: mailer, cfg: cfg}
 }
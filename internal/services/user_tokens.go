@@ -0,0 +1,59 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"rest-api/config"
+	"rest-api/internal/mailer"
+	"rest-api/internal/models"
+	"rest-api/internal/repositories"
+	"strings"
+	"time"
+)
+
+// issueUserToken membuat token sekali pakai untuk purpose tertentu dan mengembalikan token aslinya
+// Token lama dengan purpose yang sama dibatalkan sehingga hanya link terbaru yang berlaku
+func issueUserToken(repo repositories.UserTokenRepository, userID uint, purpose, email string, ttl time.Duration) (string, error) {
+	token, err := newRandomToken()
+	if err != nil {
+		return "", err
+	}
+	now := time.Now().UTC()
+	if err := repo.InvalidateByUserID(userID, purpose, now); err != nil {
+		return "", err
+	}
+	if err := repo.Create(&models.UserToken{
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: hashToken(token),
+		Email:     email,
+		ExpiresAt: now.Add(ttl),
+	}); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// parseTTL membaca durasi dari config, fallback jika kosong atau tidak valid
+func parseTTL(value string, fallback time.Duration) time.Duration {
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl <= 0 {
+		return fallback
+	}
+	return ttl
+}
+
+// tokenLink membuat link frontend yang membawa token, contoh: <APP_URL>/reset-password?token=...
+func tokenLink(cfg *config.Config, path, token string) string {
+	return fmt.Sprintf("%s/%s?token=%s", strings.TrimRight(cfg.AppURL, "/"), path, url.QueryEscape(token))
+}
+
+// sendMailAsync mengirim email di background agar waktu response tidak bergantung pada server email
+func sendMailAsync(m mailer.Mailer, msg mailer.Message) {
+	go func() {
+		if err := m.Send(msg); err != nil {
+			log.Printf("Warning: failed to send email %q to %s: %v", msg.Subject, msg.To, err)
+		}
+	}()
+}
@@ -0,0 +1,150 @@
+// Package validation runs the validate struct tags on request DTOs
+// Error dikembalikan per field memakai nama dari tag json, lengkap dengan rule dan pesan yang bisa ditampilkan ke user
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var (
+	// usernamePattern sama dengan karakter yang dipakai saat membuat username dari OIDC
+	usernamePattern = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+	// colorPattern sama dengan warna yang diterima service project/tag, contoh: #ff8800
+	colorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+)
+
+// FieldError adalah satu field yang tidak lolos validasi
+type FieldError struct {
+	Field   string `json:"field"`   // Nama field di JSON, contoh: "title" atau "changes.title"
+	Rule    string `json:"rule"`    // Tag validate yang dilanggar, contoh: "required" atau "max"
+	Message string `json:"message"` // Pesan untuk ditampilkan ke user
+}
+
+// Errors dikembalikan Struct jika satu atau lebih field tidak valid
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	return "validation failed"
+}
+
+var (
+	once     sync.Once
+	validate *validator.Validate
+)
+
+// instance membuat validator sekali; validator meng-cache metadata struct sehingga aman dipakai bersama
+func instance() *validator.Validate {
+	once.Do(func() {
+		validate = validator.New(validator.WithRequiredStructEnabled())
+		validate.RegisterTagNameFunc(fieldName)
+		register("username", usernamePattern)
+		register("color", colorPattern)
+	})
+	return validate
+}
+
+// register menambahkan tag validate untuk string yang harus cocok dengan pattern
+// String kosong dianggap valid; pakai required jika field wajib diisi
+func register(tag string, pattern *regexp.Regexp) {
+	if err := validate.RegisterValidation(tag, func(fl validator.FieldLevel) bool {
+		value := fl.Field().String()
+		return value == "" || pattern.MatchString(value)
+	}); err != nil {
+		panic(err)
+	}
+}
+
+// fieldName memakai nama dari tag json (atau query) agar error sesuai dengan nama di request
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "query"} {
+		name := strings.SplitN(field.Tag.Get(tag), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// Struct memvalidasi v (pointer ke struct atau struct) dan mengembalikan Errors, atau nil jika valid
+func Struct(v interface{}) error {
+	err := instance().Struct(v)
+	if err == nil {
+		return nil
+	}
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return err
+	}
+	fieldErrors := make(Errors, 0, len(validationErrors))
+	for _, fe := range validationErrors {
+		field := fieldPath(fe)
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:   field,
+			Rule:    fe.Tag(),
+			Message: message(field, fe),
+		})
+	}
+	return fieldErrors
+}
+
+// fieldPath membuang nama struct dari namespace, contoh: TaskBulkRequest.changes.title -> changes.title
+func fieldPath(fe validator.FieldError) string {
+	namespace := fe.Namespace()
+	if dot := strings.Index(namespace, "."); dot >= 0 {
+		return namespace[dot+1:]
+	}
+	return namespace
+}
+
+// message membuat pesan yang bisa dibaca manusia untuk rule yang dilanggar
+func message(field string, fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required", "required_with":
+		return fmt.Sprintf("%s is required.", field)
+	case "email":
+		return fmt.Sprintf("%s must be a valid email address.", field)
+	case "min":
+		if fe.Param() == "1" {
+			return fmt.Sprintf("%s must not be empty.", field)
+		}
+		return fmt.Sprintf("%s must be at least %s.", field, limit(fe))
+	case "max":
+		return fmt.Sprintf("%s must be at most %s.", field, limit(fe))
+	case "len":
+		return fmt.Sprintf("%s must be exactly %s.", field, limit(fe))
+	case "oneof":
+		return fmt.Sprintf("%s must be one of: %s.", field, strings.Join(strings.Fields(fe.Param()), ", "))
+	case "color":
+		return fmt.Sprintf("%s must be a hex color such as #1e90ff.", field)
+	case "timezone":
+		return fmt.Sprintf("%s must be an IANA time zone such as Asia/Jakarta.", field)
+	case "username":
+		return fmt.Sprintf("%s may only contain letters, digits, dots, dashes, and underscores.", field)
+	case "gt":
+		return fmt.Sprintf("%s must be greater than %s.", field, fe.Param())
+	case "gte":
+		return fmt.Sprintf("%s must be at least %s.", field, fe.Param())
+	}
+	return fmt.Sprintf("%s is invalid.", field)
+}
+
+// limit menjelaskan param min/max/len sesuai tipe field: karakter untuk string, item untuk slice, angka untuk lainnya
+func limit(fe validator.FieldError) string {
+	switch fe.Kind() {
+	case reflect.String:
+		return fe.Param() + " characters long"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return fe.Param() + " items"
+	}
+	return fe.Param()
+}